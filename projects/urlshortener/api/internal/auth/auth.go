@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"urlshortener/internal/domain"
+)
+
+const (
+	keyPrefix    = "usk_"
+	secretLength = 32
+	// PrefixLength is how much of a key is stored in clear to tell keys apart.
+	PrefixLength = len(keyPrefix) + 8
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the authenticated key.
+func NewContext(ctx context.Context, key *domain.APIKey) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// FromContext returns the authenticated key, or nil for anonymous requests.
+func FromContext(ctx context.Context) *domain.APIKey {
+	key, _ := ctx.Value(contextKey{}).(*domain.APIKey)
+	return key
+}
+
+type clientIPKey struct{}
+
+// WithClientIP returns a copy of ctx carrying the caller's IP address.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the caller's IP address, or "" if it is unknown.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// GenerateKey returns a new random key. Keys carry 256 bits of entropy, so a
+// fast unsalted hash is enough to store them and still allows lookup by hash.
+func GenerateKey() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func HashKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
@@ -1,7 +1,11 @@
 package cache
 
 import (
+	"time"
+
 	"github.com/dgraph-io/ristretto"
+
+	"urlshortener/internal/domain"
 )
 
 type URLCache struct {
@@ -24,17 +28,32 @@ func New(maxSizePow2 int) (*URLCache, error) {
 	return &URLCache{cache: cache}, nil
 }
 
-func (c *URLCache) Get(shortCode string) (string, bool) {
+func (c *URLCache) Get(shortCode string) (*domain.URL, bool) {
 	val, found := c.cache.Get(shortCode)
 	if !found {
-		return "", false
+		return nil, false
 	}
-	return val.(string), true
+	return val.(*domain.URL), true
+}
+
+// Set caches u until its expiry, so an expired link is never served from memory.
+// Links that have already expired are not cached.
+func (c *URLCache) Set(u *domain.URL) {
+	cost := int64(len(u.ShortCode) + len(u.OriginalURL) + len(u.PasswordHash) + u.Rules.Size())
+
+	var ttl time.Duration
+	if u.ExpiresAt != nil {
+		ttl = time.Until(*u.ExpiresAt)
+		if ttl <= 0 {
+			return
+		}
+	}
+
+	c.cache.SetWithTTL(u.ShortCode, u, cost, ttl)
 }
 
-func (c *URLCache) Set(shortCode, originalURL string) {
-	cost := int64(len(shortCode) + len(originalURL))
-	c.cache.Set(shortCode, originalURL, cost)
+func (c *URLCache) Delete(shortCode string) {
+	c.cache.Del(shortCode)
 }
 
 func (c *URLCache) Close() {
@@ -8,8 +8,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"urlshortener/internal/cache"
+	"urlshortener/internal/domain"
 )
 
+func link(shortCode, originalURL string) *domain.URL {
+	return &domain.URL{ShortCode: shortCode, OriginalURL: originalURL}
+}
+
 func TestNew_ValidSize(t *testing.T) {
 	c, err := cache.New(10) // 2^10 = 1KB
 	require.NoError(t, err)
@@ -31,7 +36,7 @@ func TestGet_MissingKey(t *testing.T) {
 
 	val, found := c.Get("nonexistent")
 	assert.False(t, found)
-	assert.Empty(t, val)
+	assert.Nil(t, val)
 }
 
 func TestSetThenGet(t *testing.T) {
@@ -42,12 +47,12 @@ func TestSetThenGet(t *testing.T) {
 	shortCode := "abc123"
 	originalURL := "https://example.com/very/long/path"
 
-	c.Set(shortCode, originalURL)
+	c.Set(link(shortCode, originalURL))
 	time.Sleep(10 * time.Millisecond) // Ristretto needs time to process
 
 	val, found := c.Get(shortCode)
-	assert.True(t, found)
-	assert.Equal(t, originalURL, val)
+	require.True(t, found)
+	assert.Equal(t, originalURL, val.OriginalURL)
 }
 
 func TestSet_UpdateExisting(t *testing.T) {
@@ -59,15 +64,15 @@ func TestSet_UpdateExisting(t *testing.T) {
 	url1 := "https://example.com/first"
 	url2 := "https://example.com/second"
 
-	c.Set(shortCode, url1)
+	c.Set(link(shortCode, url1))
 	time.Sleep(10 * time.Millisecond)
 
-	c.Set(shortCode, url2)
+	c.Set(link(shortCode, url2))
 	time.Sleep(10 * time.Millisecond)
 
 	val, found := c.Get(shortCode)
-	assert.True(t, found)
-	assert.Equal(t, url2, val)
+	require.True(t, found)
+	assert.Equal(t, url2, val.OriginalURL)
 }
 
 func TestSet_MultipleKeys(t *testing.T) {
@@ -82,17 +87,62 @@ func TestSet_MultipleKeys(t *testing.T) {
 	}
 
 	for k, v := range entries {
-		c.Set(k, v)
+		c.Set(link(k, v))
 	}
 	time.Sleep(10 * time.Millisecond)
 
 	for k, want := range entries {
 		got, found := c.Get(k)
-		assert.True(t, found, "key %q should be found", k)
-		assert.Equal(t, want, got, "key %q value mismatch", k)
+		require.True(t, found, "key %q should be found", k)
+		assert.Equal(t, want, got.OriginalURL, "key %q value mismatch", k)
 	}
 }
 
+func TestSet_ExpiresWithLink(t *testing.T) {
+	c, err := cache.New(20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	c.Set(&domain.URL{ShortCode: "abc123", OriginalURL: "https://example.com", ExpiresAt: &expiresAt})
+	time.Sleep(10 * time.Millisecond)
+
+	_, found := c.Get("abc123")
+	assert.True(t, found)
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, found = c.Get("abc123")
+	assert.False(t, found)
+}
+
+func TestSet_AlreadyExpired(t *testing.T) {
+	c, err := cache.New(20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	expiresAt := time.Now().Add(-time.Minute)
+	c.Set(&domain.URL{ShortCode: "abc123", OriginalURL: "https://example.com", ExpiresAt: &expiresAt})
+	time.Sleep(10 * time.Millisecond)
+
+	_, found := c.Get("abc123")
+	assert.False(t, found)
+}
+
+func TestDelete(t *testing.T) {
+	c, err := cache.New(20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	c.Set(link("abc123", "https://example.com"))
+	time.Sleep(10 * time.Millisecond)
+
+	c.Delete("abc123")
+
+	_, found := c.Get("abc123")
+	assert.False(t, found)
+}
+
 func TestStats_AfterOperations(t *testing.T) {
 	c, err := cache.New(20)
 	require.NoError(t, err)
@@ -110,7 +160,7 @@ func TestStats_AfterOperations(t *testing.T) {
 	assert.Equal(t, uint64(1), misses)
 
 	// Add and hit
-	c.Set("key1", "value1")
+	c.Set(link("key1", "value1"))
 	time.Sleep(10 * time.Millisecond)
 	c.Get("key1")
 
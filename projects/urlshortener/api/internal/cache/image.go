@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"github.com/dgraph-io/ristretto"
+)
+
+// ImageCache holds rendered images, such as QR codes, by key. Its admission
+// policy favours frequently requested keys, so hot codes stay rendered.
+type ImageCache struct {
+	cache *ristretto.Cache
+}
+
+func NewImageCache(maxBytes int64) (*ImageCache, error) {
+	maxCost := max(1, maxBytes)
+	numCounters := max(1, maxCost/1000) // ~10 counters per ~10KB image
+
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: numCounters,
+		MaxCost:     maxCost,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ImageCache{cache: cache}, nil
+}
+
+func (c *ImageCache) Get(key string) ([]byte, bool) {
+	val, found := c.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+	return val.([]byte), true
+}
+
+func (c *ImageCache) Set(key string, img []byte) {
+	c.cache.Set(key, img, int64(len(img)))
+}
+
+func (c *ImageCache) Close() {
+	c.cache.Close()
+}
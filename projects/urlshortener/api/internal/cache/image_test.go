@@ -0,0 +1,27 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/cache"
+)
+
+func TestImageCache_SetAndGet(t *testing.T) {
+	c, err := cache.NewImageCache(1 << 20)
+	require.NoError(t, err)
+	defer c.Close()
+
+	c.Set("abc123|png", []byte("image"))
+	time.Sleep(10 * time.Millisecond) // Ristretto needs time to process
+
+	img, found := c.Get("abc123|png")
+	require.True(t, found)
+	assert.Equal(t, []byte("image"), img)
+
+	_, found = c.Get("abc123|svg")
+	assert.False(t, found)
+}
@@ -3,15 +3,24 @@ package config
 import "github.com/caarlos0/env/v11"
 
 type Config struct {
-	Server     ServerConfig
-	TLS        TLSConfig
-	Database   DatabaseConfig
-	App        AppConfig
-	Cache      CacheConfig
-	RateLimit  RateLimitConfig
-	Metrics    MetricsConfig
-	Validation ValidationConfig
-	Pprof      PprofConfig
+	Server      ServerConfig
+	TLS         TLSConfig
+	Database    DatabaseConfig
+	App         AppConfig
+	Cache       CacheConfig
+	RateLimit   RateLimitConfig
+	Metrics     MetricsConfig
+	Validation  ValidationConfig
+	Pprof       PprofConfig
+	Idempotency IdempotencyConfig
+	Password    PasswordConfig
+	Auth        AuthConfig
+	Probe       ProbeConfig
+	QR          QRConfig
+	Import      ImportConfig
+	Export      ExportConfig
+	Schedule    ScheduleConfig
+	Webhook     WebhookConfig
 }
 
 type ServerConfig struct {
@@ -63,10 +72,14 @@ type MetricsConfig struct {
 }
 
 type ValidationConfig struct {
-	MaxURLLength       int    `env:"VALIDATION_MAX_URL_LENGTH" envDefault:"2048"`
-	MaxBatchSize       int    `env:"VALIDATION_MAX_BATCH_SIZE" envDefault:"5000"`
-	MaxRequestBodySize string `env:"VALIDATION_MAX_BODY_SIZE" envDefault:"1M"`
-	AllowPrivateIPs    bool   `env:"VALIDATION_ALLOW_PRIVATE_IPS" envDefault:"false"`
+	MaxURLLength        int    `env:"VALIDATION_MAX_URL_LENGTH" envDefault:"2048"`
+	MaxBatchSize        int    `env:"VALIDATION_MAX_BATCH_SIZE" envDefault:"5000"`
+	MaxRequestBodySize  string `env:"VALIDATION_MAX_BODY_SIZE" envDefault:"1M"`
+	AllowPrivateIPs     bool   `env:"VALIDATION_ALLOW_PRIVATE_IPS" envDefault:"false"`
+	ResolveHosts        bool   `env:"VALIDATION_RESOLVE_HOSTS" envDefault:"false"`
+	ResolveTimeoutMs    int    `env:"VALIDATION_RESOLVE_TIMEOUT_MS" envDefault:"1000"`
+	ResolveCacheSeconds int    `env:"VALIDATION_RESOLVE_CACHE_SECONDS" envDefault:"300"`
+	ResolveConcurrency  int    `env:"VALIDATION_RESOLVE_CONCURRENCY" envDefault:"16"`
 }
 
 type PprofConfig struct {
@@ -74,6 +87,67 @@ type PprofConfig struct {
 	Secret  string `env:"PPROF_SECRET"`
 }
 
+type IdempotencyConfig struct {
+	TTLHours               int `env:"IDEMPOTENCY_TTL_HOURS" envDefault:"24"`
+	CleanupIntervalMinutes int `env:"IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES" envDefault:"60"`
+}
+
+type PasswordConfig struct {
+	AttemptsPerMinute float64 `env:"PASSWORD_ATTEMPTS_PER_MINUTE" envDefault:"5"`
+	AttemptsBurst     int     `env:"PASSWORD_ATTEMPTS_BURST" envDefault:"5"`
+	ExpireMinutes     int     `env:"PASSWORD_ATTEMPTS_EXPIRE_MINUTES" envDefault:"10"`
+}
+
+type AuthConfig struct {
+	Required          bool    `env:"AUTH_REQUIRED" envDefault:"false"`
+	AdminSecret       string  `env:"AUTH_ADMIN_SECRET"`
+	FailuresPerMinute float64 `env:"AUTH_FAILURES_PER_MINUTE" envDefault:"10"`
+	FailuresBurst     int     `env:"AUTH_FAILURES_BURST" envDefault:"20"`
+}
+
+type ProbeConfig struct {
+	Enabled         bool `env:"PROBE_ENABLED" envDefault:"true"`
+	IntervalSeconds int  `env:"PROBE_INTERVAL_SECONDS" envDefault:"30"`
+	TimeoutMs       int  `env:"PROBE_TIMEOUT_MS" envDefault:"2000"`
+	Concurrency     int  `env:"PROBE_CONCURRENCY" envDefault:"8"`
+}
+
+type QRConfig struct {
+	Size       int    `env:"QR_SIZE" envDefault:"256"`
+	MaxSize    int    `env:"QR_MAX_SIZE" envDefault:"2048"`
+	Level      string `env:"QR_LEVEL" envDefault:"M"`
+	Margin     int    `env:"QR_MARGIN" envDefault:"4"`
+	CacheMaxMB int    `env:"QR_CACHE_MAX_MB" envDefault:"32"`
+}
+
+type ImportConfig struct {
+	ChunkSize          int `env:"IMPORT_CHUNK_SIZE" envDefault:"1000"`
+	IdleTimeoutSeconds int `env:"IMPORT_IDLE_TIMEOUT_SECONDS" envDefault:"30"`
+}
+
+type ExportConfig struct {
+	IdleTimeoutSeconds int `env:"EXPORT_IDLE_TIMEOUT_SECONDS" envDefault:"30"`
+}
+
+type ScheduleConfig struct {
+	NotYetStatus  int    `env:"SCHEDULE_NOT_YET_STATUS" envDefault:"404"`
+	NotYetMessage string `env:"SCHEDULE_NOT_YET_MESSAGE" envDefault:"url is not yet available"`
+}
+
+type WebhookConfig struct {
+	Enabled            bool `env:"WEBHOOK_ENABLED" envDefault:"false"`
+	BufferSize         int  `env:"WEBHOOK_BUFFER_SIZE" envDefault:"10000"`
+	FlushInterval      int  `env:"WEBHOOK_FLUSH_INTERVAL_MS" envDefault:"100"`
+	FlushThreshold     int  `env:"WEBHOOK_FLUSH_THRESHOLD" envDefault:"1000"`
+	PollInterval       int  `env:"WEBHOOK_POLL_INTERVAL_MS" envDefault:"1000"`
+	BatchSize          int  `env:"WEBHOOK_BATCH_SIZE" envDefault:"100"`
+	Concurrency        int  `env:"WEBHOOK_CONCURRENCY" envDefault:"8"`
+	TimeoutMs          int  `env:"WEBHOOK_TIMEOUT_MS" envDefault:"5000"`
+	MaxAttempts        int  `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"10"`
+	BackoffBaseSeconds int  `env:"WEBHOOK_BACKOFF_BASE_SECONDS" envDefault:"10"`
+	BackoffMaxSeconds  int  `env:"WEBHOOK_BACKOFF_MAX_SECONDS" envDefault:"3600"`
+}
+
 func Load() (*Config, error) {
 	var cfg Config
 	if err := env.Parse(&cfg); err != nil {
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ScopeLinksCreate = "links:create"
+	ScopeLinksUpdate = "links:update"
+	ScopeLinksDelete = "links:delete"
+	ScopeStatsRead   = "stats:read"
+)
+
+// Scopes lists every scope a key can be granted.
+var Scopes = []string{ScopeLinksCreate, ScopeLinksUpdate, ScopeLinksDelete, ScopeStatsRead}
+
+// APIKey identifies a client. The key itself is only shown once, at creation;
+// afterwards it is known by its ID and Prefix.
+type APIKey struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Prefix    string    `json:"prefix"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
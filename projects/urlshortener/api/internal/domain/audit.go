@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditCreate     = "create"
+	AuditUpdate     = "update"
+	AuditDelete     = "delete"
+	AuditDeactivate = "deactivate"
+	AuditReactivate = "reactivate"
+)
+
+type Actor struct {
+	KeyID *int64
+	IP    string
+}
+
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	Action     string          `json:"action"`
+	ActorKeyID *int64          `json:"actor_key_id,omitempty"`
+	ActorIP    string          `json:"actor_ip,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	OldValue   json.RawMessage `json:"old_value,omitempty"`
+	NewValue   json.RawMessage `json:"new_value,omitempty"`
+}
+
+type HistoryPage struct {
+	ShortCode  string       `json:"short_code"`
+	Entries    []AuditEntry `json:"entries"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
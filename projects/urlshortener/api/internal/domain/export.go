@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"urlshortener/internal/routing"
+)
+
+// Export formats.
+const (
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatCSV    = "csv"
+)
+
+// ExportedLink is one link of an export: everything needed to audit or recreate
+// it except the password, which is only marked. Destinations of protected links
+// are left out unless the caller owns them.
+type ExportedLink struct {
+	ShortCode      string            `json:"short_code"`
+	OriginalURL    string            `json:"original_url"`
+	CreatedAt      time.Time         `json:"created_at"`
+	ExpiresAt      *time.Time        `json:"expires_at,omitempty"`
+	Active         bool              `json:"active"`
+	Protected      bool              `json:"password_protected"`
+	OwnerKeyID     *int64            `json:"owner_key_id,omitempty"`
+	RedirectStatus int               `json:"redirect_status"`
+	QueryPolicy    string            `json:"query_policy"`
+	UTM            map[string]string `json:"utm,omitempty"`
+	Rules          []routing.Rule    `json:"rules,omitempty"`
+	Variants       []Variant         `json:"variants,omitempty"`
+	Sticky         bool              `json:"sticky,omitempty"`
+	Backups        []string          `json:"backups,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Campaign       string            `json:"campaign,omitempty"`
+	ActiveFrom     *time.Time        `json:"active_from,omitempty"`
+	PrelaunchURL   string            `json:"prelaunch_url,omitempty"`
+}
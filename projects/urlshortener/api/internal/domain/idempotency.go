@@ -0,0 +1,13 @@
+package domain
+
+// IdempotentResponse is the stored outcome of a request made with an
+// Idempotency-Key. StatusCode is zero while the original request is in flight.
+type IdempotentResponse struct {
+	Fingerprint string
+	StatusCode  int
+	Body        []byte
+}
+
+func (r *IdempotentResponse) Pending() bool {
+	return r.StatusCode == 0
+}
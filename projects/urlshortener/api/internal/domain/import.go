@@ -0,0 +1,19 @@
+package domain
+
+// ImportResult is the outcome of one import row: the created link, or the
+// reason the row was skipped.
+type ImportResult struct {
+	Row int `json:"row"` // 1-based, not counting a CSV header
+	*CreateURLResponse
+	Error string `json:"error,omitempty"`
+}
+
+// ImportSummary is the last line of an import response. An import that
+// stopped early, such as on a database outage, carries the error; rows after
+// the last reported one were not imported.
+type ImportSummary struct {
+	Rows      int    `json:"rows"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Error     string `json:"error,omitempty"`
+}
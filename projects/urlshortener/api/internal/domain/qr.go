@@ -0,0 +1,15 @@
+package domain
+
+// QR code formats.
+const (
+	QRFormatPNG = "png"
+	QRFormatSVG = "svg"
+)
+
+// QROptions controls how the QR code of a link is rendered.
+type QROptions struct {
+	Format string // png or svg
+	Size   int    // width and height in pixels
+	Level  string // error correction: L, M, Q or H
+	Margin int    // light modules around the code
+}
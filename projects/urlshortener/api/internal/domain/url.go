@@ -1,19 +1,200 @@
 package domain
 
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"urlshortener/internal/routing"
+)
+
+const (
+	QueryDrop     = "drop"     // ignore it (default)
+	QueryAppend   = "append"   // add parameters the destination does not set
+	QueryOverride = "override" // add parameters, replacing the destination's
+)
+
+type URL struct {
+	ShortCode      string
+	OriginalURL    string
+	CreatedAt      time.Time
+	ExpiresAt      *time.Time
+	Active         bool
+	PasswordHash   string // empty unless the link is password protected
+	OwnerKeyID     *int64 // API key that created the link, nil if anonymous
+	RedirectStatus int
+	QueryPolicy    string
+	UTM            map[string]string // always added to the destination
+	Rules          *routing.Set      // nil unless the destination depends on the visitor
+	Variants       []Variant         // split traffic instead of OriginalURL
+	Sticky         bool              // visitors keep their variant
+	Backups        []string          // tried in order when OriginalURL is unhealthy
+	Tags           []string
+	Campaign       string
+	ActiveFrom     *time.Time // the link redirects only from then on
+	PrelaunchURL   string     // served instead before ActiveFrom, if set
+}
+
+type Variant struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Weight int    `json:"weight"`
+}
+
+func (u *URL) Protected() bool {
+	return u.PasswordHash != ""
+}
+
+type Redirect struct {
+	URL         string
+	Status      int
+	CreatedAt   time.Time
+	ExpiresAt   *time.Time
+	Protected   bool
+	Conditional bool
+	Variant     string // name of the variant served, if any
+	Sticky      bool   // the variant should be remembered for the visitor
+}
+
+func (r *Redirect) Permanent() bool {
+	return r.Status == http.StatusMovedPermanently || r.Status == http.StatusPermanentRedirect
+}
+
+type Visit struct {
+	Password string
+	Preview  bool       // the visitor inspects the link instead of following it
+	Query    url.Values // query string of the short URL
+	Request  routing.Request
+	Variant  string // variant the visitor was assigned before
+}
+
+func (u *URL) Expired(now time.Time) bool {
+	return u.ExpiresAt != nil && !now.Before(*u.ExpiresAt)
+}
+
+func (u *URL) Scheduled(now time.Time) bool {
+	return u.ActiveFrom != nil && now.Before(*u.ActiveFrom)
+}
+
+type URLInfo struct {
+	ShortCode      string            `json:"short_code"`
+	ShortURL       string            `json:"short_url"`
+	OriginalURL    string            `json:"original_url,omitempty"` // withheld for protected links
+	CreatedAt      time.Time         `json:"created_at"`
+	ExpiresAt      *time.Time        `json:"expires_at,omitempty"`
+	Active         bool              `json:"active"`
+	Expired        bool              `json:"expired"`
+	Protected      bool              `json:"password_protected"`
+	RedirectStatus int               `json:"redirect_status"`
+	QueryPolicy    string            `json:"query_policy"`
+	UTM            map[string]string `json:"utm,omitempty"`
+	Rules          []routing.Rule    `json:"rules,omitempty"`    // withheld for protected links
+	Variants       []Variant         `json:"variants,omitempty"` // withheld for protected links
+	Sticky         bool              `json:"sticky,omitempty"`
+	Backups        []string          `json:"backups,omitempty"` // withheld for protected links
+	Tags           []string          `json:"tags,omitempty"`
+	Campaign       string            `json:"campaign,omitempty"`
+	ActiveFrom     *time.Time        `json:"active_from,omitempty"`
+	PrelaunchURL   string            `json:"prelaunch_url,omitempty"`
+}
+
+type LinkFilter struct {
+	Tag      string
+	Campaign string
+}
+
+func (f LinkFilter) Empty() bool {
+	return f.Tag == "" && f.Campaign == ""
+}
+
+type URLPage struct {
+	URLs       []URLInfo `json:"urls"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+type ResolveRequest struct {
+	Codes []string `json:"codes"`
+}
+
+type ResolveResponse struct {
+	URLs    []URLInfo `json:"urls"`
+	Missing []string  `json:"missing"`
+}
+
+type BulkResult struct {
+	Affected int `json:"affected"`
+}
+
 type CreateURLRequest struct {
-	URL string `json:"url"`
+	URL       string     `json:"url"`
+	Alias     string     `json:"alias,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	TTL       int        `json:"ttl,omitempty"` // seconds, alternative to ExpiresAt
+	Dedupe    bool       `json:"dedupe,omitempty"`
+	Password  string     `json:"password,omitempty"`
+	// RedirectStatus is 301, 302, 307 or 308; zero means 302.
+	RedirectStatus int `json:"redirect_status,omitempty"`
+	// QueryPolicy is drop, append or override; empty means drop.
+	QueryPolicy  string            `json:"query_policy,omitempty"`
+	UTM          map[string]string `json:"utm,omitempty"`
+	Rules        []routing.Rule    `json:"rules,omitempty"`
+	Variants     []Variant         `json:"variants,omitempty"`
+	Sticky       bool              `json:"sticky,omitempty"`
+	Backups      []string          `json:"backups,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Campaign     string            `json:"campaign,omitempty"`
+	ActiveFrom   *time.Time        `json:"active_from,omitempty"`
+	PrelaunchURL string            `json:"prelaunch_url,omitempty"`
+}
+
+// Batch entries may stay bare URL strings.
+func (r *CreateURLRequest) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*r = CreateURLRequest{}
+		return json.Unmarshal(data, &r.URL)
+	}
+
+	type plain CreateURLRequest
+	return json.Unmarshal(data, (*plain)(r))
 }
 
 type CreateURLResponse struct {
-	ShortCode   string `json:"short_code"`
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
+	ShortCode    string     `json:"short_code"`
+	ShortURL     string     `json:"short_url"`
+	OriginalURL  string     `json:"original_url"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Deduplicated bool       `json:"deduplicated,omitempty"` // an existing link was returned
+}
+
+type UpdateURLRequest struct {
+	URL string `json:"url"`
 }
 
+const (
+	BatchModeAtomic  = "atomic"  // reject the whole batch (default)
+	BatchModePartial = "partial" // create the valid entries and report the rest
+)
+
 type CreateURLBatchRequest struct {
-	URLs []string `json:"urls"`
+	URLs   []CreateURLRequest `json:"urls"`
+	Dedupe bool               `json:"dedupe,omitempty"` // applies to every entry
+	Mode   string             `json:"mode,omitempty"`
 }
 
 type CreateURLBatchResponse struct {
 	URLs []CreateURLResponse `json:"urls"`
 }
+
+type BatchEntryResult struct {
+	Index int `json:"index"`
+	*CreateURLResponse
+	Error string `json:"error,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+type PartialBatchResponse struct {
+	URLs    []BatchEntryResult `json:"urls"`
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+}
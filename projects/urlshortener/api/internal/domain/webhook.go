@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventLinkCreated = "link.created"
+	EventLinkUpdated = "link.updated"
+	EventLinkDeleted = "link.deleted"
+	EventLinkClicked = "link.clicked"
+)
+
+var Events = []string{EventLinkCreated, EventLinkUpdated, EventLinkDeleted, EventLinkClicked}
+
+type Event struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       LinkEvent `json:"data"`
+}
+
+type LinkEvent struct {
+	ShortCode   string `json:"short_code"`
+	OriginalURL string `json:"original_url,omitempty"`
+	Active      *bool  `json:"active,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Variant     string `json:"variant,omitempty"`
+}
+
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// The secret is only shown once.
+type CreateWebhookResponse struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+const (
+	DeliveryPending = "pending"
+	DeliveryDead    = "dead"
+)
+
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	WebhookID     int64           `json:"webhook_id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatus    int             `json:"last_status,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type DeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
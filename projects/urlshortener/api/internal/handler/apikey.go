@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+)
+
+var (
+	errAPIKeyNameMissing = map[string]string{"error": "name is required"}
+	errInvalidScope      = map[string]string{"error": "scopes must be a non-empty list of known scopes"}
+	errInvalidKeyID      = map[string]string{"error": "invalid api key id"}
+	errAPIKeyNotFound    = map[string]string{"error": "api key not found"}
+	errAPIKeyFailed      = map[string]string{"error": "failed to manage api keys"}
+)
+
+// KeyHandler serves API key management. Its routes are meant to sit behind
+// admin authentication.
+type KeyHandler struct {
+	keyService APIKeyService
+	logger     *slog.Logger
+}
+
+func NewKeyHandler(keyService APIKeyService, logger *slog.Logger) *KeyHandler {
+	return &KeyHandler{
+		keyService: keyService,
+		logger:     logger,
+	}
+}
+
+func (h *KeyHandler) Register(g *echo.Group) {
+	g.POST("", h.CreateKey)
+	g.GET("", h.ListKeys)
+	g.DELETE("/:id", h.RevokeKey)
+}
+
+func (h *KeyHandler) CreateKey(c echo.Context) error {
+	var req domain.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", slog.String("error", err.Error()))
+		return c.JSON(http.StatusBadRequest, errInvalidBody)
+	}
+
+	resp, err := h.keyService.CreateKey(c.Request().Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAPIKeyNameMissing):
+			return c.JSON(http.StatusBadRequest, errAPIKeyNameMissing)
+		case errors.Is(err, service.ErrInvalidScope):
+			return c.JSON(http.StatusBadRequest, errInvalidScope)
+		}
+		h.logger.Error("failed to create api key", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errAPIKeyFailed)
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (h *KeyHandler) ListKeys(c echo.Context) error {
+	keys, err := h.keyService.ListKeys(c.Request().Context())
+	if err != nil {
+		h.logger.Error("failed to list api keys", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errAPIKeyFailed)
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.APIKey{"keys": keys})
+}
+
+func (h *KeyHandler) RevokeKey(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errInvalidKeyID)
+	}
+
+	if err := h.keyService.RevokeKey(c.Request().Context(), id); err != nil {
+		if errors.Is(err, service.ErrAPIKeyNotFound) {
+			return c.JSON(http.StatusNotFound, errAPIKeyNotFound)
+		}
+		h.logger.Error("failed to revoke api key", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errAPIKeyFailed)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/handler/mocks"
+	"urlshortener/internal/service"
+)
+
+func newTestKeyHandler(t *testing.T) (*handler.KeyHandler, *mocks.MockAPIKeyService) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := mocks.NewMockAPIKeyService(t)
+	return handler.NewKeyHandler(svc, logger), svc
+}
+
+func TestCreateKey_Success(t *testing.T) {
+	h, svc := newTestKeyHandler(t)
+
+	req := &domain.CreateAPIKeyRequest{Name: "ci", Scopes: []string{domain.ScopeLinksCreate}}
+	svc.EXPECT().CreateKey(mock.Anything, req).Return(&domain.CreateAPIKeyResponse{
+		APIKey: domain.APIKey{ID: 1, Name: "ci", Prefix: "usk_abcdefgh", Scopes: req.Scopes},
+		Key:    "usk_abcdefghsecret",
+	}, nil)
+
+	e := echo.New()
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/keys",
+		strings.NewReader(`{"name":"ci","scopes":["links:create"]}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	err := h.CreateKey(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"key":"usk_abcdefghsecret"`)
+}
+
+func TestCreateKey_InvalidScope(t *testing.T) {
+	h, svc := newTestKeyHandler(t)
+
+	svc.EXPECT().CreateKey(mock.Anything, mock.Anything).Return(nil, service.ErrInvalidScope)
+
+	e := echo.New()
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/keys",
+		strings.NewReader(`{"name":"ci","scopes":["admin"]}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	err := h.CreateKey(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRevokeKey_InvalidID(t *testing.T) {
+	h, _ := newTestKeyHandler(t)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/api/v1/keys/abc", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+
+	err := h.RevokeKey(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRevokeKey_NotFound(t *testing.T) {
+	h, svc := newTestKeyHandler(t)
+
+	svc.EXPECT().RevokeKey(mock.Anything, int64(9)).Return(service.ErrAPIKeyNotFound)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/api/v1/keys/9", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("9")
+
+	err := h.RevokeKey(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
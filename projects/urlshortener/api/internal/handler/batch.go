@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/validation"
+)
+
+// entryErrorCodes name the errors a batch entry can fail with, so clients can
+// act on them without matching messages.
+var entryErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{validation.ErrEmptyURL, "url_required"},
+	{validation.ErrInvalidURLFormat, "invalid_url"},
+	{validation.ErrUnsafeProtocol, "unsafe_url"},
+	{validation.ErrURLTooLong, "url_too_long"},
+	{validation.ErrPrivateIPNotAllowed, "private_ip"},
+	{validation.ErrUnresolvableHost, "unresolvable_host"},
+	{validation.ErrInvalidAlias, "invalid_alias"},
+	{validation.ErrReservedAlias, "alias_reserved"},
+	{validation.ErrDuplicateAlias, "duplicate_alias"},
+	{validation.ErrConflictingExpiry, "conflicting_expiry"},
+	{validation.ErrInvalidTTL, "invalid_ttl"},
+	{validation.ErrExpiryInPast, "expiry_in_past"},
+	{validation.ErrPasswordTooLong, "password_too_long"},
+	{validation.ErrPasswordInBatch, "password_in_batch"},
+	{validation.ErrInvalidRedirect, "invalid_redirect_status"},
+	{validation.ErrInvalidQueryPolicy, "invalid_query_policy"},
+	{validation.ErrInvalidUTM, "invalid_utm"},
+	{validation.ErrTooManyRules, "too_many_rules"},
+	{validation.ErrInvalidRule, "invalid_rule"},
+	{validation.ErrInvalidVariants, "invalid_variants"},
+	{validation.ErrInvalidBackups, "invalid_backups"},
+	{validation.ErrInvalidTags, "invalid_tags"},
+	{validation.ErrInvalidCampaign, "invalid_campaign"},
+	{validation.ErrInvalidSchedule, "invalid_active_from"},
+	{validation.ErrInvalidPrelaunch, "invalid_prelaunch_url"},
+	{service.ErrAliasTaken, "alias_taken"},
+	{service.ErrAliasReserved, "alias_reserved"},
+}
+
+func entryErrorCode(err error) string {
+	for _, e := range entryErrorCodes {
+		if errors.Is(err, e.err) {
+			return e.code
+		}
+	}
+	return "invalid_entry"
+}
+
+// createURLBatchPartial creates the valid entries of a batch and reports the
+// others, all in input order. The batch as a whole can still be rejected for
+// being empty or too large. The status is 201 if any link was created and 400
+// otherwise.
+func (h *Handler) createURLBatchPartial(c echo.Context, reqs []domain.CreateURLRequest) error {
+	resp := domain.PartialBatchResponse{URLs: make([]domain.BatchEntryResult, len(reqs))}
+	fail := func(i int, err error) {
+		resp.URLs[i].Error = err.Error()
+		resp.URLs[i].Code = entryErrorCode(err)
+		resp.Failed++
+	}
+
+	for i := range resp.URLs {
+		resp.URLs[i].Index = i
+	}
+	if err := h.urlValidator.ValidateBatch(c.Request().Context(), reqs); err != nil {
+		var batchErr *validation.BatchValidationError
+		if !errors.As(err, &batchErr) {
+			return h.handleValidationError(c, err)
+		}
+		for _, e := range batchErr.Errors {
+			fail(e.Index, e.Err)
+		}
+	}
+
+	valid := make([]domain.CreateURLRequest, 0, len(reqs)-resp.Failed)
+	indexes := make([]int, 0, len(reqs)-resp.Failed)
+	for i := range reqs {
+		if resp.URLs[i].Error == "" {
+			valid = append(valid, reqs[i])
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(valid) > 0 {
+		responses, errs, err := h.urlService.CreateShortURLBatchPartial(c.Request().Context(), valid)
+		if err != nil {
+			return h.handleCreateError(c, err, "failed to create short urls", errCreateBatchFailed)
+		}
+		for j, i := range indexes {
+			if errs[j] != nil {
+				fail(i, errs[j])
+				continue
+			}
+			resp.URLs[i].CreateURLResponse = &responses[j]
+			resp.Created++
+		}
+	}
+
+	if resp.Created == 0 {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
@@ -0,0 +1,140 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/service"
+	"urlshortener/internal/validation"
+)
+
+func postBatch(t *testing.T, h *handler.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	return postBatchTo(t, h, "/api/v1/urls/batch", body)
+}
+
+func postBatchTo(t *testing.T, h *handler.Handler, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.CreateURLBatch(e.NewContext(req, rec)))
+	return rec
+}
+
+func TestCreateURLBatch_Partial(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(&validation.BatchValidationError{
+		Errors: []validation.IndexedError{{Index: 1, Err: validation.ErrUnsafeProtocol}},
+	})
+	svc.EXPECT().CreateShortURLBatchPartial(mock.Anything, []domain.CreateURLRequest{
+		{URL: "https://example.com/0"},
+		{URL: "https://example.com/2", Alias: "taken"},
+		{URL: "https://example.com/3"},
+	}).Return([]domain.CreateURLResponse{
+		{ShortCode: "code0", ShortURL: "http://short.url/code0", OriginalURL: "https://example.com/0"},
+		{},
+		{ShortCode: "code3", ShortURL: "http://short.url/code3", OriginalURL: "https://example.com/3"},
+	}, []error{nil, service.ErrAliasTaken, nil}, nil)
+
+	rec := postBatch(t, h, `{"mode":"partial","urls":["https://example.com/0","javascript:alert(1)",
+		{"url":"https://example.com/2","alias":"taken"},"https://example.com/3"]}`)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var resp domain.PartialBatchResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Created)
+	assert.Equal(t, 2, resp.Failed)
+	require.Len(t, resp.URLs, 4)
+	for i, entry := range resp.URLs {
+		assert.Equal(t, i, entry.Index)
+	}
+	assert.Equal(t, "code0", resp.URLs[0].ShortCode)
+	assert.Equal(t, "url protocol not allowed", resp.URLs[1].Error)
+	assert.Equal(t, "unsafe_url", resp.URLs[1].Code)
+	assert.Nil(t, resp.URLs[1].CreateURLResponse)
+	assert.Equal(t, "alias_taken", resp.URLs[2].Code)
+	assert.Equal(t, "code3", resp.URLs[3].ShortCode)
+}
+
+func TestCreateURLBatch_PartialNothingValid(t *testing.T) {
+	h, _, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(&validation.BatchValidationError{
+		Errors: []validation.IndexedError{{Index: 0, Err: validation.ErrInvalidURLFormat}},
+	})
+
+	rec := postBatch(t, h, `{"mode":"partial","urls":["not a url"]}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"urls":[{"index":0,"error":"invalid url format","code":"invalid_url"}],"created":0,"failed":1}`, rec.Body.String())
+}
+
+func TestCreateURLBatch_PartialWholeBatchErrors(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(validation.ErrBatchTooLarge).Once()
+	rec := postBatch(t, h, `{"mode":"partial","urls":["https://example.com/0","https://example.com/1"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "batch size exceeds maximum")
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(nil).Once()
+	svc.EXPECT().CreateShortURLBatchPartial(mock.Anything, mock.Anything).Return(nil, nil, errors.New("db down"))
+	rec = postBatch(t, h, `{"mode":"partial","urls":["https://example.com/0"]}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestCreateURLBatch_InvalidMode(t *testing.T) {
+	h, _, _, _ := newTestHandler(t)
+
+	rec := postBatch(t, h, `{"mode":"best-effort","urls":["https://example.com"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "mode must be atomic or partial")
+
+	rec = postBatchTo(t, h, "/api/v1/urls/batch?mode=best-effort", `{"urls":["https://example.com"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "mode must be atomic or partial")
+}
+
+func TestCreateURLBatch_ModeQueryParam(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(nil)
+	svc.EXPECT().CreateShortURLBatchPartial(mock.Anything, mock.Anything).
+		Return([]domain.CreateURLResponse{{ShortCode: "abc123"}}, []error{nil}, nil).Once()
+	rec := postBatchTo(t, h, "/api/v1/urls/batch?mode=partial", `{"urls":["https://example.com"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"created":1`)
+
+	svc.EXPECT().CreateShortURLBatch(mock.Anything, mock.Anything).
+		Return([]domain.CreateURLResponse{{ShortCode: "abc123"}}, nil).Once()
+	rec = postBatchTo(t, h, "/api/v1/urls/batch?mode=partial", `{"mode":"atomic","urls":["https://example.com"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NotContains(t, rec.Body.String(), `"created"`, "the body takes precedence")
+}
+
+func TestCreateURLBatch_AtomicErrorsHaveCodes(t *testing.T) {
+	h, _, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(&validation.BatchValidationError{
+		Errors: []validation.IndexedError{{Index: 1, Err: validation.ErrDuplicateAlias}},
+	})
+
+	rec := postBatch(t, h, `{"mode":"atomic","urls":[{"url":"https://example.com","alias":"a"},{"url":"https://example.org","alias":"a"}]}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"errors":[{"index":1,"error":"duplicate alias in batch","code":"duplicate_alias"}]}`, rec.Body.String())
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+)
+
+var (
+	errInvalidExportFormat = map[string]string{"error": "format must be ndjson or csv"}
+	errExportFailed        = map[string]string{"error": "failed to export urls"}
+)
+
+const exportFlushRows = 1000
+
+var exportColumns = []string{
+	"short_code", "original_url", "created_at", "expires_at", "active", "password_protected", "owner_key_id",
+	"redirect_status", "query_policy", "utm", "rules", "variants", "sticky", "backups", "tags", "campaign",
+	"active_from", "prelaunch_url",
+}
+
+type ExportHandler struct {
+	exporter    URLExporter
+	idleTimeout time.Duration
+	logger      *slog.Logger
+}
+
+func NewExportHandler(exporter URLExporter, idleTimeout time.Duration, logger *slog.Logger) *ExportHandler {
+	return &ExportHandler{
+		exporter:    exporter,
+		idleTimeout: idleTimeout,
+		logger:      logger,
+	}
+}
+
+func (h *ExportHandler) Register(g *echo.Group, m ...echo.MiddlewareFunc) {
+	g.GET("/export", h.ExportURLs, m...)
+}
+
+// A failure after the first link aborts the connection, so a cut-off export
+// is never mistaken for a complete one.
+func (h *ExportHandler) ExportURLs(c echo.Context) error {
+	format := cmp.Or(c.QueryParam("format"), domain.ExportFormatNDJSON)
+	var (
+		contentType string
+		newWriter   func(io.Writer) linkWriter
+	)
+	switch format {
+	case domain.ExportFormatNDJSON:
+		contentType, newWriter = "application/x-ndjson", newNDJSONLinks
+	case domain.ExportFormatCSV:
+		contentType, newWriter = "text/csv; charset=utf-8", newCSVLinks
+	default:
+		return c.JSON(http.StatusBadRequest, errInvalidExportFormat)
+	}
+
+	resp := c.Response()
+	rc := http.NewResponseController(resp)
+	var w linkWriter
+	start := func() error {
+		h.extendDeadline(rc)
+		resp.Header().Set(echo.HeaderContentType, contentType)
+		resp.Header().Set(echo.HeaderContentDisposition, `attachment; filename="links.`+format+`"`)
+		resp.WriteHeader(http.StatusOK)
+		w = newWriter(resp)
+		return w.begin()
+	}
+
+	written := 0
+	err := h.exporter.ExportURLs(c.Request().Context(), c.QueryParam("after"), func(link *domain.ExportedLink) error {
+		if w == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := w.write(link); err != nil {
+			return err
+		}
+		written++
+		if written%exportFlushRows == 0 {
+			if err := w.flush(); err != nil {
+				return err
+			}
+			resp.Flush()
+			h.extendDeadline(rc)
+		}
+		return nil
+	})
+	if err != nil {
+		h.logger.Error("failed to export urls",
+			slog.Int("written", written),
+			slog.String("error", err.Error()))
+		if w == nil {
+			return c.JSON(http.StatusInternalServerError, errExportFailed)
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	if w == nil {
+		if err := start(); err != nil {
+			h.logger.Error("failed to write export", slog.String("error", err.Error()))
+			return nil
+		}
+	}
+	if err := w.flush(); err != nil {
+		h.logger.Error("failed to write export", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+func (h *ExportHandler) extendDeadline(rc *http.ResponseController) {
+	_ = rc.SetWriteDeadline(time.Now().Add(h.idleTimeout))
+}
+
+type linkWriter interface {
+	begin() error
+	write(link *domain.ExportedLink) error
+	flush() error
+}
+
+type ndjsonLinks struct {
+	enc *json.Encoder
+}
+
+func newNDJSONLinks(w io.Writer) linkWriter {
+	return &ndjsonLinks{enc: json.NewEncoder(w)}
+}
+
+func (l *ndjsonLinks) begin() error {
+	return nil
+}
+
+func (l *ndjsonLinks) write(link *domain.ExportedLink) error {
+	return l.enc.Encode(link)
+}
+
+func (l *ndjsonLinks) flush() error {
+	return nil
+}
+
+type csvLinks struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVLinks(w io.Writer) linkWriter {
+	return &csvLinks{w: csv.NewWriter(w), record: make([]string, len(exportColumns))}
+}
+
+func (l *csvLinks) begin() error {
+	return l.w.Write(exportColumns)
+}
+
+func (l *csvLinks) write(link *domain.ExportedLink) error {
+	rules, err := jsonCell(link.Rules)
+	if err != nil {
+		return err
+	}
+	variants, err := jsonCell(link.Variants)
+	if err != nil {
+		return err
+	}
+
+	l.record = append(l.record[:0],
+		link.ShortCode,
+		link.OriginalURL,
+		link.CreatedAt.Format(time.RFC3339),
+		timeCell(link.ExpiresAt),
+		strconv.FormatBool(link.Active),
+		strconv.FormatBool(link.Protected),
+		idCell(link.OwnerKeyID),
+		strconv.Itoa(link.RedirectStatus),
+		link.QueryPolicy,
+		utmCell(link.UTM),
+		rules,
+		variants,
+		strconv.FormatBool(link.Sticky),
+		strings.Join(link.Backups, " "),
+		strings.Join(link.Tags, " "),
+		link.Campaign,
+		timeCell(link.ActiveFrom),
+		link.PrelaunchURL,
+	)
+	return l.w.Write(l.record)
+}
+
+func (l *csvLinks) flush() error {
+	l.w.Flush()
+	return l.w.Error()
+}
+
+func timeCell(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func idCell(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+func utmCell(utm map[string]string) string {
+	values := make(url.Values, len(utm))
+	for k, v := range utm {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
+
+func jsonCell[T any](list []T) (string, error) {
+	if len(list) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(list)
+	return string(b), err
+}
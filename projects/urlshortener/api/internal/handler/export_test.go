@@ -0,0 +1,171 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/handler/mocks"
+)
+
+func newExportHandler(t *testing.T) (*handler.ExportHandler, *mocks.MockURLExporter) {
+	exporter := mocks.NewMockURLExporter(t)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	return handler.NewExportHandler(exporter, time.Minute, logger), exporter
+}
+
+func getExport(h *handler.ExportHandler, query string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/export"+query, nil)
+	rec := httptest.NewRecorder()
+	_ = h.ExportURLs(e.NewContext(req, rec))
+	return rec
+}
+
+// exportLinks makes the exporter yield links.
+func exportLinks(exporter *mocks.MockURLExporter, after string, links ...*domain.ExportedLink) {
+	exporter.EXPECT().ExportURLs(mock.Anything, after, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, fn func(*domain.ExportedLink) error) error {
+			for _, link := range links {
+				if err := fn(link); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+var exportCreated = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestExportURLs_NDJSON(t *testing.T) {
+	h, exporter := newExportHandler(t)
+	owner := int64(7)
+	exportLinks(exporter, "abc",
+		&domain.ExportedLink{ShortCode: "abd", OriginalURL: "https://example.com/a", CreatedAt: exportCreated, Active: true, RedirectStatus: 302, QueryPolicy: "drop"},
+		&domain.ExportedLink{ShortCode: "abe", OriginalURL: "https://example.com/b", CreatedAt: exportCreated, OwnerKeyID: &owner, Tags: []string{"email"}},
+	)
+
+	rec := getExport(h, "?after=abc")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="links.ndjson"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, "abd", lines[0]["short_code"])
+	assert.Equal(t, "https://example.com/a", lines[0]["original_url"])
+	assert.Equal(t, "2025-01-01T12:00:00Z", lines[0]["created_at"])
+	assert.NotContains(t, lines[0], "owner_key_id")
+	assert.InDelta(t, 7, lines[1]["owner_key_id"], 0)
+	assert.Equal(t, []any{"email"}, lines[1]["tags"])
+}
+
+func TestExportURLs_CSV(t *testing.T) {
+	h, exporter := newExportHandler(t)
+	expires := exportCreated.Add(24 * time.Hour)
+	exportLinks(exporter, "",
+		&domain.ExportedLink{
+			ShortCode: "abc", OriginalURL: "https://example.com/a,b", CreatedAt: exportCreated, ExpiresAt: &expires,
+			Active: true, Protected: true, RedirectStatus: 301, QueryPolicy: "append",
+			UTM:      map[string]string{"utm_source": "mail", "utm_medium": "email"},
+			Variants: []domain.Variant{{URL: "https://example.com/x", Weight: 1}, {URL: "https://example.com/y", Weight: 3}},
+			Backups:  []string{"https://b1.example.com", "https://b2.example.com"},
+			Tags:     []string{"email", "promo"}, Campaign: "Spring, 2026",
+		},
+		&domain.ExportedLink{
+			ShortCode: "abd", OriginalURL: "https://example.com/b", CreatedAt: exportCreated, RedirectStatus: 302, QueryPolicy: "drop",
+			ActiveFrom: &exportCreated, PrelaunchURL: "https://example.com/soon",
+		},
+	)
+
+	rec := getExport(h, "?format=csv")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{
+		"short_code", "original_url", "created_at", "expires_at", "active", "password_protected", "owner_key_id",
+		"redirect_status", "query_policy", "utm", "rules", "variants", "sticky", "backups", "tags", "campaign",
+		"active_from", "prelaunch_url",
+	}, records[0])
+	assert.Equal(t, []string{
+		"abc", "https://example.com/a,b", "2025-01-01T12:00:00Z", "2025-01-02T12:00:00Z", "true", "true", "",
+		"301", "append", "utm_medium=email&utm_source=mail", "",
+		`[{"name":"","url":"https://example.com/x","weight":1},{"name":"","url":"https://example.com/y","weight":3}]`,
+		"false", "https://b1.example.com https://b2.example.com", "email promo", "Spring, 2026", "", "",
+	}, records[1])
+	assert.Equal(t, []string{
+		"abd", "https://example.com/b", "2025-01-01T12:00:00Z", "", "false", "false", "",
+		"302", "drop", "", "", "", "false", "", "", "", "2025-01-01T12:00:00Z", "https://example.com/soon",
+	}, records[2])
+}
+
+func TestExportURLs_Empty(t *testing.T) {
+	h, exporter := newExportHandler(t)
+	exportLinks(exporter, "zzz")
+
+	rec := getExport(h, "?format=csv&after=zzz")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "header only")
+}
+
+func TestExportURLs_InvalidFormat(t *testing.T) {
+	h, _ := newExportHandler(t)
+
+	rec := getExport(h, "?format=xml")
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "format must be ndjson or csv")
+}
+
+func TestExportURLs_FailsBeforeFirstLink(t *testing.T) {
+	h, exporter := newExportHandler(t)
+	exporter.EXPECT().ExportURLs(mock.Anything, "", mock.Anything).Return(errors.New("db down"))
+
+	rec := getExport(h, "")
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "failed to export urls")
+}
+
+func TestExportURLs_FailsMidwayAborts(t *testing.T) {
+	h, exporter := newExportHandler(t)
+	exporter.EXPECT().ExportURLs(mock.Anything, "", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, fn func(*domain.ExportedLink) error) error {
+			if err := fn(&domain.ExportedLink{ShortCode: "abc"}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { getExport(h, "") },
+		"a cut-off export must not end like a complete one")
+}
@@ -4,13 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 
 	"urlshortener/internal/domain"
+	"urlshortener/internal/routing"
 	"urlshortener/internal/service"
 	"urlshortener/internal/validation"
 )
@@ -28,7 +33,41 @@ var (
 	errUnsafeURL         = map[string]string{"error": "url protocol not allowed"}
 	errURLTooLong        = map[string]string{"error": "url exceeds maximum length"}
 	errPrivateIP         = map[string]string{"error": "private ip addresses not allowed"}
+	errUnresolvableHost  = map[string]string{"error": "url host could not be resolved"}
 	errBatchTooLarge     = map[string]string{"error": "batch size exceeds maximum"}
+	errInvalidBatchMode  = map[string]string{"error": "mode must be atomic or partial"}
+	errInvalidAlias      = map[string]string{"error": "invalid alias format"}
+	errAliasReserved     = map[string]string{"error": "alias is reserved"}
+	errDuplicateAlias    = map[string]string{"error": "duplicate alias in batch"}
+	errAliasTaken        = map[string]string{"error": "alias already taken"}
+	errConflictingExpiry = map[string]string{"error": "expires_at and ttl are mutually exclusive"}
+	errInvalidTTL        = map[string]string{"error": "ttl must be positive and at most 10 years"}
+	errExpiryInPast      = map[string]string{"error": "expires_at must be in the future"}
+	errURLExpired        = map[string]string{"error": "url expired"}
+	errURLInactive       = map[string]string{"error": "url is inactive"}
+	errDeleteFailed      = map[string]string{"error": "failed to delete url"}
+	errUpdateFailed      = map[string]string{"error": "failed to update url"}
+	errPasswordTooLong   = map[string]string{"error": "password exceeds maximum length"}
+	errPasswordInBatch   = map[string]string{"error": "password is not supported in batch"}
+	errPasswordRequired  = map[string]string{"error": "password required"}
+	errPasswordInvalid   = map[string]string{"error": "invalid password"}
+	errTooManyAttempts   = map[string]string{"error": "too many failed password attempts"}
+	errInvalidRedirect   = map[string]string{"error": "redirect_status must be 301, 302, 307 or 308"}
+	errInvalidPolicy     = map[string]string{"error": "query_policy must be drop, append or override"}
+	errInvalidUTM        = map[string]string{"error": "utm keys must be utm_source, utm_medium, utm_campaign, utm_term or utm_content with non-empty values of at most 256 characters"}
+	errTooManyRules      = map[string]string{"error": "a link can have at most 20 rules"}
+	errInvalidVariants   = map[string]string{"error": "variants need 2 to 10 entries with weights from 1 to 1000 and either unique names or none"}
+	errInvalidBackups    = map[string]string{"error": "backups must be at most 5 urls and cannot be combined with variants"}
+	errInvalidRule       = map[string]string{"error": "each rule needs a url and a device (ios, android, mobile, desktop), languages or a time_window (HH:MM, IANA timezone)"}
+	errInvalidTags       = map[string]string{"error": "tags must be at most 10 values of 1 to 64 letters, digits, '-' or '_'"}
+	errInvalidCampaign   = map[string]string{"error": "campaign must be at most 128 characters without control characters"}
+	errInvalidSchedule   = map[string]string{"error": "active_from must be before the link expires"}
+	errInvalidPrelaunch  = map[string]string{"error": "prelaunch_url must be a valid url and requires active_from"}
+	errInvalidCursor     = map[string]string{"error": "invalid cursor"}
+	errInvalidLimit      = map[string]string{"error": "limit must be a positive integer"}
+	errFilterRequired    = map[string]string{"error": "tag or campaign is required"}
+	errListFailed        = map[string]string{"error": "failed to list urls"}
+	errHistoryFailed     = map[string]string{"error": "failed to get url history"}
 	respHealthOK         = map[string]string{"status": "ok"}
 )
 
@@ -37,6 +76,12 @@ type Handler struct {
 	urlValidator URLValidator
 	logger       *slog.Logger
 	recorder     BusinessRecorder
+	notYet       NotYetAvailable
+}
+
+type NotYetAvailable struct {
+	Status  int
+	Message string
 }
 
 func New(
@@ -44,21 +89,59 @@ func New(
 	urlValidator URLValidator,
 	logger *slog.Logger,
 	recorder BusinessRecorder,
+	notYet NotYetAvailable,
 ) *Handler {
 	return &Handler{
 		urlService:   urlService,
 		urlValidator: urlValidator,
 		logger:       logger,
 		recorder:     recorder,
+		notYet:       notYet,
 	}
 }
 
-func (h *Handler) Register(e *echo.Echo) {
+type RouteMiddleware struct {
+	Idempotent   echo.MiddlewareFunc
+	RequireScope func(scope string) echo.MiddlewareFunc
+	// RequireKey demands a key even when keys are optional elsewhere.
+	RequireKey func(scope string) echo.MiddlewareFunc
+}
+
+func (h *Handler) Register(e *echo.Echo, mw RouteMiddleware) {
+	create := mw.RequireScope(domain.ScopeLinksCreate)
+	update := mw.RequireKey(domain.ScopeLinksUpdate)
+	remove := mw.RequireKey(domain.ScopeLinksDelete)
+	stats := mw.RequireScope(domain.ScopeStatsRead)
+
 	api := e.Group("/api/v1")
 	api.GET("/health", h.Health)
-	api.POST("/urls", h.CreateURL)
-	api.POST("/urls/batch", h.CreateURLBatch)
+	api.POST("/urls", h.CreateURL, create, mw.Idempotent)
+	api.POST("/urls/batch", h.CreateURLBatch, create, mw.Idempotent)
+	api.GET("/urls", h.ListURLs, stats)
+	api.DELETE("/urls", h.DeleteURLs, remove)
+	api.POST("/urls/deactivate", h.DeactivateURLs, update)
+	api.POST("/urls/reactivate", h.ReactivateURLs, update)
+	api.POST("/urls/resolve", h.ResolveURLs, stats)
+	api.GET("/urls/:code", h.GetURLInfo, stats)
+	api.GET("/urls/:code/history", h.GetURLHistory, mw.RequireKey(domain.ScopeStatsRead))
+	api.PATCH("/urls/:code", h.UpdateURL, update)
+	api.DELETE("/urls/:code", h.DeleteURL, remove)
+	api.POST("/urls/:code/deactivate", h.DeactivateURL, update)
+	api.POST("/urls/:code/reactivate", h.ReactivateURL, update)
 	e.GET("/:code", h.Redirect)
+	e.POST("/:code", h.Redirect) // password form and 307/308 callbacks
+}
+
+func RootSegments(routes []*echo.Route) []string {
+	var segments []string
+	for _, r := range routes {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(r.Path, "/"), "/")
+		if segment == "" || strings.ContainsAny(segment, ":*") || slices.Contains(segments, segment) {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return segments
 }
 
 func (h *Handler) Health(c echo.Context) error {
@@ -72,16 +155,18 @@ func (h *Handler) CreateURL(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, errInvalidBody)
 	}
 
-	if err := h.urlValidator.ValidateURL(req.URL); err != nil {
+	if err := h.urlValidator.ValidateRequest(c.Request().Context(), &req); err != nil {
 		return h.handleValidationError(c, err)
 	}
 
-	resp, err := h.urlService.CreateShortURL(c.Request().Context(), req.URL)
+	resp, err := h.urlService.CreateShortURL(c.Request().Context(), &req)
 	if err != nil {
-		h.logger.Error("failed to create short url", slog.String("error", err.Error()))
-		return c.JSON(http.StatusInternalServerError, errCreateFailed)
+		return h.handleCreateError(c, err, "failed to create short url", errCreateFailed)
 	}
 
+	if resp.Deduplicated {
+		return c.JSON(http.StatusOK, resp)
+	}
 	return c.JSON(http.StatusCreated, resp)
 }
 
@@ -92,46 +177,238 @@ func (h *Handler) CreateURLBatch(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, errInvalidBody)
 	}
 
-	if err := h.urlValidator.ValidateBatch(req.URLs); err != nil {
+	if req.Dedupe {
+		for i := range req.URLs {
+			req.URLs[i].Dedupe = true
+		}
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = c.QueryParam("mode")
+	}
+	switch mode {
+	case "", domain.BatchModeAtomic:
+	case domain.BatchModePartial:
+		return h.createURLBatchPartial(c, req.URLs)
+	default:
+		return c.JSON(http.StatusBadRequest, errInvalidBatchMode)
+	}
+
+	if err := h.urlValidator.ValidateBatch(c.Request().Context(), req.URLs); err != nil {
 		return h.handleValidationError(c, err)
 	}
 
 	responses, err := h.urlService.CreateShortURLBatch(c.Request().Context(), req.URLs)
 	if err != nil {
-		h.logger.Error("failed to create short urls", slog.String("error", err.Error()))
-		return c.JSON(http.StatusInternalServerError, errCreateBatchFailed)
+		return h.handleCreateError(c, err, "failed to create short urls", errCreateBatchFailed)
 	}
 
 	return c.JSON(http.StatusCreated, domain.CreateURLBatchResponse{URLs: responses})
 }
 
-func (h *Handler) Redirect(c echo.Context) error {
+func (h *Handler) GetURLInfo(c echo.Context) error {
 	code := c.Param("code")
 	if code == "" {
 		return c.JSON(http.StatusBadRequest, errCodeRequired)
 	}
 
-	clientIP := c.RealIP()
-	referrer := extractDomain(c.Request().Referer())
-
-	originalURL, err := h.urlService.GetOriginalURL(c.Request().Context(), code)
+	info, err := h.urlService.GetURLInfo(c.Request().Context(), code)
 	if err != nil {
 		if errors.Is(err, service.ErrURLNotFound) {
-			labels := fmt.Appendf(nil, `{"short_code":%q,"client_ip":%q,"referrer":%q}`, code, clientIP, referrer)
-			h.recorder.RecordBusiness(time.Now(), "url_not_found", 1, labels)
 			return c.JSON(http.StatusNotFound, errURLNotFound)
 		}
-		h.logger.Error("failed to get original url", slog.String("error", err.Error()))
+		h.logger.Error("failed to get url info", slog.String("error", err.Error()))
 		return c.JSON(http.StatusInternalServerError, errGetFailed)
 	}
 
+	return c.JSON(http.StatusOK, info)
+}
+
+func (h *Handler) UpdateURL(c echo.Context) error {
+	code := c.Param("code")
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, errCodeRequired)
+	}
+
+	var req domain.UpdateURLRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", slog.String("error", err.Error()))
+		return c.JSON(http.StatusBadRequest, errInvalidBody)
+	}
+
+	if err := h.urlValidator.ValidateURL(c.Request().Context(), req.URL); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	resp, err := h.urlService.UpdateURL(c.Request().Context(), code, req.URL)
+	if err != nil {
+		if errors.Is(err, service.ErrURLNotFound) {
+			return c.JSON(http.StatusNotFound, errURLNotFound)
+		}
+		h.logger.Error("failed to update url", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errUpdateFailed)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) DeleteURL(c echo.Context) error {
+	code := c.Param("code")
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, errCodeRequired)
+	}
+
+	if err := h.urlService.DeleteURL(c.Request().Context(), code); err != nil {
+		if errors.Is(err, service.ErrURLNotFound) {
+			return c.JSON(http.StatusNotFound, errURLNotFound)
+		}
+		h.logger.Error("failed to delete url", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errDeleteFailed)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) DeactivateURL(c echo.Context) error {
+	return h.setActive(c, false)
+}
+
+func (h *Handler) ReactivateURL(c echo.Context) error {
+	return h.setActive(c, true)
+}
+
+func (h *Handler) setActive(c echo.Context, active bool) error {
+	code := c.Param("code")
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, errCodeRequired)
+	}
+
+	if err := h.urlService.SetURLActive(c.Request().Context(), code, active); err != nil {
+		if errors.Is(err, service.ErrURLNotFound) {
+			return c.JSON(http.StatusNotFound, errURLNotFound)
+		}
+		h.logger.Error("failed to update url", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errUpdateFailed)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) Redirect(c echo.Context) error {
+	code, preview := strings.CutSuffix(c.Param("code"), previewSuffix)
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, errCodeRequired)
+	}
+
+	clientIP := c.RealIP()
+	referrer := extractDomain(c.Request().Referer())
+
+	visit := domain.Visit{
+		Password: c.Request().Header.Get(passwordHeader),
+		Preview:  preview,
+		Request: routing.Request{
+			UserAgent:      c.Request().UserAgent(),
+			AcceptLanguage: c.Request().Header.Get("Accept-Language"),
+		},
+	}
+	if query := c.QueryParams(); len(query) > 0 {
+		visit.Query = query
+	}
+	if cookie, err := c.Cookie(variantCookieName(code)); err == nil {
+		visit.Variant = cookie.Value
+	}
+	redirect, err := h.urlService.GetOriginalURL(c.Request().Context(), code, visit)
+	// Only protected links read the form; other POST bodies are left alone.
+	var fromForm bool
+	if errors.Is(err, service.ErrPasswordRequired) && c.Request().Method == http.MethodPost {
+		if password := c.FormValue("password"); password != "" {
+			visit.Password, fromForm = password, true
+			redirect, err = h.urlService.GetOriginalURL(c.Request().Context(), code, visit)
+		}
+	}
+	if err != nil {
+		var (
+			status int
+			body   map[string]string
+			metric string
+			notYet *service.NotYetActiveError
+		)
+		switch {
+		case errors.Is(err, service.ErrURLNotFound):
+			status, body, metric = http.StatusNotFound, errURLNotFound, "url_not_found"
+		case errors.Is(err, service.ErrURLInactive):
+			status, body, metric = http.StatusNotFound, errURLInactive, "url_inactive"
+		case errors.Is(err, service.ErrURLExpired):
+			status, body, metric = http.StatusGone, errURLExpired, "url_expired"
+		case errors.As(err, &notYet):
+			status, metric = h.notYet.Status, "url_not_yet_active"
+			body = map[string]string{"error": h.notYet.Message, "active_from": notYet.ActiveFrom.UTC().Format(time.RFC3339)}
+			// Nothing may cache this past the launch.
+			c.Response().Header().Set(echo.HeaderCacheControl, "private, no-cache")
+			c.Response().Header().Set(echo.HeaderRetryAfter, retryAfter(notYet.ActiveFrom, time.Now()))
+		case errors.Is(err, service.ErrPasswordRequired):
+			return h.passwordPrompt(c, http.StatusUnauthorized, errPasswordRequired, "")
+		case errors.Is(err, service.ErrPasswordInvalid):
+			status, body, metric = http.StatusUnauthorized, errPasswordInvalid, "password_failed"
+		case errors.Is(err, service.ErrTooManyAttempts):
+			status, body, metric = http.StatusTooManyRequests, errTooManyAttempts, "password_blocked"
+		default:
+			h.logger.Error("failed to get original url", slog.String("error", err.Error()))
+			return c.JSON(http.StatusInternalServerError, errGetFailed)
+		}
+		labels := fmt.Appendf(nil, `{"short_code":%q,"client_ip":%q,"referrer":%q}`, code, clientIP, referrer)
+		h.recorder.RecordBusiness(time.Now(), metric, 1, labels)
+		if errors.Is(err, service.ErrPasswordInvalid) || errors.Is(err, service.ErrTooManyAttempts) {
+			return h.passwordPrompt(c, status, body, body["error"])
+		}
+		return c.JSON(status, body)
+	}
+
+	if preview {
+		return h.renderPreview(c, code, redirect)
+	}
+
 	now := time.Now()
 	visitorsLabels := fmt.Appendf(nil, `{"short_code":%q,"client_ip":%q}`, code, clientIP)
 	referrerLabels := fmt.Appendf(nil, `{"short_code":%q,"referrer":%q}`, code, referrer)
 	h.recorder.RecordBusiness(now, "unique_visitors", 1, visitorsLabels)
 	h.recorder.RecordBusiness(now, "referrer_redirects", 1, referrerLabels)
 
-	return c.Redirect(http.StatusFound, originalURL)
+	if redirect.Sticky && redirect.Variant != visit.Variant {
+		c.SetCookie(variantCookie(code, redirect.Variant))
+	}
+
+	// 303 so the password form is not replayed to the destination.
+	if fromForm {
+		return c.Redirect(http.StatusSeeOther, redirect.URL)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, redirectCacheControl(redirect, now))
+	return c.Redirect(redirect.Status, redirect.URL)
+}
+
+func retryAfter(t, now time.Time) string {
+	return strconv.Itoa(max(1, int(math.Ceil(t.Sub(now).Seconds()))))
+}
+
+// Kept short: a changed link keeps redirecting from browser caches until then.
+const permanentRedirectMaxAge = 5 * time.Minute
+
+// Private only, since a CDN copy could not be invalidated when the link changes.
+func redirectCacheControl(r *domain.Redirect, now time.Time) string {
+	if !r.Permanent() || r.Protected || r.Conditional {
+		return "private, no-cache"
+	}
+
+	maxAge := permanentRedirectMaxAge
+	if r.ExpiresAt != nil {
+		maxAge = min(maxAge, r.ExpiresAt.Sub(now))
+	}
+	if maxAge < time.Second {
+		return "private, no-cache"
+	}
+	return fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
 }
 
 func extractDomain(referer string) string {
@@ -145,6 +422,18 @@ func extractDomain(referer string) string {
 	return parsed.Host
 }
 
+func (h *Handler) handleCreateError(c echo.Context, err error, msg string, failure map[string]string) error {
+	switch {
+	case errors.Is(err, service.ErrAliasTaken):
+		return c.JSON(http.StatusConflict, errAliasTaken)
+	case errors.Is(err, service.ErrAliasReserved):
+		return c.JSON(http.StatusBadRequest, errAliasReserved)
+	default:
+		h.logger.Error(msg, slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, failure)
+	}
+}
+
 func (h *Handler) handleValidationError(c echo.Context, err error) error {
 	switch {
 	case errors.Is(err, validation.ErrEmptyURL):
@@ -157,10 +446,52 @@ func (h *Handler) handleValidationError(c echo.Context, err error) error {
 		return c.JSON(http.StatusBadRequest, errURLTooLong)
 	case errors.Is(err, validation.ErrPrivateIPNotAllowed):
 		return c.JSON(http.StatusBadRequest, errPrivateIP)
+	case errors.Is(err, validation.ErrUnresolvableHost):
+		return c.JSON(http.StatusBadRequest, errUnresolvableHost)
 	case errors.Is(err, validation.ErrBatchTooLarge):
 		return c.JSON(http.StatusBadRequest, errBatchTooLarge)
 	case errors.Is(err, validation.ErrEmptyBatch):
 		return c.JSON(http.StatusBadRequest, errURLsRequired)
+	case errors.Is(err, validation.ErrEmptyCodes):
+		return c.JSON(http.StatusBadRequest, errCodesRequired)
+	case errors.Is(err, validation.ErrInvalidAlias):
+		return c.JSON(http.StatusBadRequest, errInvalidAlias)
+	case errors.Is(err, validation.ErrReservedAlias):
+		return c.JSON(http.StatusBadRequest, errAliasReserved)
+	case errors.Is(err, validation.ErrDuplicateAlias):
+		return c.JSON(http.StatusBadRequest, errDuplicateAlias)
+	case errors.Is(err, validation.ErrConflictingExpiry):
+		return c.JSON(http.StatusBadRequest, errConflictingExpiry)
+	case errors.Is(err, validation.ErrInvalidTTL):
+		return c.JSON(http.StatusBadRequest, errInvalidTTL)
+	case errors.Is(err, validation.ErrExpiryInPast):
+		return c.JSON(http.StatusBadRequest, errExpiryInPast)
+	case errors.Is(err, validation.ErrPasswordTooLong):
+		return c.JSON(http.StatusBadRequest, errPasswordTooLong)
+	case errors.Is(err, validation.ErrPasswordInBatch):
+		return c.JSON(http.StatusBadRequest, errPasswordInBatch)
+	case errors.Is(err, validation.ErrInvalidRedirect):
+		return c.JSON(http.StatusBadRequest, errInvalidRedirect)
+	case errors.Is(err, validation.ErrInvalidQueryPolicy):
+		return c.JSON(http.StatusBadRequest, errInvalidPolicy)
+	case errors.Is(err, validation.ErrInvalidUTM):
+		return c.JSON(http.StatusBadRequest, errInvalidUTM)
+	case errors.Is(err, validation.ErrTooManyRules):
+		return c.JSON(http.StatusBadRequest, errTooManyRules)
+	case errors.Is(err, validation.ErrInvalidRule):
+		return c.JSON(http.StatusBadRequest, errInvalidRule)
+	case errors.Is(err, validation.ErrInvalidVariants):
+		return c.JSON(http.StatusBadRequest, errInvalidVariants)
+	case errors.Is(err, validation.ErrInvalidBackups):
+		return c.JSON(http.StatusBadRequest, errInvalidBackups)
+	case errors.Is(err, validation.ErrInvalidTags):
+		return c.JSON(http.StatusBadRequest, errInvalidTags)
+	case errors.Is(err, validation.ErrInvalidCampaign):
+		return c.JSON(http.StatusBadRequest, errInvalidCampaign)
+	case errors.Is(err, validation.ErrInvalidSchedule):
+		return c.JSON(http.StatusBadRequest, errInvalidSchedule)
+	case errors.Is(err, validation.ErrInvalidPrelaunch):
+		return c.JSON(http.StatusBadRequest, errInvalidPrelaunch)
 	default:
 		var batchErr *validation.BatchValidationError
 		if errors.As(err, &batchErr) {
@@ -176,6 +507,7 @@ func (h *Handler) formatBatchErrors(err *validation.BatchValidationError) map[st
 		errs[i] = map[string]any{
 			"index": e.Index,
 			"error": e.Err.Error(),
+			"code":  entryErrorCode(e.Err),
 		}
 	}
 	return map[string]any{"errors": errs}
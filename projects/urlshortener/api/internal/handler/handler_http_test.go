@@ -5,9 +5,11 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +19,7 @@ import (
 	"urlshortener/internal/domain"
 	"urlshortener/internal/handler"
 	"urlshortener/internal/handler/mocks"
+	"urlshortener/internal/routing"
 	"urlshortener/internal/service"
 	"urlshortener/internal/validation"
 )
@@ -26,7 +29,7 @@ func newTestHandler(t *testing.T) (*handler.Handler, *mocks.MockURLService, *moc
 	svc := mocks.NewMockURLService(t)
 	val := mocks.NewMockURLValidator(t)
 	rec := mocks.NewMockBusinessRecorder(t)
-	h := handler.New(svc, val, logger, rec)
+	h := handler.New(svc, val, logger, rec, handler.NotYetAvailable{Status: http.StatusNotFound, Message: "url is not yet available"})
 	return h, svc, val, rec
 }
 
@@ -35,8 +38,8 @@ func newTestHandler(t *testing.T) (*handler.Handler, *mocks.MockURLService, *moc
 func TestCreateURL_Success(t *testing.T) {
 	h, svc, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateURL("https://example.com").Return(nil)
-	svc.EXPECT().CreateShortURL(mock.Anything, "https://example.com").Return(&domain.CreateURLResponse{
+	val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: "https://example.com"}).Return(nil)
+	svc.EXPECT().CreateShortURL(mock.Anything, &domain.CreateURLRequest{URL: "https://example.com"}).Return(&domain.CreateURLResponse{
 		ShortCode:   "xyz789",
 		ShortURL:    "http://short.url/xyz789",
 		OriginalURL: "https://example.com",
@@ -71,7 +74,7 @@ func TestCreateURL_InvalidJSON(t *testing.T) {
 func TestCreateURL_EmptyURL(t *testing.T) {
 	h, _, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateURL("").Return(validation.ErrEmptyURL)
+	val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: ""}).Return(validation.ErrEmptyURL)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls", strings.NewReader(`{"url":""}`))
@@ -88,7 +91,7 @@ func TestCreateURL_EmptyURL(t *testing.T) {
 func TestCreateURL_InvalidURLFormat(t *testing.T) {
 	h, _, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateURL("not-a-url").Return(validation.ErrInvalidURLFormat)
+	val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: "not-a-url"}).Return(validation.ErrInvalidURLFormat)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls", strings.NewReader(`{"url":"not-a-url"}`))
@@ -105,8 +108,8 @@ func TestCreateURL_InvalidURLFormat(t *testing.T) {
 func TestCreateURL_ServiceError(t *testing.T) {
 	h, svc, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateURL("https://example.com").Return(nil)
-	svc.EXPECT().CreateShortURL(mock.Anything, "https://example.com").Return(nil, errors.New("db error"))
+	val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: "https://example.com"}).Return(nil)
+	svc.EXPECT().CreateShortURL(mock.Anything, &domain.CreateURLRequest{URL: "https://example.com"}).Return(nil, errors.New("db error"))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls", strings.NewReader(`{"url":"https://example.com"}`))
@@ -119,13 +122,151 @@ func TestCreateURL_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
+func TestCreateURL_WithAlias(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	req := &domain.CreateURLRequest{URL: "https://example.com", Alias: "spring-sale"}
+	val.EXPECT().ValidateRequest(mock.Anything, req).Return(nil)
+	svc.EXPECT().CreateShortURL(mock.Anything, req).Return(&domain.CreateURLResponse{
+		ShortCode:   "spring-sale",
+		ShortURL:    "http://short.url/spring-sale",
+		OriginalURL: "https://example.com",
+	}, nil)
+
+	e := echo.New()
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/urls",
+		strings.NewReader(`{"url":"https://example.com","alias":"spring-sale"}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	err := h.CreateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "spring-sale")
+}
+
+func TestCreateURL_AliasTaken(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: "https://example.com", Alias: "spring-sale"}).Return(nil)
+	svc.EXPECT().CreateShortURL(mock.Anything, mock.Anything).Return(nil, service.ErrAliasTaken)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls",
+		strings.NewReader(`{"url":"https://example.com","alias":"spring-sale"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.CreateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "alias already taken")
+}
+
+func TestCreateURL_InvalidAlias(t *testing.T) {
+	h, _, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: "https://example.com", Alias: "api"}).Return(validation.ErrReservedAlias)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls",
+		strings.NewReader(`{"url":"https://example.com","alias":"api"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.CreateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "alias is reserved")
+}
+
+func TestCreateURL_Deduplicated(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	req := &domain.CreateURLRequest{URL: "https://example.com", Dedupe: true}
+	val.EXPECT().ValidateRequest(mock.Anything, req).Return(nil)
+	svc.EXPECT().CreateShortURL(mock.Anything, req).Return(&domain.CreateURLResponse{
+		ShortCode:    "old123",
+		ShortURL:     "http://short.url/old123",
+		OriginalURL:  "https://example.com",
+		Deduplicated: true,
+	}, nil)
+
+	e := echo.New()
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/urls",
+		strings.NewReader(`{"url":"https://example.com","dedupe":true}`))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httpReq, rec)
+
+	err := h.CreateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deduplicated":true`)
+}
+
 // CreateURLBatch tests
 
+func TestCreateURLBatch_DedupeAppliesToEntries(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateBatch(mock.Anything, mock.Anything).Return(nil)
+	svc.EXPECT().CreateShortURLBatch(mock.Anything, []domain.CreateURLRequest{
+		{URL: "https://example.com/1", Dedupe: true},
+		{URL: "https://example.com/1", Dedupe: true},
+	}).Return([]domain.CreateURLResponse{
+		{ShortCode: "code0", ShortURL: "http://short.url/code0", OriginalURL: "https://example.com/1"},
+		{ShortCode: "code0", ShortURL: "http://short.url/code0", OriginalURL: "https://example.com/1", Deduplicated: true},
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/batch",
+		strings.NewReader(`{"urls":["https://example.com/1","https://example.com/1"],"dedupe":true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.CreateURLBatch(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateURLBatch_MixedEntries(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	reqs := []domain.CreateURLRequest{
+		{URL: "https://example.com/1"},
+		{URL: "https://example.com/2", Alias: "spring-sale"},
+	}
+	val.EXPECT().ValidateBatch(mock.Anything, reqs).Return(nil)
+	svc.EXPECT().CreateShortURLBatch(mock.Anything, reqs).
+		Return([]domain.CreateURLResponse{
+			{ShortCode: "code0", ShortURL: "http://short.url/code0", OriginalURL: "https://example.com/1"},
+			{ShortCode: "spring-sale", ShortURL: "http://short.url/spring-sale", OriginalURL: "https://example.com/2"},
+		}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/batch",
+		strings.NewReader(`{"urls":["https://example.com/1",{"url":"https://example.com/2","alias":"spring-sale"}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.CreateURLBatch(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "spring-sale")
+}
+
 func TestCreateURLBatch_Success(t *testing.T) {
 	h, svc, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateBatch([]string{"https://example.com/1", "https://example.com/2"}).Return(nil)
-	svc.EXPECT().CreateShortURLBatch(mock.Anything, []string{"https://example.com/1", "https://example.com/2"}).
+	reqs := []domain.CreateURLRequest{{URL: "https://example.com/1"}, {URL: "https://example.com/2"}}
+	val.EXPECT().ValidateBatch(mock.Anything, reqs).Return(nil)
+	svc.EXPECT().CreateShortURLBatch(mock.Anything, reqs).
 		Return([]domain.CreateURLResponse{
 			{ShortCode: "code0", ShortURL: "http://short.url/code0", OriginalURL: "https://example.com/1"},
 			{ShortCode: "code1", ShortURL: "http://short.url/code1", OriginalURL: "https://example.com/2"},
@@ -146,7 +287,7 @@ func TestCreateURLBatch_Success(t *testing.T) {
 func TestCreateURLBatch_EmptyBatch(t *testing.T) {
 	h, _, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateBatch([]string{}).Return(validation.ErrEmptyBatch)
+	val.EXPECT().ValidateBatch(mock.Anything, []domain.CreateURLRequest{}).Return(validation.ErrEmptyBatch)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/batch", strings.NewReader(`{"urls":[]}`))
@@ -163,7 +304,7 @@ func TestCreateURLBatch_EmptyBatch(t *testing.T) {
 func TestCreateURLBatch_TooLarge(t *testing.T) {
 	h, _, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateBatch([]string{"url1", "url2"}).Return(validation.ErrBatchTooLarge)
+	val.EXPECT().ValidateBatch(mock.Anything, []domain.CreateURLRequest{{URL: "url1"}, {URL: "url2"}}).Return(validation.ErrBatchTooLarge)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/batch", strings.NewReader(`{"urls":["url1","url2"]}`))
@@ -180,7 +321,7 @@ func TestCreateURLBatch_TooLarge(t *testing.T) {
 func TestCreateURLBatch_BatchValidationError(t *testing.T) {
 	h, _, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateBatch([]string{"https://example.com", "javascript:alert(1)"}).
+	val.EXPECT().ValidateBatch(mock.Anything, []domain.CreateURLRequest{{URL: "https://example.com"}, {URL: "javascript:alert(1)"}}).
 		Return(&validation.BatchValidationError{
 			Errors: []validation.IndexedError{
 				{Index: 1, Err: validation.ErrUnsafeProtocol},
@@ -203,8 +344,8 @@ func TestCreateURLBatch_BatchValidationError(t *testing.T) {
 func TestCreateURLBatch_ServiceError(t *testing.T) {
 	h, svc, val, _ := newTestHandler(t)
 
-	val.EXPECT().ValidateBatch([]string{"https://example.com"}).Return(nil)
-	svc.EXPECT().CreateShortURLBatch(mock.Anything, []string{"https://example.com"}).
+	val.EXPECT().ValidateBatch(mock.Anything, []domain.CreateURLRequest{{URL: "https://example.com"}}).Return(nil)
+	svc.EXPECT().CreateShortURLBatch(mock.Anything, []domain.CreateURLRequest{{URL: "https://example.com"}}).
 		Return(nil, errors.New("batch error"))
 
 	e := echo.New()
@@ -218,12 +359,201 @@ func TestCreateURLBatch_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
+// GetURLInfo tests
+
+func TestGetURLInfo_Success(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().GetURLInfo(mock.Anything, "abc123").Return(&domain.URLInfo{
+		ShortCode:   "abc123",
+		ShortURL:    "http://short.url/abc123",
+		OriginalURL: "https://example.com",
+		Active:      true,
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/urls/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.GetURLInfo(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Location"))
+	assert.Contains(t, rec.Body.String(), `"original_url":"https://example.com"`)
+	assert.Contains(t, rec.Body.String(), `"active":true`)
+}
+
+func TestGetURLInfo_NotFound(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().GetURLInfo(mock.Anything, "notfound").Return(nil, service.ErrURLNotFound)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/urls/notfound", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("notfound")
+
+	err := h.GetURLInfo(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// UpdateURL tests
+
+func TestUpdateURL_Success(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateURL(mock.Anything, "https://new.example.com").Return(nil)
+	svc.EXPECT().UpdateURL(mock.Anything, "abc123", "https://new.example.com").Return(&domain.CreateURLResponse{
+		ShortCode:   "abc123",
+		ShortURL:    "http://short.url/abc123",
+		OriginalURL: "https://new.example.com",
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/urls/abc123", strings.NewReader(`{"url":"https://new.example.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.UpdateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "https://new.example.com")
+}
+
+func TestUpdateURL_InvalidURL(t *testing.T) {
+	h, _, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateURL(mock.Anything, "javascript:alert(1)").Return(validation.ErrUnsafeProtocol)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/urls/abc123", strings.NewReader(`{"url":"javascript:alert(1)"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.UpdateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "url protocol not allowed")
+}
+
+func TestUpdateURL_NotFound(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateURL(mock.Anything, "https://new.example.com").Return(nil)
+	svc.EXPECT().UpdateURL(mock.Anything, "notfound", "https://new.example.com").Return(nil, service.ErrURLNotFound)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/urls/notfound", strings.NewReader(`{"url":"https://new.example.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("notfound")
+
+	err := h.UpdateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// DeleteURL tests
+
+func TestDeleteURL_Success(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().DeleteURL(mock.Anything, "abc123").Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/urls/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.DeleteURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestDeleteURL_NotFound(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().DeleteURL(mock.Anything, "notfound").Return(service.ErrURLNotFound)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/urls/notfound", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("notfound")
+
+	err := h.DeleteURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// DeactivateURL / ReactivateURL tests
+
+func TestDeactivateURL_Success(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().SetURLActive(mock.Anything, "abc123", false).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/abc123/deactivate", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code/deactivate")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.DeactivateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestReactivateURL_ServiceError(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().SetURLActive(mock.Anything, "abc123", true).Return(errors.New("db error"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/abc123/reactivate", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code/reactivate")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.ReactivateURL(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
 // Redirect tests
 
 func TestRedirect_Success(t *testing.T) {
 	h, svc, _, recorder := newTestHandler(t)
 
-	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123").Return("https://example.com/redirect-target", nil)
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(&domain.Redirect{URL: "https://example.com/redirect-target", Status: http.StatusFound}, nil)
 	recorder.EXPECT().RecordBusiness(mock.Anything, "unique_visitors", float64(1), mock.Anything).Return()
 	recorder.EXPECT().RecordBusiness(mock.Anything, "referrer_redirects", float64(1), mock.Anything).Return()
 
@@ -260,7 +590,7 @@ func TestRedirect_EmptyCode(t *testing.T) {
 func TestRedirect_NotFound(t *testing.T) {
 	h, svc, _, recorder := newTestHandler(t)
 
-	svc.EXPECT().GetOriginalURL(mock.Anything, "notfound").Return("", service.ErrURLNotFound)
+	svc.EXPECT().GetOriginalURL(mock.Anything, "notfound", domain.Visit{}).Return(nil, service.ErrURLNotFound)
 	recorder.EXPECT().RecordBusiness(mock.Anything, "url_not_found", float64(1), mock.Anything).Return()
 
 	e := echo.New()
@@ -276,10 +606,96 @@ func TestRedirect_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
+func TestRedirect_Expired(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, service.ErrURLExpired)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "url_expired", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGone, rec.Code)
+	assert.Empty(t, rec.Header().Get("Location"))
+}
+
+func TestRedirect_Inactive(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, service.ErrURLInactive)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "url_inactive", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "url is inactive")
+}
+
+func TestRedirect_NotYetActive(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	activeFrom := time.Now().Add(90 * time.Second).Truncate(time.Second)
+	svc.EXPECT().GetOriginalURL(mock.Anything, "launch", domain.Visit{}).Return(nil, &service.NotYetActiveError{ActiveFrom: activeFrom})
+	recorder.EXPECT().RecordBusiness(mock.Anything, "url_not_yet_active", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/launch", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("launch")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error":"url is not yet available","active_from":"`+activeFrom.UTC().Format(time.RFC3339)+`"}`, rec.Body.String())
+	assert.Equal(t, "private, no-cache", rec.Header().Get(echo.HeaderCacheControl))
+	assert.Contains(t, []string{"89", "90"}, rec.Header().Get(echo.HeaderRetryAfter))
+}
+
+func TestRedirect_Prelaunch(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "launch", domain.Visit{}).
+		Return(&domain.Redirect{URL: "https://example.com/teaser", Status: http.StatusFound, Conditional: true}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "unique_visitors", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "referrer_redirects", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/launch", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("launch")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://example.com/teaser", rec.Header().Get("Location"))
+	assert.Equal(t, "private, no-cache", rec.Header().Get(echo.HeaderCacheControl))
+}
+
 func TestRedirect_ServiceError(t *testing.T) {
 	h, svc, _, _ := newTestHandler(t)
 
-	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123").Return("", errors.New("db error"))
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, errors.New("db error"))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
@@ -308,15 +724,19 @@ func TestHandleValidationError_AllErrorTypes(t *testing.T) {
 		{"ErrUnsafeProtocol", validation.ErrUnsafeProtocol, http.StatusBadRequest, "url protocol not allowed"},
 		{"ErrURLTooLong", validation.ErrURLTooLong, http.StatusBadRequest, "url exceeds maximum length"},
 		{"ErrPrivateIPNotAllowed", validation.ErrPrivateIPNotAllowed, http.StatusBadRequest, "private ip addresses not allowed"},
+		{"ErrUnresolvableHost", validation.ErrUnresolvableHost, http.StatusBadRequest, "url host could not be resolved"},
 		{"ErrBatchTooLarge", validation.ErrBatchTooLarge, http.StatusBadRequest, "batch size exceeds maximum"},
 		{"ErrEmptyBatch", validation.ErrEmptyBatch, http.StatusBadRequest, "urls is required"},
+		{"ErrConflictingExpiry", validation.ErrConflictingExpiry, http.StatusBadRequest, "mutually exclusive"},
+		{"ErrInvalidTTL", validation.ErrInvalidTTL, http.StatusBadRequest, "ttl must be positive and at most 10 years"},
+		{"ErrExpiryInPast", validation.ErrExpiryInPast, http.StatusBadRequest, "must be in the future"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h, _, val, _ := newTestHandler(t)
 
-			val.EXPECT().ValidateURL("test").Return(tt.err)
+			val.EXPECT().ValidateRequest(mock.Anything, &domain.CreateURLRequest{URL: "test"}).Return(tt.err)
 
 			e := echo.New()
 			req := httptest.NewRequest(http.MethodPost, "/api/v1/urls", strings.NewReader(`{"url":"test"}`))
@@ -362,3 +782,400 @@ func TestCreateURLBatch_InvalidJSON(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func TestRedirect_PasswordRequiredServesForm(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, service.ErrPasswordRequired)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	req.Header.Set(echo.HeaderAccept, "text/html,application/xhtml+xml")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+	assert.Contains(t, rec.Body.String(), `name="password"`)
+}
+
+func TestRedirect_PasswordRequiredJSON(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, service.ErrPasswordRequired)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "password required")
+}
+
+func TestRedirect_PasswordHeader(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{Password: "secret"}).Return(&domain.Redirect{URL: "https://docs.example.com", Status: http.StatusFound}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	req.Header.Set("X-Link-Password", "secret")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://docs.example.com", rec.Header().Get("Location"))
+}
+
+func TestRedirect_PasswordFormSubmit(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, service.ErrPasswordRequired)
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{Password: "secret"}).Return(&domain.Redirect{URL: "https://docs.example.com", Status: http.StatusFound, Protected: true}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/abc123", strings.NewReader("password=secret"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "https://docs.example.com", rec.Header().Get("Location"))
+}
+
+func TestRedirect_PostToUnprotectedLinkKeepsStatus(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(&domain.Redirect{URL: "https://api.example.com/hook", Status: http.StatusPermanentRedirect}, nil).Once()
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/abc123", strings.NewReader("password=hunter2&user=ci"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+	assert.Equal(t, "https://api.example.com/hook", rec.Header().Get("Location"))
+}
+
+func TestRedirect_PasswordInvalidRecordsMetric(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(nil, service.ErrPasswordRequired)
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{Password: "guess"}).Return(nil, service.ErrPasswordInvalid)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "password_failed", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/abc123", strings.NewReader("password=guess"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.Header.Set(echo.HeaderAccept, "text/html")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid password")
+}
+
+func TestRedirect_PasswordBlocked(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{Password: "guess"}).Return(nil, service.ErrTooManyAttempts)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "password_blocked", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	req.Header.Set("X-Link-Password", "guess")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestRedirect_StatusAndCaching(t *testing.T) {
+	soon := time.Now().Add(2 * time.Minute)
+
+	tests := []struct {
+		name         string
+		redirect     domain.Redirect
+		cacheControl string
+	}{
+		{
+			name:         "temporary",
+			redirect:     domain.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect},
+			cacheControl: "private, no-cache",
+		},
+		{
+			name:         "permanent",
+			redirect:     domain.Redirect{URL: "https://example.com", Status: http.StatusMovedPermanently},
+			cacheControl: "private, max-age=300",
+		},
+		{
+			name:         "permanent capped by expiry",
+			redirect:     domain.Redirect{URL: "https://example.com", Status: http.StatusPermanentRedirect, ExpiresAt: &soon},
+			cacheControl: "private, max-age=119",
+		},
+		{
+			name:         "permanent but protected",
+			redirect:     domain.Redirect{URL: "https://example.com", Status: http.StatusMovedPermanently, Protected: true},
+			cacheControl: "private, no-cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc, _, recorder := newTestHandler(t)
+
+			svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(&tt.redirect, nil)
+			recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/abc123", nil), rec)
+			c.SetPath("/:code")
+			c.SetParamNames("code")
+			c.SetParamValues("abc123")
+
+			err := h.Redirect(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.redirect.Status, rec.Code)
+			assert.Equal(t, tt.cacheControl, rec.Header().Get(echo.HeaderCacheControl))
+		})
+	}
+}
+
+func TestRedirect_PostKeepsLinkStatus(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).
+		Return(&domain.Redirect{URL: "https://hooks.example.com", Status: http.StatusPermanentRedirect}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/abc123", strings.NewReader(`{"event":"ping"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+	assert.Equal(t, "https://hooks.example.com", rec.Header().Get("Location"))
+}
+
+func TestRedirect_PreviewSuffix(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	created := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC)
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{Preview: true}).Return(&domain.Redirect{
+		URL:       "https://docs.example.com/guide?a=1&b=2",
+		Status:    http.StatusMovedPermanently,
+		CreatedAt: created,
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123+", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123+")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Location"))
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+	assert.Equal(t, "private, no-cache", rec.Header().Get(echo.HeaderCacheControl))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "docs.example.com")
+	assert.Contains(t, body, `href="https://docs.example.com/guide?a=1&amp;b=2"`)
+	assert.Contains(t, body, "March 4, 2025")
+	assert.Contains(t, body, "Continue")
+}
+
+func TestRedirect_PreviewNotFound(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "missing", domain.Visit{Preview: true}).Return(nil, service.ErrURLNotFound)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "url_not_found", float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/missing+", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("missing+")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRedirect_PreviewSuffixOnly(t *testing.T) {
+	h, _, _, _ := newTestHandler(t)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/+", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("+")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRedirect_PassesQueryToService(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	visit := domain.Visit{Query: url.Values{"utm_source": {"newsletter"}}}
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", visit).Return(&domain.Redirect{URL: "https://example.com/?utm_source=newsletter", Status: http.StatusFound}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123?utm_source=newsletter", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://example.com/?utm_source=newsletter", rec.Header().Get("Location"))
+}
+
+func TestRedirect_ConditionalIsNotCached(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	visit := domain.Visit{Request: routing.Request{UserAgent: "Mozilla/5.0 (iPhone)", AcceptLanguage: "de-DE,de;q=0.9"}}
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", visit).Return(&domain.Redirect{
+		URL:         "https://apps.apple.com/app/id1",
+		Status:      http.StatusMovedPermanently,
+		Conditional: true,
+	}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone)")
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "https://apps.apple.com/app/id1", rec.Header().Get("Location"))
+	assert.Equal(t, "private, no-cache", rec.Header().Get(echo.HeaderCacheControl))
+}
+
+func TestRedirect_StickyVariantSetsCookie(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{}).Return(&domain.Redirect{
+		URL:         "https://example.com/landing-b",
+		Status:      http.StatusFound,
+		Conditional: true,
+		Variant:     "b",
+		Sticky:      true,
+	}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/landing-b", rec.Header().Get("Location"))
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "ab_abc123", cookies[0].Name)
+	assert.Equal(t, "b", cookies[0].Value)
+	assert.Equal(t, "/abc123", cookies[0].Path)
+	assert.Positive(t, cookies[0].MaxAge)
+	assert.True(t, cookies[0].HttpOnly)
+}
+
+func TestRedirect_StickyVariantFromCookie(t *testing.T) {
+	h, svc, _, recorder := newTestHandler(t)
+
+	svc.EXPECT().GetOriginalURL(mock.Anything, "abc123", domain.Visit{Variant: "a"}).Return(&domain.Redirect{
+		URL:     "https://example.com/landing-a",
+		Status:  http.StatusFound,
+		Variant: "a",
+		Sticky:  true,
+	}, nil)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	req.AddCookie(&http.Cookie{Name: "ab_abc123", Value: "a"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/:code")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+
+	err := h.Redirect(c)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/landing-a", rec.Header().Get("Location"))
+	assert.Empty(t, rec.Result().Cookies(), "an unchanged assignment is not re-sent")
+}
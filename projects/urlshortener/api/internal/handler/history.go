@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/service"
+)
+
+// GetURLHistory pages through the audit log of a link: every create, update,
+// delete, deactivation and reactivation, with the key or IP address behind it.
+// Pagination works as in ListURLs.
+func (h *Handler) GetURLHistory(c echo.Context) error {
+	code := c.Param("code")
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, errCodeRequired)
+	}
+	limit, ok := pageLimit(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, errInvalidLimit)
+	}
+
+	page, err := h.urlService.History(c.Request().Context(), code, c.QueryParam("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrURLNotFound):
+			return c.JSON(http.StatusNotFound, errURLNotFound)
+		case errors.Is(err, service.ErrInvalidCursor):
+			return c.JSON(http.StatusBadRequest, errInvalidCursor)
+		}
+		h.logger.Error("failed to get url history", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errHistoryFailed)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/service"
+)
+
+func getHistory(t *testing.T, h *handler.Handler, code, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/urls/"+code+"/history"+query, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code/history")
+	c.SetParamNames("code")
+	c.SetParamValues(code)
+	require.NoError(t, h.GetURLHistory(c))
+	return rec
+}
+
+func TestGetURLHistory_Success(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	keyID := int64(7)
+	svc.EXPECT().History(mock.Anything, "abc123", "Mw", 10).Return(&domain.HistoryPage{
+		ShortCode: "abc123",
+		Entries: []domain.AuditEntry{{
+			ID:         4,
+			Action:     domain.AuditUpdate,
+			ActorKeyID: &keyID,
+			OldValue:   json.RawMessage(`{"original_url": "https://old.example.com"}`),
+			NewValue:   json.RawMessage(`{"original_url": "https://new.example.com"}`),
+		}},
+	}, nil)
+
+	rec := getHistory(t, h, "abc123", "?cursor=Mw&limit=10")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"action":"update"`)
+	assert.Contains(t, rec.Body.String(), `"actor_key_id":7`)
+	assert.Contains(t, rec.Body.String(), `"old_value":{"original_url":"https://old.example.com"}`)
+}
+
+func TestGetURLHistory_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not found", service.ErrURLNotFound, http.StatusNotFound},
+		{"invalid cursor", service.ErrInvalidCursor, http.StatusBadRequest},
+		{"database", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc, _, _ := newTestHandler(t)
+			svc.EXPECT().History(mock.Anything, "abc123", "", 0).Return(nil, tt.err)
+
+			rec := getHistory(t, h, "abc123", "")
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestGetURLHistory_InvalidLimit(t *testing.T) {
+	h, _, _, _ := newTestHandler(t)
+
+	rec := getHistory(t, h, "abc123", "?limit=0")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+)
+
+const ImportPath = "/api/v1/urls/import"
+
+const maxImportLineSize = 64 << 10
+
+var (
+	errUnsupportedImport = map[string]string{"error": "content type must be text/csv or application/x-ndjson"}
+	errInvalidCSVHeader  = map[string]string{"error": "csv header must have a url column and only url, alias, expires_at, tags and campaign columns"}
+)
+
+var (
+	errUnsupportedMediaType = errors.New("unsupported media type")
+	errCSVHeader            = errors.New("invalid csv header")
+	errRowTooLong           = errors.New("row exceeds 64KB")
+)
+
+var csvColumns = map[string]bool{"url": true, "alias": true, "expires_at": true, "tags": true, "campaign": true}
+
+type ImportHandler struct {
+	importer    URLImporter
+	validator   URLValidator
+	chunkSize   int
+	idleTimeout time.Duration
+	logger      *slog.Logger
+}
+
+func NewImportHandler(importer URLImporter, validator URLValidator, chunkSize int, idleTimeout time.Duration, logger *slog.Logger) *ImportHandler {
+	return &ImportHandler{
+		importer:    importer,
+		validator:   validator,
+		chunkSize:   max(1, chunkSize),
+		idleTimeout: idleTimeout,
+		logger:      logger,
+	}
+}
+
+func (h *ImportHandler) Register(e *echo.Echo, m ...echo.MiddlewareFunc) {
+	e.POST(ImportPath, h.ImportURLs, m...)
+}
+
+func (h *ImportHandler) ImportURLs(c echo.Context) error {
+	rows, err := newRowReader(c.Request())
+	if err != nil {
+		if errors.Is(err, errUnsupportedMediaType) {
+			return c.JSON(http.StatusUnsupportedMediaType, errUnsupportedImport)
+		}
+		return c.JSON(http.StatusBadRequest, errInvalidCSVHeader)
+	}
+
+	// Results are written while the body is still being read.
+	rc := http.NewResponseController(c.Response())
+	_ = rc.EnableFullDuplex()
+	h.extendDeadlines(rc)
+
+	ctx := c.Request().Context()
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	resp.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(resp)
+
+	var (
+		summary domain.ImportSummary
+		pending []domain.ImportResult     // results of the current chunk
+		entries []domain.CreateURLRequest // parsed rows of the current chunk
+		slots   []int                     // index in pending of each entry
+		failed  bool                      // the import stopped early
+	)
+
+	flush := func() error {
+		if len(entries) > 0 {
+			// Validated a chunk at a time, so hostnames are resolved together.
+			errs, err := h.validator.ValidateBatchEntries(ctx, entries)
+			if err != nil {
+				return err
+			}
+			valid, validSlots := entries[:0], slots[:0]
+			for i, slot := range slots {
+				if errs[i] != nil {
+					pending[slot].Error = errs[i].Error()
+					continue
+				}
+				valid = append(valid, entries[i])
+				validSlots = append(validSlots, slot)
+			}
+			entries, slots = valid, validSlots
+		}
+		if len(entries) > 0 {
+			results, err := h.importer.ImportURLs(ctx, entries)
+			if err != nil {
+				return err
+			}
+			for i, slot := range slots {
+				results[i].Row = pending[slot].Row
+				pending[slot] = results[i]
+			}
+		}
+		for _, result := range pending {
+			if result.Error != "" {
+				summary.Failed++
+			} else {
+				summary.Succeeded++
+			}
+			if err := enc.Encode(result); err != nil {
+				return err
+			}
+		}
+		resp.Flush()
+		h.extendDeadlines(rc)
+		pending, entries, slots = pending[:0], entries[:0], slots[:0]
+		return nil
+	}
+
+	for !failed {
+		req, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr rowError
+		if err != nil && !errors.As(err, &rowErr) {
+			summary.Error = "failed to read request body"
+			if errors.Is(err, errRowTooLong) {
+				summary.Error = err.Error()
+			}
+			h.logger.Error("failed to read import", slog.String("error", err.Error()))
+			break
+		}
+
+		summary.Rows++
+		result := domain.ImportResult{Row: summary.Rows}
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			slots = append(slots, len(pending))
+			entries = append(entries, req)
+		}
+		pending = append(pending, result)
+
+		if len(pending) >= h.chunkSize {
+			if err := flush(); err != nil {
+				summary.Error = "failed to import urls"
+				h.logger.Error("failed to import urls", slog.String("error", err.Error()))
+				failed = true
+			}
+		}
+	}
+
+	if !failed {
+		if err := flush(); err != nil {
+			summary.Error = "failed to import urls"
+			h.logger.Error("failed to import urls", slog.String("error", err.Error()))
+		}
+	}
+	if err := enc.Encode(summary); err != nil {
+		h.logger.Error("failed to write import summary", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// The server timeouts are sized for ordinary requests, not a whole import.
+func (h *ImportHandler) extendDeadlines(rc *http.ResponseController) {
+	deadline := time.Now().Add(h.idleTimeout)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+}
+
+// A rowError skips one row; any other error ends the import.
+type rowReader interface {
+	next() (domain.CreateURLRequest, error)
+}
+
+type rowError string
+
+func (e rowError) Error() string {
+	return string(e)
+}
+
+func newRowReader(r *http.Request) (rowReader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case "text/csv":
+		return newCSVRows(r.Body)
+	case "application/x-ndjson", "application/jsonl":
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(make([]byte, 0, 4096), maxImportLineSize)
+		return &ndjsonRows{scanner: scanner}, nil
+	}
+	return nil, errUnsupportedMediaType
+}
+
+type ndjsonRows struct {
+	scanner *bufio.Scanner
+}
+
+func (r *ndjsonRows) next() (domain.CreateURLRequest, error) {
+	var req domain.CreateURLRequest
+	for r.scanner.Scan() {
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(line, &req); err != nil {
+			return req, rowError("invalid json")
+		}
+		return req, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return req, errRowTooLong
+		}
+		return req, err
+	}
+	return req, io.EOF
+}
+
+type csvRows struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVRows(body io.Reader) (*csvRows, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errCSVHeader
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // spreadsheet byte order mark
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, dup := columns[name]; dup || !csvColumns[name] {
+			return nil, errCSVHeader
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, errCSVHeader
+	}
+	return &csvRows{reader: reader, columns: columns}, nil
+}
+
+func (r *csvRows) next() (domain.CreateURLRequest, error) {
+	var req domain.CreateURLRequest
+	record, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return req, rowError("invalid csv row")
+		}
+		return req, err
+	}
+
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	req.URL = field("url")
+	req.Alias = field("alias")
+	req.Campaign = field("campaign")
+	if tags := field("tags"); tags != "" {
+		req.Tags = strings.Fields(tags)
+	}
+	if raw := field("expires_at"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return req, rowError("invalid expires_at")
+		}
+		req.ExpiresAt = &t
+	}
+	return req, nil
+}
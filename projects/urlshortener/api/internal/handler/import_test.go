@@ -0,0 +1,147 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/handler/mocks"
+	"urlshortener/internal/validation"
+)
+
+func newImportHandler(t *testing.T, chunkSize int) (*handler.ImportHandler, *mocks.MockURLImporter, *mocks.MockURLValidator) {
+	importer := mocks.NewMockURLImporter(t)
+	val := mocks.NewMockURLValidator(t)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	return handler.NewImportHandler(importer, val, chunkSize, time.Minute, logger), importer, val
+}
+
+func postImport(t *testing.T, h *handler.ImportHandler, contentType, body string) (*httptest.ResponseRecorder, []map[string]any) {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, handler.ImportPath, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.ImportURLs(e.NewContext(req, rec)))
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return rec, lines
+}
+
+func created(code string) domain.ImportResult {
+	return domain.ImportResult{CreateURLResponse: &domain.CreateURLResponse{ShortCode: code, ShortURL: "http://short.url/" + code}}
+}
+
+func allValid(_ context.Context, reqs []domain.CreateURLRequest) ([]error, error) {
+	return make([]error, len(reqs)), nil
+}
+
+func TestImportURLs_CSVInChunks(t *testing.T) {
+	h, importer, val := newImportHandler(t, 2)
+
+	val.EXPECT().ValidateBatchEntries(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, reqs []domain.CreateURLRequest) ([]error, error) {
+			errs := make([]error, len(reqs))
+			for i, req := range reqs {
+				if req.URL == "ftp://bad" {
+					errs[i] = validation.ErrInvalidURLFormat
+				}
+			}
+			return errs, nil
+		})
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	importer.EXPECT().ImportURLs(mock.Anything, []domain.CreateURLRequest{
+		{URL: "https://example.com/a", Alias: "a", Tags: []string{"x", "y"}, ExpiresAt: &expires},
+	}).Return([]domain.ImportResult{created("a")}, nil).Once()
+	importer.EXPECT().ImportURLs(mock.Anything, []domain.CreateURLRequest{
+		{URL: "https://example.com/c", Campaign: "Spring"},
+	}).Return([]domain.ImportResult{{Error: "alias already taken"}}, nil).Once()
+
+	body := "\ufeffURL,alias,tags,expires_at,campaign\n" +
+		"https://example.com/a,a,x y,2030-01-01T00:00:00Z,\n" +
+		"ftp://bad,,,,\n" +
+		"https://example.com/c,,,,Spring\n"
+	rec, lines := postImport(t, h, "text/csv; charset=utf-8", body)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+	require.Len(t, lines, 4)
+	assert.Equal(t, map[string]any{"row": 1.0, "short_code": "a", "short_url": "http://short.url/a", "original_url": ""}, lines[0])
+	assert.Equal(t, map[string]any{"row": 2.0, "error": "invalid url format"}, lines[1])
+	assert.Equal(t, map[string]any{"row": 3.0, "error": "alias already taken"}, lines[2])
+	assert.Equal(t, map[string]any{"rows": 3.0, "succeeded": 1.0, "failed": 2.0}, lines[3])
+}
+
+func TestImportURLs_NDJSON(t *testing.T) {
+	h, importer, val := newImportHandler(t, 1000)
+
+	val.EXPECT().ValidateBatchEntries(mock.Anything, mock.Anything).RunAndReturn(allValid)
+	importer.EXPECT().ImportURLs(mock.Anything, []domain.CreateURLRequest{
+		{URL: "https://example.com/a"},
+		{URL: "https://example.com/b", Alias: "b"},
+	}).Return([]domain.ImportResult{created("x1"), created("b")}, nil)
+
+	body := "\"https://example.com/a\"\n\n{not json}\n{\"url\":\"https://example.com/b\",\"alias\":\"b\"}"
+	_, lines := postImport(t, h, "application/x-ndjson", body)
+
+	require.Len(t, lines, 4)
+	assert.Equal(t, "x1", lines[0]["short_code"])
+	assert.Equal(t, map[string]any{"row": 2.0, "error": "invalid json"}, lines[1])
+	assert.Equal(t, "b", lines[2]["short_code"])
+	assert.Equal(t, 3.0, lines[2]["row"])
+	assert.Equal(t, map[string]any{"rows": 3.0, "succeeded": 2.0, "failed": 1.0}, lines[3])
+}
+
+func TestImportURLs_ImporterFailureStops(t *testing.T) {
+	h, importer, val := newImportHandler(t, 1)
+
+	val.EXPECT().ValidateBatchEntries(mock.Anything, mock.Anything).RunAndReturn(allValid)
+	importer.EXPECT().ImportURLs(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	_, lines := postImport(t, h, "text/csv", "url\nhttps://example.com/a\nhttps://example.com/b\n")
+
+	require.Len(t, lines, 1)
+	assert.Equal(t, map[string]any{"rows": 1.0, "succeeded": 0.0, "failed": 0.0, "error": "failed to import urls"}, lines[0])
+}
+
+func TestImportURLs_RowTooLong(t *testing.T) {
+	h, _, _ := newImportHandler(t, 10)
+
+	_, lines := postImport(t, h, "application/x-ndjson", `"`+strings.Repeat("a", 70<<10)+`"`)
+
+	require.Len(t, lines, 1)
+	assert.Equal(t, "row exceeds 64KB", lines[0]["error"])
+}
+
+func TestImportURLs_Rejected(t *testing.T) {
+	h, _, _ := newImportHandler(t, 10)
+
+	rec, _ := postImport(t, h, "application/json", `["https://example.com"]`)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	for _, header := range []string{"alias\nx\n", "url,color\n", "url,url\n", ""} {
+		rec, _ = postImport(t, h, "text/csv", header)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, header)
+	}
+}
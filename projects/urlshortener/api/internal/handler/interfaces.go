@@ -8,16 +8,55 @@ import (
 )
 
 type URLService interface {
-	CreateShortURL(ctx context.Context, originalURL string) (*domain.CreateURLResponse, error)
-	GetOriginalURL(ctx context.Context, shortCode string) (string, error)
-	CreateShortURLBatch(ctx context.Context, originalURLs []string) ([]domain.CreateURLResponse, error)
+	CreateShortURL(ctx context.Context, req *domain.CreateURLRequest) (*domain.CreateURLResponse, error)
+	GetOriginalURL(ctx context.Context, shortCode string, visit domain.Visit) (*domain.Redirect, error)
+	CreateShortURLBatch(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.CreateURLResponse, error)
+	CreateShortURLBatchPartial(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.CreateURLResponse, []error, error)
+	GetURLInfo(ctx context.Context, shortCode string) (*domain.URLInfo, error)
+	UpdateURL(ctx context.Context, shortCode, originalURL string) (*domain.CreateURLResponse, error)
+	DeleteURL(ctx context.Context, shortCode string) error
+	SetURLActive(ctx context.Context, shortCode string, active bool) error
+	ListURLs(ctx context.Context, filter domain.LinkFilter, cursor string, limit int) (*domain.URLPage, error)
+	SetURLsActive(ctx context.Context, filter domain.LinkFilter, active bool) (int, error)
+	DeleteURLs(ctx context.Context, filter domain.LinkFilter) (int, error)
+	ResolveURLs(ctx context.Context, codes []string) (*domain.ResolveResponse, error)
+	History(ctx context.Context, shortCode, cursor string, limit int) (*domain.HistoryPage, error)
+}
+
+type APIKeyService interface {
+	CreateKey(ctx context.Context, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error)
+	ListKeys(ctx context.Context) ([]domain.APIKey, error)
+	RevokeKey(ctx context.Context, id int64) error
+}
+
+type URLImporter interface {
+	ImportURLs(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.ImportResult, error)
+}
+
+type URLExporter interface {
+	ExportURLs(ctx context.Context, after string, fn func(*domain.ExportedLink) error) error
+}
+
+type QRService interface {
+	QRCode(ctx context.Context, shortCode string, opts domain.QROptions) ([]byte, error)
 }
 
 type URLValidator interface {
-	ValidateURL(url string) error
-	ValidateBatch(urls []string) error
+	ValidateURL(ctx context.Context, url string) error
+	ValidateRequest(ctx context.Context, req *domain.CreateURLRequest) error
+	ValidateBatch(ctx context.Context, reqs []domain.CreateURLRequest) error
+	ValidateBatchEntries(ctx context.Context, reqs []domain.CreateURLRequest) ([]error, error)
+	ValidateCodes(codes []string) error
 }
 
 type BusinessRecorder interface {
 	RecordBusiness(t time.Time, name string, value float64, labelsJSON []byte)
 }
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *domain.CreateWebhookRequest) (*domain.CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, status string, webhookID int64, cursor string, limit int) (*domain.DeliveryPage, error)
+	RetryDelivery(ctx context.Context, id int64) error
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+)
+
+// ListURLs pages through links, optionally narrowed by the tag and campaign
+// query parameters. Pass next_cursor of a page as cursor to get the next one.
+func (h *Handler) ListURLs(c echo.Context) error {
+	limit, ok := pageLimit(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, errInvalidLimit)
+	}
+
+	page, err := h.urlService.ListURLs(c.Request().Context(), linkFilter(c), c.QueryParam("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, errInvalidCursor)
+		}
+		h.logger.Error("failed to list urls", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errListFailed)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *Handler) DeactivateURLs(c echo.Context) error {
+	return h.setActiveByFilter(c, false)
+}
+
+func (h *Handler) ReactivateURLs(c echo.Context) error {
+	return h.setActiveByFilter(c, true)
+}
+
+func (h *Handler) setActiveByFilter(c echo.Context, active bool) error {
+	n, err := h.urlService.SetURLsActive(c.Request().Context(), linkFilter(c), active)
+	if err != nil {
+		return h.handleBulkError(c, err, "failed to update urls", errUpdateFailed)
+	}
+	return c.JSON(http.StatusOK, domain.BulkResult{Affected: n})
+}
+
+func (h *Handler) DeleteURLs(c echo.Context) error {
+	n, err := h.urlService.DeleteURLs(c.Request().Context(), linkFilter(c))
+	if err != nil {
+		return h.handleBulkError(c, err, "failed to delete urls", errDeleteFailed)
+	}
+	return c.JSON(http.StatusOK, domain.BulkResult{Affected: n})
+}
+
+func (h *Handler) handleBulkError(c echo.Context, err error, msg string, failure map[string]string) error {
+	if errors.Is(err, service.ErrFilterRequired) {
+		return c.JSON(http.StatusBadRequest, errFilterRequired)
+	}
+	h.logger.Error(msg, slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, failure)
+}
+
+// pageLimit returns the limit query parameter, or zero for the default page
+// size. ok is false if it is not a positive integer.
+func pageLimit(c echo.Context) (limit int, ok bool) {
+	raw := c.QueryParam("limit")
+	if raw == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+func linkFilter(c echo.Context) domain.LinkFilter {
+	return domain.LinkFilter{
+		Tag:      c.QueryParam("tag"),
+		Campaign: c.QueryParam("campaign"),
+	}
+}
@@ -0,0 +1,193 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/service"
+)
+
+func TestListURLs_Success(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().ListURLs(mock.Anything, domain.LinkFilter{Tag: "black-friday"}, "YQ", 2).Return(&domain.URLPage{
+		URLs:       []domain.URLInfo{{ShortCode: "b", ShortURL: "http://short.url/b"}},
+		NextCursor: "Yg",
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/urls?tag=black-friday&cursor=YQ&limit=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.ListURLs(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"next_cursor":"Yg"`)
+	assert.Contains(t, rec.Body.String(), `"short_code":"b"`)
+}
+
+func TestListURLs_InvalidLimit(t *testing.T) {
+	h, _, _, _ := newTestHandler(t)
+
+	for _, limit := range []string{"0", "-1", "ten"} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/urls?limit="+limit, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, h.ListURLs(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, limit)
+	}
+}
+
+func TestListURLs_InvalidCursor(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().ListURLs(mock.Anything, domain.LinkFilter{}, "!", 0).Return(nil, service.ErrInvalidCursor)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/urls?cursor=!", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.ListURLs(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid cursor")
+}
+
+func TestDeactivateURLs_Campaign(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().SetURLsActive(mock.Anything, domain.LinkFilter{Campaign: "Black Friday"}, false).Return(12, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/deactivate?campaign=Black+Friday", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.DeactivateURLs(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"affected":12}`, rec.Body.String())
+}
+
+func TestReactivateURLs_FilterRequired(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().SetURLsActive(mock.Anything, domain.LinkFilter{}, true).Return(0, service.ErrFilterRequired)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/reactivate", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.ReactivateURLs(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDeleteURLs_ServiceError(t *testing.T) {
+	h, svc, _, _ := newTestHandler(t)
+
+	svc.EXPECT().DeleteURLs(mock.Anything, domain.LinkFilter{Tag: "old"}).Return(0, errors.New("db down"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/urls?tag=old", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.DeleteURLs(c))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+// The bulk routes share a prefix with the per-link routes; both must resolve.
+func TestRegister_BulkRoutes(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	svc.EXPECT().SetURLsActive(mock.Anything, domain.LinkFilter{Tag: "spring"}, false).Return(1, nil)
+	svc.EXPECT().SetURLActive(mock.Anything, "spring", false).Return(nil)
+
+	e := echo.New()
+	pass := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	h.Register(e, handler.RouteMiddleware{
+		Idempotent:   pass,
+		RequireScope: func(string) echo.MiddlewareFunc { return pass },
+		RequireKey:   func(string) echo.MiddlewareFunc { return pass },
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/urls/deactivate?tag=spring", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/urls/spring/deactivate", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	val.EXPECT().ValidateCodes([]string{"spring"}).Return(nil)
+	svc.EXPECT().ResolveURLs(mock.Anything, []string{"spring"}).Return(&domain.ResolveResponse{}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/resolve", strings.NewReader(`{"codes":["spring"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRegister_RequireKey(t *testing.T) {
+	h, _, _, _ := newTestHandler(t)
+
+	e := echo.New()
+	pass := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	var scopes []string
+	h.Register(e, handler.RouteMiddleware{
+		Idempotent:   pass,
+		RequireScope: func(string) echo.MiddlewareFunc { return pass },
+		RequireKey: func(scope string) echo.MiddlewareFunc {
+			scopes = append(scopes, scope)
+			return func(echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error { return c.NoContent(http.StatusUnauthorized) }
+			}
+		},
+	})
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodDelete, "/api/v1/urls?tag=spring"},
+		{http.MethodPost, "/api/v1/urls/deactivate?tag=spring"},
+		{http.MethodPost, "/api/v1/urls/reactivate?tag=spring"},
+		{http.MethodPatch, "/api/v1/urls/abc123"},
+		{http.MethodDelete, "/api/v1/urls/abc123"},
+		{http.MethodPost, "/api/v1/urls/abc123/deactivate"},
+		{http.MethodPost, "/api/v1/urls/abc123/reactivate"},
+		{http.MethodGet, "/api/v1/urls/abc123/history"},
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(route.method, route.path, nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "%s %s", route.method, route.path)
+	}
+	assert.Contains(t, scopes, domain.ScopeLinksUpdate)
+	assert.Contains(t, scopes, domain.ScopeLinksDelete)
+	assert.Contains(t, scopes, domain.ScopeStatsRead)
+}
+
+func TestRootSegments(t *testing.T) {
+	h, _, _, _ := newTestHandler(t)
+
+	e := echo.New()
+	pass := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	h.Register(e, handler.RouteMiddleware{
+		Idempotent:   pass,
+		RequireScope: func(string) echo.MiddlewareFunc { return pass },
+		RequireKey:   func(string) echo.MiddlewareFunc { return pass },
+	})
+	e.GET("/debug/pprof/*", func(echo.Context) error { return nil })
+	e.GET("/metrics", func(echo.Context) error { return nil })
+
+	assert.ElementsMatch(t, []string{"api", "debug", "metrics"}, handler.RootSegments(e.Routes()))
+}
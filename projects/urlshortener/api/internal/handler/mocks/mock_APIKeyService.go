@@ -0,0 +1,201 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyService is an autogenerated mock type for the APIKeyService type
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+type MockAPIKeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyService) EXPECT() *MockAPIKeyService_Expecter {
+	return &MockAPIKeyService_Expecter{mock: &_m.Mock}
+}
+
+// CreateKey provides a mock function with given fields: ctx, req
+func (_m *MockAPIKeyService) CreateKey(ctx context.Context, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateKey")
+	}
+
+	var r0 *domain.CreateAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateAPIKeyRequest) *domain.CreateAPIKeyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CreateAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateAPIKeyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyService_CreateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateKey'
+type MockAPIKeyService_CreateKey_Call struct {
+	*mock.Call
+}
+
+// CreateKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.CreateAPIKeyRequest
+func (_e *MockAPIKeyService_Expecter) CreateKey(ctx interface{}, req interface{}) *MockAPIKeyService_CreateKey_Call {
+	return &MockAPIKeyService_CreateKey_Call{Call: _e.mock.On("CreateKey", ctx, req)}
+}
+
+func (_c *MockAPIKeyService_CreateKey_Call) Run(run func(ctx context.Context, req *domain.CreateAPIKeyRequest)) *MockAPIKeyService_CreateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.CreateAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_CreateKey_Call) Return(_a0 *domain.CreateAPIKeyResponse, _a1 error) *MockAPIKeyService_CreateKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyService_CreateKey_Call) RunAndReturn(run func(context.Context, *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error)) *MockAPIKeyService_CreateKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListKeys provides a mock function with given fields: ctx
+func (_m *MockAPIKeyService) ListKeys(ctx context.Context) ([]domain.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListKeys")
+	}
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.APIKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyService_ListKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListKeys'
+type MockAPIKeyService_ListKeys_Call struct {
+	*mock.Call
+}
+
+// ListKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAPIKeyService_Expecter) ListKeys(ctx interface{}) *MockAPIKeyService_ListKeys_Call {
+	return &MockAPIKeyService_ListKeys_Call{Call: _e.mock.On("ListKeys", ctx)}
+}
+
+func (_c *MockAPIKeyService_ListKeys_Call) Run(run func(ctx context.Context)) *MockAPIKeyService_ListKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_ListKeys_Call) Return(_a0 []domain.APIKey, _a1 error) *MockAPIKeyService_ListKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyService_ListKeys_Call) RunAndReturn(run func(context.Context) ([]domain.APIKey, error)) *MockAPIKeyService_ListKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeKey provides a mock function with given fields: ctx, id
+func (_m *MockAPIKeyService) RevokeKey(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyService_RevokeKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeKey'
+type MockAPIKeyService_RevokeKey_Call struct {
+	*mock.Call
+}
+
+// RevokeKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockAPIKeyService_Expecter) RevokeKey(ctx interface{}, id interface{}) *MockAPIKeyService_RevokeKey_Call {
+	return &MockAPIKeyService_RevokeKey_Call{Call: _e.mock.On("RevokeKey", ctx, id)}
+}
+
+func (_c *MockAPIKeyService_RevokeKey_Call) Run(run func(ctx context.Context, id int64)) *MockAPIKeyService_RevokeKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyService_RevokeKey_Call) Return(_a0 error) *MockAPIKeyService_RevokeKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyService_RevokeKey_Call) RunAndReturn(run func(context.Context, int64) error) *MockAPIKeyService_RevokeKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyService creates a new instance of MockAPIKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyService {
+	mock := &MockAPIKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockQRService is an autogenerated mock type for the QRService type
+type MockQRService struct {
+	mock.Mock
+}
+
+type MockQRService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQRService) EXPECT() *MockQRService_Expecter {
+	return &MockQRService_Expecter{mock: &_m.Mock}
+}
+
+// QRCode provides a mock function with given fields: ctx, shortCode, opts
+func (_m *MockQRService) QRCode(ctx context.Context, shortCode string, opts domain.QROptions) ([]byte, error) {
+	ret := _m.Called(ctx, shortCode, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QRCode")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.QROptions) ([]byte, error)); ok {
+		return rf(ctx, shortCode, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.QROptions) []byte); ok {
+		r0 = rf(ctx, shortCode, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.QROptions) error); ok {
+		r1 = rf(ctx, shortCode, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQRService_QRCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QRCode'
+type MockQRService_QRCode_Call struct {
+	*mock.Call
+}
+
+// QRCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - opts domain.QROptions
+func (_e *MockQRService_Expecter) QRCode(ctx interface{}, shortCode interface{}, opts interface{}) *MockQRService_QRCode_Call {
+	return &MockQRService_QRCode_Call{Call: _e.mock.On("QRCode", ctx, shortCode, opts)}
+}
+
+func (_c *MockQRService_QRCode_Call) Run(run func(ctx context.Context, shortCode string, opts domain.QROptions)) *MockQRService_QRCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.QROptions))
+	})
+	return _c
+}
+
+func (_c *MockQRService_QRCode_Call) Return(_a0 []byte, _a1 error) *MockQRService_QRCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQRService_QRCode_Call) RunAndReturn(run func(context.Context, string, domain.QROptions) ([]byte, error)) *MockQRService_QRCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQRService creates a new instance of MockQRService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQRService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQRService {
+	mock := &MockQRService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockURLExporter is an autogenerated mock type for the URLExporter type
+type MockURLExporter struct {
+	mock.Mock
+}
+
+type MockURLExporter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockURLExporter) EXPECT() *MockURLExporter_Expecter {
+	return &MockURLExporter_Expecter{mock: &_m.Mock}
+}
+
+// ExportURLs provides a mock function with given fields: ctx, after, fn
+func (_m *MockURLExporter) ExportURLs(ctx context.Context, after string, fn func(*domain.ExportedLink) error) error {
+	ret := _m.Called(ctx, after, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportURLs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*domain.ExportedLink) error) error); ok {
+		r0 = rf(ctx, after, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLExporter_ExportURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportURLs'
+type MockURLExporter_ExportURLs_Call struct {
+	*mock.Call
+}
+
+// ExportURLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after string
+//   - fn func(*domain.ExportedLink) error
+func (_e *MockURLExporter_Expecter) ExportURLs(ctx interface{}, after interface{}, fn interface{}) *MockURLExporter_ExportURLs_Call {
+	return &MockURLExporter_ExportURLs_Call{Call: _e.mock.On("ExportURLs", ctx, after, fn)}
+}
+
+func (_c *MockURLExporter_ExportURLs_Call) Run(run func(ctx context.Context, after string, fn func(*domain.ExportedLink) error)) *MockURLExporter_ExportURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(*domain.ExportedLink) error))
+	})
+	return _c
+}
+
+func (_c *MockURLExporter_ExportURLs_Call) Return(_a0 error) *MockURLExporter_ExportURLs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLExporter_ExportURLs_Call) RunAndReturn(run func(context.Context, string, func(*domain.ExportedLink) error) error) *MockURLExporter_ExportURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockURLExporter creates a new instance of MockURLExporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockURLExporter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockURLExporter {
+	mock := &MockURLExporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockURLImporter is an autogenerated mock type for the URLImporter type
+type MockURLImporter struct {
+	mock.Mock
+}
+
+type MockURLImporter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockURLImporter) EXPECT() *MockURLImporter_Expecter {
+	return &MockURLImporter_Expecter{mock: &_m.Mock}
+}
+
+// ImportURLs provides a mock function with given fields: ctx, reqs
+func (_m *MockURLImporter) ImportURLs(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.ImportResult, error) {
+	ret := _m.Called(ctx, reqs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportURLs")
+	}
+
+	var r0 []domain.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) ([]domain.ImportResult, error)); ok {
+		return rf(ctx, reqs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) []domain.ImportResult); ok {
+		r0 = rf(ctx, reqs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.CreateURLRequest) error); ok {
+		r1 = rf(ctx, reqs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLImporter_ImportURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportURLs'
+type MockURLImporter_ImportURLs_Call struct {
+	*mock.Call
+}
+
+// ImportURLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqs []domain.CreateURLRequest
+func (_e *MockURLImporter_Expecter) ImportURLs(ctx interface{}, reqs interface{}) *MockURLImporter_ImportURLs_Call {
+	return &MockURLImporter_ImportURLs_Call{Call: _e.mock.On("ImportURLs", ctx, reqs)}
+}
+
+func (_c *MockURLImporter_ImportURLs_Call) Run(run func(ctx context.Context, reqs []domain.CreateURLRequest)) *MockURLImporter_ImportURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.CreateURLRequest))
+	})
+	return _c
+}
+
+func (_c *MockURLImporter_ImportURLs_Call) Return(_a0 []domain.ImportResult, _a1 error) *MockURLImporter_ImportURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLImporter_ImportURLs_Call) RunAndReturn(run func(context.Context, []domain.CreateURLRequest) ([]domain.ImportResult, error)) *MockURLImporter_ImportURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockURLImporter creates a new instance of MockURLImporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockURLImporter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockURLImporter {
+	mock := &MockURLImporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
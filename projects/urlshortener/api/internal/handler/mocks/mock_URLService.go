@@ -22,9 +22,9 @@ func (_m *MockURLService) EXPECT() *MockURLService_Expecter {
 	return &MockURLService_Expecter{mock: &_m.Mock}
 }
 
-// CreateShortURL provides a mock function with given fields: ctx, originalURL
-func (_m *MockURLService) CreateShortURL(ctx context.Context, originalURL string) (*domain.CreateURLResponse, error) {
-	ret := _m.Called(ctx, originalURL)
+// CreateShortURL provides a mock function with given fields: ctx, req
+func (_m *MockURLService) CreateShortURL(ctx context.Context, req *domain.CreateURLRequest) (*domain.CreateURLResponse, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortURL")
@@ -32,19 +32,19 @@ func (_m *MockURLService) CreateShortURL(ctx context.Context, originalURL string
 
 	var r0 *domain.CreateURLResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.CreateURLResponse, error)); ok {
-		return rf(ctx, originalURL)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateURLRequest) (*domain.CreateURLResponse, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.CreateURLResponse); ok {
-		r0 = rf(ctx, originalURL)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateURLRequest) *domain.CreateURLResponse); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.CreateURLResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, originalURL)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateURLRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,14 +59,14 @@ type MockURLService_CreateShortURL_Call struct {
 
 // CreateShortURL is a helper method to define mock.On call
 //   - ctx context.Context
-//   - originalURL string
-func (_e *MockURLService_Expecter) CreateShortURL(ctx interface{}, originalURL interface{}) *MockURLService_CreateShortURL_Call {
-	return &MockURLService_CreateShortURL_Call{Call: _e.mock.On("CreateShortURL", ctx, originalURL)}
+//   - req *domain.CreateURLRequest
+func (_e *MockURLService_Expecter) CreateShortURL(ctx interface{}, req interface{}) *MockURLService_CreateShortURL_Call {
+	return &MockURLService_CreateShortURL_Call{Call: _e.mock.On("CreateShortURL", ctx, req)}
 }
 
-func (_c *MockURLService_CreateShortURL_Call) Run(run func(ctx context.Context, originalURL string)) *MockURLService_CreateShortURL_Call {
+func (_c *MockURLService_CreateShortURL_Call) Run(run func(ctx context.Context, req *domain.CreateURLRequest)) *MockURLService_CreateShortURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(*domain.CreateURLRequest))
 	})
 	return _c
 }
@@ -76,14 +76,14 @@ func (_c *MockURLService_CreateShortURL_Call) Return(_a0 *domain.CreateURLRespon
 	return _c
 }
 
-func (_c *MockURLService_CreateShortURL_Call) RunAndReturn(run func(context.Context, string) (*domain.CreateURLResponse, error)) *MockURLService_CreateShortURL_Call {
+func (_c *MockURLService_CreateShortURL_Call) RunAndReturn(run func(context.Context, *domain.CreateURLRequest) (*domain.CreateURLResponse, error)) *MockURLService_CreateShortURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateShortURLBatch provides a mock function with given fields: ctx, originalURLs
-func (_m *MockURLService) CreateShortURLBatch(ctx context.Context, originalURLs []string) ([]domain.CreateURLResponse, error) {
-	ret := _m.Called(ctx, originalURLs)
+// CreateShortURLBatch provides a mock function with given fields: ctx, reqs
+func (_m *MockURLService) CreateShortURLBatch(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.CreateURLResponse, error) {
+	ret := _m.Called(ctx, reqs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortURLBatch")
@@ -91,19 +91,19 @@ func (_m *MockURLService) CreateShortURLBatch(ctx context.Context, originalURLs
 
 	var r0 []domain.CreateURLResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.CreateURLResponse, error)); ok {
-		return rf(ctx, originalURLs)
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) ([]domain.CreateURLResponse, error)); ok {
+		return rf(ctx, reqs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.CreateURLResponse); ok {
-		r0 = rf(ctx, originalURLs)
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) []domain.CreateURLResponse); ok {
+		r0 = rf(ctx, reqs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.CreateURLResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, originalURLs)
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.CreateURLRequest) error); ok {
+		r1 = rf(ctx, reqs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,14 +118,14 @@ type MockURLService_CreateShortURLBatch_Call struct {
 
 // CreateShortURLBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - originalURLs []string
-func (_e *MockURLService_Expecter) CreateShortURLBatch(ctx interface{}, originalURLs interface{}) *MockURLService_CreateShortURLBatch_Call {
-	return &MockURLService_CreateShortURLBatch_Call{Call: _e.mock.On("CreateShortURLBatch", ctx, originalURLs)}
+//   - reqs []domain.CreateURLRequest
+func (_e *MockURLService_Expecter) CreateShortURLBatch(ctx interface{}, reqs interface{}) *MockURLService_CreateShortURLBatch_Call {
+	return &MockURLService_CreateShortURLBatch_Call{Call: _e.mock.On("CreateShortURLBatch", ctx, reqs)}
 }
 
-func (_c *MockURLService_CreateShortURLBatch_Call) Run(run func(ctx context.Context, originalURLs []string)) *MockURLService_CreateShortURLBatch_Call {
+func (_c *MockURLService_CreateShortURLBatch_Call) Run(run func(ctx context.Context, reqs []domain.CreateURLRequest)) *MockURLService_CreateShortURLBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].([]domain.CreateURLRequest))
 	})
 	return _c
 }
@@ -135,28 +135,262 @@ func (_c *MockURLService_CreateShortURLBatch_Call) Return(_a0 []domain.CreateURL
 	return _c
 }
 
-func (_c *MockURLService_CreateShortURLBatch_Call) RunAndReturn(run func(context.Context, []string) ([]domain.CreateURLResponse, error)) *MockURLService_CreateShortURLBatch_Call {
+func (_c *MockURLService_CreateShortURLBatch_Call) RunAndReturn(run func(context.Context, []domain.CreateURLRequest) ([]domain.CreateURLResponse, error)) *MockURLService_CreateShortURLBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOriginalURL provides a mock function with given fields: ctx, shortCode
-func (_m *MockURLService) GetOriginalURL(ctx context.Context, shortCode string) (string, error) {
+// CreateShortURLBatchPartial provides a mock function with given fields: ctx, reqs
+func (_m *MockURLService) CreateShortURLBatchPartial(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.CreateURLResponse, []error, error) {
+	ret := _m.Called(ctx, reqs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShortURLBatchPartial")
+	}
+
+	var r0 []domain.CreateURLResponse
+	var r1 []error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) ([]domain.CreateURLResponse, []error, error)); ok {
+		return rf(ctx, reqs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) []domain.CreateURLResponse); ok {
+		r0 = rf(ctx, reqs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CreateURLResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.CreateURLRequest) []error); ok {
+		r1 = rf(ctx, reqs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []domain.CreateURLRequest) error); ok {
+		r2 = rf(ctx, reqs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockURLService_CreateShortURLBatchPartial_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShortURLBatchPartial'
+type MockURLService_CreateShortURLBatchPartial_Call struct {
+	*mock.Call
+}
+
+// CreateShortURLBatchPartial is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqs []domain.CreateURLRequest
+func (_e *MockURLService_Expecter) CreateShortURLBatchPartial(ctx interface{}, reqs interface{}) *MockURLService_CreateShortURLBatchPartial_Call {
+	return &MockURLService_CreateShortURLBatchPartial_Call{Call: _e.mock.On("CreateShortURLBatchPartial", ctx, reqs)}
+}
+
+func (_c *MockURLService_CreateShortURLBatchPartial_Call) Run(run func(ctx context.Context, reqs []domain.CreateURLRequest)) *MockURLService_CreateShortURLBatchPartial_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.CreateURLRequest))
+	})
+	return _c
+}
+
+func (_c *MockURLService_CreateShortURLBatchPartial_Call) Return(_a0 []domain.CreateURLResponse, _a1 []error, _a2 error) *MockURLService_CreateShortURLBatchPartial_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockURLService_CreateShortURLBatchPartial_Call) RunAndReturn(run func(context.Context, []domain.CreateURLRequest) ([]domain.CreateURLResponse, []error, error)) *MockURLService_CreateShortURLBatchPartial_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteURL provides a mock function with given fields: ctx, shortCode
+func (_m *MockURLService) DeleteURL(ctx context.Context, shortCode string) error {
 	ret := _m.Called(ctx, shortCode)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLService_DeleteURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURL'
+type MockURLService_DeleteURL_Call struct {
+	*mock.Call
+}
+
+// DeleteURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+func (_e *MockURLService_Expecter) DeleteURL(ctx interface{}, shortCode interface{}) *MockURLService_DeleteURL_Call {
+	return &MockURLService_DeleteURL_Call{Call: _e.mock.On("DeleteURL", ctx, shortCode)}
+}
+
+func (_c *MockURLService_DeleteURL_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLService_DeleteURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLService_DeleteURL_Call) Return(_a0 error) *MockURLService_DeleteURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLService_DeleteURL_Call) RunAndReturn(run func(context.Context, string) error) *MockURLService_DeleteURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteURLs provides a mock function with given fields: ctx, filter
+func (_m *MockURLService) DeleteURLs(ctx context.Context, filter domain.LinkFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURLs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LinkFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_DeleteURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLs'
+type MockURLService_DeleteURLs_Call struct {
+	*mock.Call
+}
+
+// DeleteURLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LinkFilter
+func (_e *MockURLService_Expecter) DeleteURLs(ctx interface{}, filter interface{}) *MockURLService_DeleteURLs_Call {
+	return &MockURLService_DeleteURLs_Call{Call: _e.mock.On("DeleteURLs", ctx, filter)}
+}
+
+func (_c *MockURLService_DeleteURLs_Call) Run(run func(ctx context.Context, filter domain.LinkFilter)) *MockURLService_DeleteURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LinkFilter))
+	})
+	return _c
+}
+
+func (_c *MockURLService_DeleteURLs_Call) Return(_a0 int, _a1 error) *MockURLService_DeleteURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_DeleteURLs_Call) RunAndReturn(run func(context.Context, domain.LinkFilter) (int, error)) *MockURLService_DeleteURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOriginalURL provides a mock function with given fields: ctx, shortCode, visit
+func (_m *MockURLService) GetOriginalURL(ctx context.Context, shortCode string, visit domain.Visit) (*domain.Redirect, error) {
+	ret := _m.Called(ctx, shortCode, visit)
+
 	if len(ret) == 0 {
 		panic("no return value specified for GetOriginalURL")
 	}
 
-	var r0 string
+	var r0 *domain.Redirect
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Visit) (*domain.Redirect, error)); ok {
+		return rf(ctx, shortCode, visit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Visit) *domain.Redirect); ok {
+		r0 = rf(ctx, shortCode, visit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Redirect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Visit) error); ok {
+		r1 = rf(ctx, shortCode, visit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_GetOriginalURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOriginalURL'
+type MockURLService_GetOriginalURL_Call struct {
+	*mock.Call
+}
+
+// GetOriginalURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - visit domain.Visit
+func (_e *MockURLService_Expecter) GetOriginalURL(ctx interface{}, shortCode interface{}, visit interface{}) *MockURLService_GetOriginalURL_Call {
+	return &MockURLService_GetOriginalURL_Call{Call: _e.mock.On("GetOriginalURL", ctx, shortCode, visit)}
+}
+
+func (_c *MockURLService_GetOriginalURL_Call) Run(run func(ctx context.Context, shortCode string, visit domain.Visit)) *MockURLService_GetOriginalURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Visit))
+	})
+	return _c
+}
+
+func (_c *MockURLService_GetOriginalURL_Call) Return(_a0 *domain.Redirect, _a1 error) *MockURLService_GetOriginalURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_GetOriginalURL_Call) RunAndReturn(run func(context.Context, string, domain.Visit) (*domain.Redirect, error)) *MockURLService_GetOriginalURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLInfo provides a mock function with given fields: ctx, shortCode
+func (_m *MockURLService) GetURLInfo(ctx context.Context, shortCode string) (*domain.URLInfo, error) {
+	ret := _m.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLInfo")
+	}
+
+	var r0 *domain.URLInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.URLInfo, error)); ok {
 		return rf(ctx, shortCode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.URLInfo); ok {
 		r0 = rf(ctx, shortCode)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.URLInfo)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -168,31 +402,378 @@ func (_m *MockURLService) GetOriginalURL(ctx context.Context, shortCode string)
 	return r0, r1
 }
 
-// MockURLService_GetOriginalURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOriginalURL'
-type MockURLService_GetOriginalURL_Call struct {
+// MockURLService_GetURLInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLInfo'
+type MockURLService_GetURLInfo_Call struct {
 	*mock.Call
 }
 
-// GetOriginalURL is a helper method to define mock.On call
+// GetURLInfo is a helper method to define mock.On call
 //   - ctx context.Context
 //   - shortCode string
-func (_e *MockURLService_Expecter) GetOriginalURL(ctx interface{}, shortCode interface{}) *MockURLService_GetOriginalURL_Call {
-	return &MockURLService_GetOriginalURL_Call{Call: _e.mock.On("GetOriginalURL", ctx, shortCode)}
+func (_e *MockURLService_Expecter) GetURLInfo(ctx interface{}, shortCode interface{}) *MockURLService_GetURLInfo_Call {
+	return &MockURLService_GetURLInfo_Call{Call: _e.mock.On("GetURLInfo", ctx, shortCode)}
 }
 
-func (_c *MockURLService_GetOriginalURL_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLService_GetOriginalURL_Call {
+func (_c *MockURLService_GetURLInfo_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLService_GetURLInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockURLService_GetOriginalURL_Call) Return(_a0 string, _a1 error) *MockURLService_GetOriginalURL_Call {
+func (_c *MockURLService_GetURLInfo_Call) Return(_a0 *domain.URLInfo, _a1 error) *MockURLService_GetURLInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_GetURLInfo_Call) RunAndReturn(run func(context.Context, string) (*domain.URLInfo, error)) *MockURLService_GetURLInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function with given fields: ctx, shortCode, cursor, limit
+func (_m *MockURLService) History(ctx context.Context, shortCode string, cursor string, limit int) (*domain.HistoryPage, error) {
+	ret := _m.Called(ctx, shortCode, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 *domain.HistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*domain.HistoryPage, error)); ok {
+		return rf(ctx, shortCode, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *domain.HistoryPage); ok {
+		r0 = rf(ctx, shortCode, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.HistoryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, shortCode, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockURLService_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - cursor string
+//   - limit int
+func (_e *MockURLService_Expecter) History(ctx interface{}, shortCode interface{}, cursor interface{}, limit interface{}) *MockURLService_History_Call {
+	return &MockURLService_History_Call{Call: _e.mock.On("History", ctx, shortCode, cursor, limit)}
+}
+
+func (_c *MockURLService_History_Call) Run(run func(ctx context.Context, shortCode string, cursor string, limit int)) *MockURLService_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockURLService_History_Call) Return(_a0 *domain.HistoryPage, _a1 error) *MockURLService_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_History_Call) RunAndReturn(run func(context.Context, string, string, int) (*domain.HistoryPage, error)) *MockURLService_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListURLs provides a mock function with given fields: ctx, filter, cursor, limit
+func (_m *MockURLService) ListURLs(ctx context.Context, filter domain.LinkFilter, cursor string, limit int) (*domain.URLPage, error) {
+	ret := _m.Called(ctx, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLs")
+	}
+
+	var r0 *domain.URLPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, string, int) (*domain.URLPage, error)); ok {
+		return rf(ctx, filter, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, string, int) *domain.URLPage); ok {
+		r0 = rf(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.URLPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LinkFilter, string, int) error); ok {
+		r1 = rf(ctx, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_ListURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListURLs'
+type MockURLService_ListURLs_Call struct {
+	*mock.Call
+}
+
+// ListURLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LinkFilter
+//   - cursor string
+//   - limit int
+func (_e *MockURLService_Expecter) ListURLs(ctx interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockURLService_ListURLs_Call {
+	return &MockURLService_ListURLs_Call{Call: _e.mock.On("ListURLs", ctx, filter, cursor, limit)}
+}
+
+func (_c *MockURLService_ListURLs_Call) Run(run func(ctx context.Context, filter domain.LinkFilter, cursor string, limit int)) *MockURLService_ListURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LinkFilter), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockURLService_ListURLs_Call) Return(_a0 *domain.URLPage, _a1 error) *MockURLService_ListURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_ListURLs_Call) RunAndReturn(run func(context.Context, domain.LinkFilter, string, int) (*domain.URLPage, error)) *MockURLService_ListURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveURLs provides a mock function with given fields: ctx, codes
+func (_m *MockURLService) ResolveURLs(ctx context.Context, codes []string) (*domain.ResolveResponse, error) {
+	ret := _m.Called(ctx, codes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveURLs")
+	}
+
+	var r0 *domain.ResolveResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*domain.ResolveResponse, error)); ok {
+		return rf(ctx, codes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *domain.ResolveResponse); ok {
+		r0 = rf(ctx, codes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResolveResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, codes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_ResolveURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveURLs'
+type MockURLService_ResolveURLs_Call struct {
+	*mock.Call
+}
+
+// ResolveURLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - codes []string
+func (_e *MockURLService_Expecter) ResolveURLs(ctx interface{}, codes interface{}) *MockURLService_ResolveURLs_Call {
+	return &MockURLService_ResolveURLs_Call{Call: _e.mock.On("ResolveURLs", ctx, codes)}
+}
+
+func (_c *MockURLService_ResolveURLs_Call) Run(run func(ctx context.Context, codes []string)) *MockURLService_ResolveURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockURLService_ResolveURLs_Call) Return(_a0 *domain.ResolveResponse, _a1 error) *MockURLService_ResolveURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_ResolveURLs_Call) RunAndReturn(run func(context.Context, []string) (*domain.ResolveResponse, error)) *MockURLService_ResolveURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLActive provides a mock function with given fields: ctx, shortCode, active
+func (_m *MockURLService) SetURLActive(ctx context.Context, shortCode string, active bool) error {
+	ret := _m.Called(ctx, shortCode, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, shortCode, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLService_SetURLActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLActive'
+type MockURLService_SetURLActive_Call struct {
+	*mock.Call
+}
+
+// SetURLActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - active bool
+func (_e *MockURLService_Expecter) SetURLActive(ctx interface{}, shortCode interface{}, active interface{}) *MockURLService_SetURLActive_Call {
+	return &MockURLService_SetURLActive_Call{Call: _e.mock.On("SetURLActive", ctx, shortCode, active)}
+}
+
+func (_c *MockURLService_SetURLActive_Call) Run(run func(ctx context.Context, shortCode string, active bool)) *MockURLService_SetURLActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLService_SetURLActive_Call) Return(_a0 error) *MockURLService_SetURLActive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLService_SetURLActive_Call) RunAndReturn(run func(context.Context, string, bool) error) *MockURLService_SetURLActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLsActive provides a mock function with given fields: ctx, filter, active
+func (_m *MockURLService) SetURLsActive(ctx context.Context, filter domain.LinkFilter, active bool) (int, error) {
+	ret := _m.Called(ctx, filter, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLsActive")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, bool) (int, error)); ok {
+		return rf(ctx, filter, active)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, bool) int); ok {
+		r0 = rf(ctx, filter, active)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LinkFilter, bool) error); ok {
+		r1 = rf(ctx, filter, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_SetURLsActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLsActive'
+type MockURLService_SetURLsActive_Call struct {
+	*mock.Call
+}
+
+// SetURLsActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LinkFilter
+//   - active bool
+func (_e *MockURLService_Expecter) SetURLsActive(ctx interface{}, filter interface{}, active interface{}) *MockURLService_SetURLsActive_Call {
+	return &MockURLService_SetURLsActive_Call{Call: _e.mock.On("SetURLsActive", ctx, filter, active)}
+}
+
+func (_c *MockURLService_SetURLsActive_Call) Run(run func(ctx context.Context, filter domain.LinkFilter, active bool)) *MockURLService_SetURLsActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LinkFilter), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLService_SetURLsActive_Call) Return(_a0 int, _a1 error) *MockURLService_SetURLsActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLService_SetURLsActive_Call) RunAndReturn(run func(context.Context, domain.LinkFilter, bool) (int, error)) *MockURLService_SetURLsActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURL provides a mock function with given fields: ctx, shortCode, originalURL
+func (_m *MockURLService) UpdateURL(ctx context.Context, shortCode string, originalURL string) (*domain.CreateURLResponse, error) {
+	ret := _m.Called(ctx, shortCode, originalURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURL")
+	}
+
+	var r0 *domain.CreateURLResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.CreateURLResponse, error)); ok {
+		return rf(ctx, shortCode, originalURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.CreateURLResponse); ok {
+		r0 = rf(ctx, shortCode, originalURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CreateURLResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, shortCode, originalURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLService_UpdateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURL'
+type MockURLService_UpdateURL_Call struct {
+	*mock.Call
+}
+
+// UpdateURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - originalURL string
+func (_e *MockURLService_Expecter) UpdateURL(ctx interface{}, shortCode interface{}, originalURL interface{}) *MockURLService_UpdateURL_Call {
+	return &MockURLService_UpdateURL_Call{Call: _e.mock.On("UpdateURL", ctx, shortCode, originalURL)}
+}
+
+func (_c *MockURLService_UpdateURL_Call) Run(run func(ctx context.Context, shortCode string, originalURL string)) *MockURLService_UpdateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLService_UpdateURL_Call) Return(_a0 *domain.CreateURLResponse, _a1 error) *MockURLService_UpdateURL_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLService_GetOriginalURL_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockURLService_GetOriginalURL_Call {
+func (_c *MockURLService_UpdateURL_Call) RunAndReturn(run func(context.Context, string, string) (*domain.CreateURLResponse, error)) *MockURLService_UpdateURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,7 +2,12 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockURLValidator is an autogenerated mock type for the URLValidator type
 type MockURLValidator struct {
@@ -17,17 +22,17 @@ func (_m *MockURLValidator) EXPECT() *MockURLValidator_Expecter {
 	return &MockURLValidator_Expecter{mock: &_m.Mock}
 }
 
-// ValidateBatch provides a mock function with given fields: urls
-func (_m *MockURLValidator) ValidateBatch(urls []string) error {
-	ret := _m.Called(urls)
+// ValidateBatch provides a mock function with given fields: ctx, reqs
+func (_m *MockURLValidator) ValidateBatch(ctx context.Context, reqs []domain.CreateURLRequest) error {
+	ret := _m.Called(ctx, reqs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateBatch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(urls)
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) error); ok {
+		r0 = rf(ctx, reqs)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,14 +46,15 @@ type MockURLValidator_ValidateBatch_Call struct {
 }
 
 // ValidateBatch is a helper method to define mock.On call
-//   - urls []string
-func (_e *MockURLValidator_Expecter) ValidateBatch(urls interface{}) *MockURLValidator_ValidateBatch_Call {
-	return &MockURLValidator_ValidateBatch_Call{Call: _e.mock.On("ValidateBatch", urls)}
+//   - ctx context.Context
+//   - reqs []domain.CreateURLRequest
+func (_e *MockURLValidator_Expecter) ValidateBatch(ctx interface{}, reqs interface{}) *MockURLValidator_ValidateBatch_Call {
+	return &MockURLValidator_ValidateBatch_Call{Call: _e.mock.On("ValidateBatch", ctx, reqs)}
 }
 
-func (_c *MockURLValidator_ValidateBatch_Call) Run(run func(urls []string)) *MockURLValidator_ValidateBatch_Call {
+func (_c *MockURLValidator_ValidateBatch_Call) Run(run func(ctx context.Context, reqs []domain.CreateURLRequest)) *MockURLValidator_ValidateBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string))
+		run(args[0].(context.Context), args[1].([]domain.CreateURLRequest))
 	})
 	return _c
 }
@@ -58,22 +64,174 @@ func (_c *MockURLValidator_ValidateBatch_Call) Return(_a0 error) *MockURLValidat
 	return _c
 }
 
-func (_c *MockURLValidator_ValidateBatch_Call) RunAndReturn(run func([]string) error) *MockURLValidator_ValidateBatch_Call {
+func (_c *MockURLValidator_ValidateBatch_Call) RunAndReturn(run func(context.Context, []domain.CreateURLRequest) error) *MockURLValidator_ValidateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateBatchEntries provides a mock function with given fields: ctx, reqs
+func (_m *MockURLValidator) ValidateBatchEntries(ctx context.Context, reqs []domain.CreateURLRequest) ([]error, error) {
+	ret := _m.Called(ctx, reqs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateBatchEntries")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) ([]error, error)); ok {
+		return rf(ctx, reqs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.CreateURLRequest) []error); ok {
+		r0 = rf(ctx, reqs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.CreateURLRequest) error); ok {
+		r1 = rf(ctx, reqs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLValidator_ValidateBatchEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateBatchEntries'
+type MockURLValidator_ValidateBatchEntries_Call struct {
+	*mock.Call
+}
+
+// ValidateBatchEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqs []domain.CreateURLRequest
+func (_e *MockURLValidator_Expecter) ValidateBatchEntries(ctx interface{}, reqs interface{}) *MockURLValidator_ValidateBatchEntries_Call {
+	return &MockURLValidator_ValidateBatchEntries_Call{Call: _e.mock.On("ValidateBatchEntries", ctx, reqs)}
+}
+
+func (_c *MockURLValidator_ValidateBatchEntries_Call) Run(run func(ctx context.Context, reqs []domain.CreateURLRequest)) *MockURLValidator_ValidateBatchEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.CreateURLRequest))
+	})
+	return _c
+}
+
+func (_c *MockURLValidator_ValidateBatchEntries_Call) Return(_a0 []error, _a1 error) *MockURLValidator_ValidateBatchEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLValidator_ValidateBatchEntries_Call) RunAndReturn(run func(context.Context, []domain.CreateURLRequest) ([]error, error)) *MockURLValidator_ValidateBatchEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateCodes provides a mock function with given fields: codes
+func (_m *MockURLValidator) ValidateCodes(codes []string) error {
+	ret := _m.Called(codes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(codes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLValidator_ValidateCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateCodes'
+type MockURLValidator_ValidateCodes_Call struct {
+	*mock.Call
+}
+
+// ValidateCodes is a helper method to define mock.On call
+//   - codes []string
+func (_e *MockURLValidator_Expecter) ValidateCodes(codes interface{}) *MockURLValidator_ValidateCodes_Call {
+	return &MockURLValidator_ValidateCodes_Call{Call: _e.mock.On("ValidateCodes", codes)}
+}
+
+func (_c *MockURLValidator_ValidateCodes_Call) Run(run func(codes []string)) *MockURLValidator_ValidateCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *MockURLValidator_ValidateCodes_Call) Return(_a0 error) *MockURLValidator_ValidateCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLValidator_ValidateCodes_Call) RunAndReturn(run func([]string) error) *MockURLValidator_ValidateCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateRequest provides a mock function with given fields: ctx, req
+func (_m *MockURLValidator) ValidateRequest(ctx context.Context, req *domain.CreateURLRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateURLRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLValidator_ValidateRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateRequest'
+type MockURLValidator_ValidateRequest_Call struct {
+	*mock.Call
+}
+
+// ValidateRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.CreateURLRequest
+func (_e *MockURLValidator_Expecter) ValidateRequest(ctx interface{}, req interface{}) *MockURLValidator_ValidateRequest_Call {
+	return &MockURLValidator_ValidateRequest_Call{Call: _e.mock.On("ValidateRequest", ctx, req)}
+}
+
+func (_c *MockURLValidator_ValidateRequest_Call) Run(run func(ctx context.Context, req *domain.CreateURLRequest)) *MockURLValidator_ValidateRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.CreateURLRequest))
+	})
+	return _c
+}
+
+func (_c *MockURLValidator_ValidateRequest_Call) Return(_a0 error) *MockURLValidator_ValidateRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLValidator_ValidateRequest_Call) RunAndReturn(run func(context.Context, *domain.CreateURLRequest) error) *MockURLValidator_ValidateRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateURL provides a mock function with given fields: url
-func (_m *MockURLValidator) ValidateURL(url string) error {
-	ret := _m.Called(url)
+// ValidateURL provides a mock function with given fields: ctx, url
+func (_m *MockURLValidator) ValidateURL(ctx context.Context, url string) error {
+	ret := _m.Called(ctx, url)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateURL")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(url)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, url)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,14 +245,15 @@ type MockURLValidator_ValidateURL_Call struct {
 }
 
 // ValidateURL is a helper method to define mock.On call
+//   - ctx context.Context
 //   - url string
-func (_e *MockURLValidator_Expecter) ValidateURL(url interface{}) *MockURLValidator_ValidateURL_Call {
-	return &MockURLValidator_ValidateURL_Call{Call: _e.mock.On("ValidateURL", url)}
+func (_e *MockURLValidator_Expecter) ValidateURL(ctx interface{}, url interface{}) *MockURLValidator_ValidateURL_Call {
+	return &MockURLValidator_ValidateURL_Call{Call: _e.mock.On("ValidateURL", ctx, url)}
 }
 
-func (_c *MockURLValidator_ValidateURL_Call) Run(run func(url string)) *MockURLValidator_ValidateURL_Call {
+func (_c *MockURLValidator_ValidateURL_Call) Run(run func(ctx context.Context, url string)) *MockURLValidator_ValidateURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -104,7 +263,7 @@ func (_c *MockURLValidator_ValidateURL_Call) Return(_a0 error) *MockURLValidator
 	return _c
 }
 
-func (_c *MockURLValidator_ValidateURL_Call) RunAndReturn(run func(string) error) *MockURLValidator_ValidateURL_Call {
+func (_c *MockURLValidator_ValidateURL_Call) RunAndReturn(run func(context.Context, string) error) *MockURLValidator_ValidateURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
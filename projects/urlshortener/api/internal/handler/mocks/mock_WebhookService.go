@@ -0,0 +1,310 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService is an autogenerated mock type for the WebhookService type
+type MockWebhookService struct {
+	mock.Mock
+}
+
+type MockWebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookService) EXPECT() *MockWebhookService_Expecter {
+	return &MockWebhookService_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, req
+func (_m *MockWebhookService) CreateWebhook(ctx context.Context, req *domain.CreateWebhookRequest) (*domain.CreateWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *domain.CreateWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateWebhookRequest) (*domain.CreateWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateWebhookRequest) *domain.CreateWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CreateWebhookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockWebhookService_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.CreateWebhookRequest
+func (_e *MockWebhookService_Expecter) CreateWebhook(ctx interface{}, req interface{}) *MockWebhookService_CreateWebhook_Call {
+	return &MockWebhookService_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, req)}
+}
+
+func (_c *MockWebhookService_CreateWebhook_Call) Run(run func(ctx context.Context, req *domain.CreateWebhookRequest)) *MockWebhookService_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.CreateWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_CreateWebhook_Call) Return(_a0 *domain.CreateWebhookResponse, _a1 error) *MockWebhookService_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_CreateWebhook_Call) RunAndReturn(run func(context.Context, *domain.CreateWebhookRequest) (*domain.CreateWebhookResponse, error)) *MockWebhookService_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *MockWebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookService_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockWebhookService_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookService_Expecter) DeleteWebhook(ctx interface{}, id interface{}) *MockWebhookService_DeleteWebhook_Call {
+	return &MockWebhookService_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, id)}
+}
+
+func (_c *MockWebhookService_DeleteWebhook_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookService_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteWebhook_Call) Return(_a0 error) *MockWebhookService_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteWebhook_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookService_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, status, webhookID, cursor, limit
+func (_m *MockWebhookService) ListDeliveries(ctx context.Context, status string, webhookID int64, cursor string, limit int) (*domain.DeliveryPage, error) {
+	ret := _m.Called(ctx, status, webhookID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 *domain.DeliveryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, int) (*domain.DeliveryPage, error)); ok {
+		return rf(ctx, status, webhookID, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, int) *domain.DeliveryPage); ok {
+		r0 = rf(ctx, status, webhookID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DeliveryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string, int) error); ok {
+		r1 = rf(ctx, status, webhookID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type MockWebhookService_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+//   - webhookID int64
+//   - cursor string
+//   - limit int
+func (_e *MockWebhookService_Expecter) ListDeliveries(ctx interface{}, status interface{}, webhookID interface{}, cursor interface{}, limit interface{}) *MockWebhookService_ListDeliveries_Call {
+	return &MockWebhookService_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, status, webhookID, cursor, limit)}
+}
+
+func (_c *MockWebhookService_ListDeliveries_Call) Run(run func(ctx context.Context, status string, webhookID int64, cursor string, limit int)) *MockWebhookService_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListDeliveries_Call) Return(_a0 *domain.DeliveryPage, _a1 error) *MockWebhookService_ListDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ListDeliveries_Call) RunAndReturn(run func(context.Context, string, int64, string, int) (*domain.DeliveryPage, error)) *MockWebhookService_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: ctx
+func (_m *MockWebhookService) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type MockWebhookService_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) ListWebhooks(ctx interface{}) *MockWebhookService_ListWebhooks_Call {
+	return &MockWebhookService_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx)}
+}
+
+func (_c *MockWebhookService_ListWebhooks_Call) Run(run func(ctx context.Context)) *MockWebhookService_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListWebhooks_Call) Return(_a0 []domain.Webhook, _a1 error) *MockWebhookService_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ListWebhooks_Call) RunAndReturn(run func(context.Context) ([]domain.Webhook, error)) *MockWebhookService_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryDelivery provides a mock function with given fields: ctx, id
+func (_m *MockWebhookService) RetryDelivery(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookService_RetryDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryDelivery'
+type MockWebhookService_RetryDelivery_Call struct {
+	*mock.Call
+}
+
+// RetryDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookService_Expecter) RetryDelivery(ctx interface{}, id interface{}) *MockWebhookService_RetryDelivery_Call {
+	return &MockWebhookService_RetryDelivery_Call{Call: _e.mock.On("RetryDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookService_RetryDelivery_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookService_RetryDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RetryDelivery_Call) Return(_a0 error) *MockWebhookService_RetryDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookService_RetryDelivery_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookService_RetryDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookService creates a new instance of MockWebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookService {
+	mock := &MockWebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// passwordHeader lets API clients unlock a protected link without the form.
+const passwordHeader = "X-Link-Password"
+
+var passwordForm = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+</head>
+<body>
+<form method="post">
+<p>This link is password protected.</p>
+{{if .}}<p role="alert">{{.}}</p>{{end}}
+<input type="password" name="password" aria-label="Password" autofocus required>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// passwordPrompt answers a locked redirect. Browsers get the password form,
+// API clients get body as JSON.
+func (h *Handler) passwordPrompt(c echo.Context, status int, body map[string]string, message string) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	if !strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMETextHTML) {
+		return c.JSON(status, body)
+	}
+
+	var buf bytes.Buffer
+	if err := passwordForm.Execute(&buf, message); err != nil {
+		return c.JSON(http.StatusInternalServerError, errGetFailed)
+	}
+	return c.HTMLBlob(status, buf.Bytes())
+}
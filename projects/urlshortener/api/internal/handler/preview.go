@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+)
+
+// previewSuffix turns /:code into /:code+, which shows where a link goes
+// instead of following it. Aliases cannot contain "+".
+const previewSuffix = "+"
+
+var previewPage = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link preview: {{.Code}}</title>
+</head>
+<body>
+<h1>This link leads to {{.Domain}}</h1>
+<dl>
+<dt>Destination</dt>
+<dd>{{.URL}}</dd>
+<dt>Created</dt>
+<dd><time datetime="{{.CreatedAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.CreatedAt.Format "January 2, 2006"}}</time></dd>
+</dl>
+<a href="{{.URL}}" rel="noreferrer noopener">Continue to {{.Domain}}</a>
+</body>
+</html>
+`))
+
+type previewData struct {
+	Code      string
+	URL       string
+	Domain    string
+	CreatedAt time.Time
+}
+
+func (h *Handler) renderPreview(c echo.Context, code string, r *domain.Redirect) error {
+	data := previewData{
+		Code:      code,
+		URL:       r.URL,
+		Domain:    r.URL,
+		CreatedAt: r.CreatedAt,
+	}
+	if parsed, err := url.Parse(r.URL); err == nil && parsed.Host != "" {
+		data.Domain = parsed.Hostname()
+	}
+
+	var buf bytes.Buffer
+	if err := previewPage.Execute(&buf, data); err != nil {
+		return c.JSON(http.StatusInternalServerError, errGetFailed)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, no-cache")
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
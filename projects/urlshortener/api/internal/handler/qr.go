@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+)
+
+var (
+	errInvalidQRFormat = map[string]string{"error": "format must be png or svg"}
+	errInvalidQRLevel  = map[string]string{"error": "level must be L, M, Q or H"}
+	errInvalidQRSize   = map[string]string{"error": "size is out of range"}
+	errInvalidQRMargin = map[string]string{"error": "margin must be between 0 and 16"}
+	errQRFailed        = map[string]string{"error": "failed to render qr code"}
+)
+
+// The image only encodes the short URL, which never changes.
+const qrMaxAge = "private, max-age=86400"
+
+type QRHandler struct {
+	qrService QRService
+	defaults  domain.QROptions
+	logger    *slog.Logger
+}
+
+func NewQRHandler(qrService QRService, defaults domain.QROptions, logger *slog.Logger) *QRHandler {
+	return &QRHandler{
+		qrService: qrService,
+		defaults:  defaults,
+		logger:    logger,
+	}
+}
+
+func (h *QRHandler) Register(g *echo.Group, m ...echo.MiddlewareFunc) {
+	g.GET("/urls/:code/qr", h.QRCode, m...)
+}
+
+func (h *QRHandler) QRCode(c echo.Context) error {
+	code := c.Param("code")
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, errCodeRequired)
+	}
+
+	opts := h.defaults
+	if format := c.QueryParam("format"); format != "" {
+		opts.Format = format
+	}
+	if level := c.QueryParam("level"); level != "" {
+		opts.Level = level
+	}
+	var ok bool
+	if opts.Size, ok = intParam(c, "size", opts.Size); !ok {
+		return c.JSON(http.StatusBadRequest, errInvalidQRSize)
+	}
+	if opts.Margin, ok = intParam(c, "margin", opts.Margin); !ok {
+		return c.JSON(http.StatusBadRequest, errInvalidQRMargin)
+	}
+
+	img, err := h.qrService.QRCode(c.Request().Context(), code, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrURLNotFound):
+			return c.JSON(http.StatusNotFound, errURLNotFound)
+		case errors.Is(err, service.ErrInvalidQRFormat):
+			return c.JSON(http.StatusBadRequest, errInvalidQRFormat)
+		case errors.Is(err, service.ErrInvalidQRLevel):
+			return c.JSON(http.StatusBadRequest, errInvalidQRLevel)
+		case errors.Is(err, service.ErrInvalidQRSize):
+			return c.JSON(http.StatusBadRequest, errInvalidQRSize)
+		case errors.Is(err, service.ErrInvalidQRMargin):
+			return c.JSON(http.StatusBadRequest, errInvalidQRMargin)
+		}
+		h.logger.Error("failed to render qr code", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errQRFailed)
+	}
+
+	contentType := "image/png"
+	if opts.Format == domain.QRFormatSVG {
+		contentType = "image/svg+xml"
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, qrMaxAge)
+	return c.Blob(http.StatusOK, contentType, img)
+}
+
+func intParam(c echo.Context, name string, fallback int) (n int, ok bool) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return fallback, true
+	}
+	n, err := strconv.Atoi(raw)
+	return n, err == nil
+}
@@ -0,0 +1,89 @@
+package handler_test
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/handler/mocks"
+	"urlshortener/internal/service"
+)
+
+var qrDefaults = domain.QROptions{Format: domain.QRFormatPNG, Size: 256, Level: "M", Margin: 4}
+
+func newQRHandler(t *testing.T) (*handler.QRHandler, *mocks.MockQRService) {
+	svc := mocks.NewMockQRService(t)
+	return handler.NewQRHandler(svc, qrDefaults, slog.New(slog.NewTextHandler(os.Stdout, nil))), svc
+}
+
+func qrContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/v1/urls/:code/qr")
+	c.SetParamNames("code")
+	c.SetParamValues("abc123")
+	return c, rec
+}
+
+func TestQRCode_Defaults(t *testing.T) {
+	h, svc := newQRHandler(t)
+	svc.EXPECT().QRCode(mock.Anything, "abc123", qrDefaults).Return([]byte("png"), nil)
+
+	c, rec := qrContext("/api/v1/urls/abc123/qr")
+	require.NoError(t, h.QRCode(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "private, max-age=86400", rec.Header().Get(echo.HeaderCacheControl))
+	assert.Equal(t, "png", rec.Body.String())
+}
+
+func TestQRCode_Overrides(t *testing.T) {
+	h, svc := newQRHandler(t)
+	want := domain.QROptions{Format: domain.QRFormatSVG, Size: 512, Level: "H", Margin: 0}
+	svc.EXPECT().QRCode(mock.Anything, "abc123", want).Return([]byte("<svg/>"), nil)
+
+	c, rec := qrContext("/api/v1/urls/abc123/qr?format=svg&size=512&level=H&margin=0")
+	require.NoError(t, h.QRCode(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/svg+xml", rec.Header().Get(echo.HeaderContentType))
+}
+
+func TestQRCode_InvalidSize(t *testing.T) {
+	h, _ := newQRHandler(t)
+
+	c, rec := qrContext("/api/v1/urls/abc123/qr?size=big")
+	require.NoError(t, h.QRCode(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestQRCode_Errors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{service.ErrURLNotFound, http.StatusNotFound},
+		{service.ErrInvalidQRLevel, http.StatusBadRequest},
+		{service.ErrInvalidQRSize, http.StatusBadRequest},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		h, svc := newQRHandler(t)
+		svc.EXPECT().QRCode(mock.Anything, "abc123", mock.Anything).Return(nil, tt.err)
+
+		c, rec := qrContext("/api/v1/urls/abc123/qr")
+		require.NoError(t, h.QRCode(c))
+		assert.Equal(t, tt.status, rec.Code, tt.err.Error())
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+)
+
+var (
+	errCodesRequired = map[string]string{"error": "codes is required"}
+	errResolveFailed = map[string]string{"error": "failed to resolve urls"}
+)
+
+// ResolveURLs returns the links of many short codes in one response, for
+// tools that would otherwise follow each short URL.
+func (h *Handler) ResolveURLs(c echo.Context) error {
+	var req domain.ResolveRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", slog.String("error", err.Error()))
+		return c.JSON(http.StatusBadRequest, errInvalidBody)
+	}
+
+	if err := h.urlValidator.ValidateCodes(req.Codes); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	resp, err := h.urlService.ResolveURLs(c.Request().Context(), req.Codes)
+	if err != nil {
+		h.logger.Error("failed to resolve urls", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errResolveFailed)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/validation"
+)
+
+func postResolve(t *testing.T, h *handler.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/urls/resolve", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.ResolveURLs(e.NewContext(req, rec)))
+	return rec
+}
+
+func TestResolveURLs_Success(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateCodes([]string{"abc123", "gone"}).Return(nil)
+	svc.EXPECT().ResolveURLs(mock.Anything, []string{"abc123", "gone"}).Return(&domain.ResolveResponse{
+		URLs:    []domain.URLInfo{{ShortCode: "abc123", OriginalURL: "https://example.com"}},
+		Missing: []string{"gone"},
+	}, nil)
+
+	rec := postResolve(t, h, `{"codes":["abc123","gone"]}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"original_url":"https://example.com"`)
+	assert.Contains(t, rec.Body.String(), `"missing":["gone"]`)
+}
+
+func TestResolveURLs_Rejected(t *testing.T) {
+	h, _, val, _ := newTestHandler(t)
+
+	rec := postResolve(t, h, `{"codes":`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	val.EXPECT().ValidateCodes([]string(nil)).Return(validation.ErrEmptyCodes)
+	rec = postResolve(t, h, `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "codes is required")
+
+	val.EXPECT().ValidateCodes([]string{"a", "b"}).Return(validation.ErrBatchTooLarge)
+	rec = postResolve(t, h, `{"codes":["a","b"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "batch size exceeds maximum")
+}
+
+func TestResolveURLs_ServiceError(t *testing.T) {
+	h, svc, val, _ := newTestHandler(t)
+
+	val.EXPECT().ValidateCodes(mock.Anything).Return(nil)
+	svc.EXPECT().ResolveURLs(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	rec := postResolve(t, h, `{"codes":["abc123"]}`)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "failed to resolve urls")
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+// variantCookieMaxAge is how long a visitor keeps the variant of a sticky
+// split.
+const variantCookieMaxAge = 30 * 24 * time.Hour
+
+// variantCookieName is per link, so each split assigns visitors separately.
+func variantCookieName(code string) string {
+	return "ab_" + code
+}
+
+// variantCookie remembers the variant served for code. It is only sent back
+// on visits to the same short URL.
+func variantCookie(code, variant string) *http.Cookie {
+	return &http.Cookie{
+		Name:     variantCookieName(code),
+		Value:    variant,
+		Path:     "/" + code,
+		MaxAge:   int(variantCookieMaxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+)
+
+var (
+	errInvalidWebhookURL = map[string]string{"error": "url must be a public http or https url"}
+	errInvalidEvents     = map[string]string{"error": "events must be a non-empty list of link.created, link.updated, link.deleted and link.clicked"}
+	errInvalidWebhookID  = map[string]string{"error": "invalid webhook id"}
+	errWebhookNotFound   = map[string]string{"error": "webhook not found"}
+	errInvalidStatus     = map[string]string{"error": "status must be pending or dead"}
+	errInvalidDelivery   = map[string]string{"error": "invalid delivery id"}
+	errDeliveryNotFound  = map[string]string{"error": "dead delivery not found"}
+	errWebhookFailed     = map[string]string{"error": "failed to manage webhooks"}
+)
+
+// WebhookHandler serves webhook registration and the delivery queue. Its
+// routes are meant to sit behind admin authentication.
+type WebhookHandler struct {
+	webhookService WebhookService
+	validator      URLValidator
+	logger         *slog.Logger
+}
+
+func NewWebhookHandler(webhookService WebhookService, validator URLValidator, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validator:      validator,
+		logger:         logger,
+	}
+}
+
+func (h *WebhookHandler) Register(g *echo.Group) {
+	g.POST("", h.CreateWebhook)
+	g.GET("", h.ListWebhooks)
+	g.DELETE("/:id", h.DeleteWebhook)
+	g.GET("/deliveries", h.ListDeliveries)
+	g.POST("/deliveries/:id/retry", h.RetryDelivery)
+}
+
+// CreateWebhook registers an endpoint. The secret in the response signs every
+// delivery and is only shown here.
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var req domain.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("failed to bind request", slog.String("error", err.Error()))
+		return c.JSON(http.StatusBadRequest, errInvalidBody)
+	}
+	if err := h.validator.ValidateURL(c.Request().Context(), req.URL); err != nil {
+		return c.JSON(http.StatusBadRequest, errInvalidWebhookURL)
+	}
+
+	resp, err := h.webhookService.CreateWebhook(c.Request().Context(), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidEvents) {
+			return c.JSON(http.StatusBadRequest, errInvalidEvents)
+		}
+		h.logger.Error("failed to create webhook", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errWebhookFailed)
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	hooks, err := h.webhookService.ListWebhooks(c.Request().Context())
+	if err != nil {
+		h.logger.Error("failed to list webhooks", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errWebhookFailed)
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.Webhook{"webhooks": hooks})
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errInvalidWebhookID)
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request().Context(), id); err != nil {
+		if errors.Is(err, service.ErrWebhookNotFound) {
+			return c.JSON(http.StatusNotFound, errWebhookNotFound)
+		}
+		h.logger.Error("failed to delete webhook", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errWebhookFailed)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries pages through queued deliveries, narrowed by the status and
+// webhook_id query parameters. Pagination works as in ListURLs.
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	limit, ok := pageLimit(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, errInvalidLimit)
+	}
+	var webhookID int64
+	if raw := c.QueryParam("webhook_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, errInvalidWebhookID)
+		}
+		webhookID = id
+	}
+
+	page, err := h.webhookService.ListDeliveries(c.Request().Context(), c.QueryParam("status"), webhookID, c.QueryParam("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidStatus):
+			return c.JSON(http.StatusBadRequest, errInvalidStatus)
+		case errors.Is(err, service.ErrInvalidCursor):
+			return c.JSON(http.StatusBadRequest, errInvalidCursor)
+		}
+		h.logger.Error("failed to list deliveries", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errWebhookFailed)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// RetryDelivery requeues a dead delivery.
+func (h *WebhookHandler) RetryDelivery(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errInvalidDelivery)
+	}
+
+	if err := h.webhookService.RetryDelivery(c.Request().Context(), id); err != nil {
+		if errors.Is(err, service.ErrDeliveryNotFound) {
+			return c.JSON(http.StatusNotFound, errDeliveryNotFound)
+		}
+		h.logger.Error("failed to retry delivery", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, errWebhookFailed)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
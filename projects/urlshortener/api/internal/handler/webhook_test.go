@@ -0,0 +1,142 @@
+package handler_test
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/handler"
+	"urlshortener/internal/handler/mocks"
+	"urlshortener/internal/service"
+	"urlshortener/internal/validation"
+)
+
+func newTestWebhookHandler(t *testing.T) (*handler.WebhookHandler, *mocks.MockWebhookService, *mocks.MockURLValidator) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := mocks.NewMockWebhookService(t)
+	val := mocks.NewMockURLValidator(t)
+	return handler.NewWebhookHandler(svc, val, logger), svc, val
+}
+
+func webhookContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestCreateWebhook_Success(t *testing.T) {
+	h, svc, val := newTestWebhookHandler(t)
+
+	req := &domain.CreateWebhookRequest{URL: "https://hooks.example.com", Events: []string{domain.EventLinkCreated}}
+	val.EXPECT().ValidateURL(mock.Anything, "https://hooks.example.com").Return(nil)
+	svc.EXPECT().CreateWebhook(mock.Anything, req).Return(&domain.CreateWebhookResponse{
+		Webhook: domain.Webhook{ID: 1, URL: req.URL, Events: req.Events},
+		Secret:  "whsec_secret",
+	}, nil)
+
+	c, rec := webhookContext(http.MethodPost, "/api/v1/webhooks", `{"url":"https://hooks.example.com","events":["link.created"]}`)
+
+	require.NoError(t, h.CreateWebhook(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"secret":"whsec_secret"`)
+}
+
+func TestCreateWebhook_InvalidURL(t *testing.T) {
+	h, _, val := newTestWebhookHandler(t)
+
+	val.EXPECT().ValidateURL(mock.Anything, "http://10.0.0.1/hook").Return(validation.ErrPrivateIPNotAllowed)
+
+	c, rec := webhookContext(http.MethodPost, "/api/v1/webhooks", `{"url":"http://10.0.0.1/hook","events":["link.created"]}`)
+
+	require.NoError(t, h.CreateWebhook(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateWebhook_InvalidEvents(t *testing.T) {
+	h, svc, val := newTestWebhookHandler(t)
+
+	val.EXPECT().ValidateURL(mock.Anything, mock.Anything).Return(nil)
+	svc.EXPECT().CreateWebhook(mock.Anything, mock.Anything).Return(nil, service.ErrInvalidEvents)
+
+	c, rec := webhookContext(http.MethodPost, "/api/v1/webhooks", `{"url":"https://hooks.example.com","events":["link.*"]}`)
+
+	require.NoError(t, h.CreateWebhook(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDeleteWebhook_NotFound(t *testing.T) {
+	h, svc, _ := newTestWebhookHandler(t)
+
+	svc.EXPECT().DeleteWebhook(mock.Anything, int64(3)).Return(service.ErrWebhookNotFound)
+
+	c, rec := webhookContext(http.MethodDelete, "/api/v1/webhooks/3", "")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+
+	require.NoError(t, h.DeleteWebhook(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListDeliveries_Filters(t *testing.T) {
+	h, svc, _ := newTestWebhookHandler(t)
+
+	svc.EXPECT().ListDeliveries(mock.Anything, domain.DeliveryDead, int64(3), "abc", 20).
+		Return(&domain.DeliveryPage{Deliveries: []domain.WebhookDelivery{{ID: 4, Status: domain.DeliveryDead}}}, nil)
+
+	c, rec := webhookContext(http.MethodGet, "/api/v1/webhooks/deliveries?status=dead&webhook_id=3&cursor=abc&limit=20", "")
+
+	require.NoError(t, h.ListDeliveries(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"dead"`)
+}
+
+func TestListDeliveries_Invalid(t *testing.T) {
+	h, svc, _ := newTestWebhookHandler(t)
+
+	c, rec := webhookContext(http.MethodGet, "/api/v1/webhooks/deliveries?webhook_id=x", "")
+	require.NoError(t, h.ListDeliveries(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	svc.EXPECT().ListDeliveries(mock.Anything, "delivered", int64(0), "", 0).Return(nil, service.ErrInvalidStatus)
+	c, rec = webhookContext(http.MethodGet, "/api/v1/webhooks/deliveries?status=delivered", "")
+	require.NoError(t, h.ListDeliveries(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRetryDelivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"requeued", nil, http.StatusAccepted},
+		{"not dead", service.ErrDeliveryNotFound, http.StatusNotFound},
+		{"failure", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, svc, _ := newTestWebhookHandler(t)
+			svc.EXPECT().RetryDelivery(mock.Anything, int64(5)).Return(tt.err)
+
+			c, rec := webhookContext(http.MethodPost, "/api/v1/webhooks/deliveries/5/retry", "")
+			c.SetParamNames("id")
+			c.SetParamValues("5")
+
+			require.NoError(t, h.RetryDelivery(c))
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+)
+
+const (
+	apiKeyHeader      = "X-API-Key"
+	adminSecretHeader = "X-Admin-Secret"
+)
+
+var (
+	errInvalidAPIKey      = map[string]string{"error": "invalid api key"}
+	errAPIKeyRequired     = map[string]string{"error": "api key required"}
+	errInsufficientScope  = map[string]string{"error": "api key lacks required scope"}
+	errAuthInternal       = map[string]string{"error": "internal server error"}
+	errTooManyKeyFailures = rateLimitResponse{Error: "too many invalid api keys", RetryAfter: 60}
+	errAdminUnauthorized  = map[string]string{"error": "unauthorized"}
+)
+
+type APIKeyStore interface {
+	FindByHash(ctx context.Context, keyHash []byte) (*domain.APIKey, error)
+}
+
+type KeyAttemptLimiter interface {
+	Reserve(clientIP string) (refund func(), ok bool)
+}
+
+// Clients that sent too many unknown keys get 429 before the lookup, so random
+// keys cannot be used to load the database.
+func Authenticate(store APIKeyStore, attempts KeyAttemptLimiter, logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			clientIP := c.RealIP()
+			c.SetRequest(c.Request().WithContext(auth.WithClientIP(c.Request().Context(), clientIP)))
+
+			plain := apiKeyFrom(c.Request())
+			if plain == "" {
+				return next(c)
+			}
+
+			refund, ok := attempts.Reserve(clientIP)
+			if !ok {
+				logger.Warn("too many invalid api keys", slog.String("client_ip", clientIP))
+				c.Response().Header().Set("Retry-After", strconv.Itoa(errTooManyKeyFailures.RetryAfter))
+				return c.JSON(http.StatusTooManyRequests, errTooManyKeyFailures)
+			}
+			key, err := store.FindByHash(c.Request().Context(), auth.HashKey(plain))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return c.JSON(http.StatusUnauthorized, errInvalidAPIKey)
+				}
+				refund()
+				logger.Error("failed to authenticate api key", slog.String("error", err.Error()))
+				return c.JSON(http.StatusInternalServerError, errAuthInternal)
+			}
+			refund()
+
+			c.SetRequest(c.Request().WithContext(auth.NewContext(c.Request().Context(), key)))
+			return next(c)
+		}
+	}
+}
+
+func apiKeyFrom(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return r.Header.Get(apiKeyHeader)
+}
+
+// Anonymous requests pass unless required, so keys can be rolled out before
+// they are enforced.
+func RequireScope(scope string, required bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := auth.FromContext(c.Request().Context())
+			if key == nil {
+				if required {
+					return c.JSON(http.StatusUnauthorized, errAPIKeyRequired)
+				}
+				return next(c)
+			}
+			if !key.HasScope(scope) {
+				return c.JSON(http.StatusForbidden, errInsufficientScope)
+			}
+			return next(c)
+		}
+	}
+}
+
+func AdminAuth(secret string) echo.MiddlewareFunc {
+	secretBytes := []byte(secret)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			provided := c.Request().Header.Get(adminSecretHeader)
+			if secret == "" || subtle.ConstantTimeCompare([]byte(provided), secretBytes) != 1 {
+				return c.JSON(http.StatusUnauthorized, errAdminUnauthorized)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package middleware_test
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/middleware"
+	"urlshortener/internal/middleware/mocks"
+	"urlshortener/internal/password"
+)
+
+func newAuthEcho(store middleware.APIKeyStore, routeMW ...echo.MiddlewareFunc) *echo.Echo {
+	e := echo.New()
+	e.Use(middleware.Authenticate(store, password.NewAttemptLimiter(60, 100, time.Minute), slog.New(slog.NewTextHandler(os.Stdout, nil))))
+	e.GET("/test", func(c echo.Context) error {
+		if key := auth.FromContext(c.Request().Context()); key != nil {
+			return c.String(http.StatusOK, key.Name)
+		}
+		return c.String(http.StatusOK, "anonymous")
+	}, routeMW...)
+	return e
+}
+
+func TestAuthenticate_BearerToken(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+	store.EXPECT().FindByHash(mock.Anything, auth.HashKey("usk_test")).
+		Return(&domain.APIKey{ID: 1, Name: "ci"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer usk_test")
+	rec := httptest.NewRecorder()
+	newAuthEcho(store).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ci", rec.Body.String())
+}
+
+func TestAuthenticate_APIKeyHeader(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+	store.EXPECT().FindByHash(mock.Anything, auth.HashKey("usk_test")).
+		Return(&domain.APIKey{ID: 1, Name: "ci"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-API-Key", "usk_test")
+	rec := httptest.NewRecorder()
+	newAuthEcho(store).ServeHTTP(rec, req)
+
+	assert.Equal(t, "ci", rec.Body.String())
+}
+
+func TestAuthenticate_NoKeyIsAnonymous(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+
+	rec := httptest.NewRecorder()
+	newAuthEcho(store).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "anonymous", rec.Body.String())
+}
+
+func TestAuthenticate_UnknownKey(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+	store.EXPECT().FindByHash(mock.Anything, mock.Anything).Return(nil, pgx.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-API-Key", "usk_revoked")
+	rec := httptest.NewRecorder()
+	newAuthEcho(store).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthenticate_StoreError(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+	store.EXPECT().FindByHash(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-API-Key", "usk_test")
+	rec := httptest.NewRecorder()
+	newAuthEcho(store).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string // nil sends no key
+		required bool
+		want     int
+	}{
+		{"anonymous allowed", nil, false, http.StatusOK},
+		{"anonymous rejected", nil, true, http.StatusUnauthorized},
+		{"key with scope", []string{domain.ScopeLinksCreate}, true, http.StatusOK},
+		{"key without scope", []string{domain.ScopeStatsRead}, false, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := mocks.NewMockAPIKeyStore(t)
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.scopes != nil {
+				store.EXPECT().FindByHash(mock.Anything, mock.Anything).
+					Return(&domain.APIKey{ID: 1, Name: "ci", Scopes: tt.scopes}, nil)
+				req.Header.Set("X-API-Key", "usk_test")
+			}
+
+			rec := httptest.NewRecorder()
+			newAuthEcho(store, middleware.RequireScope(domain.ScopeLinksCreate, tt.required)).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		provided string
+		want     int
+	}{
+		{"correct secret", "admin", "admin", http.StatusOK},
+		{"wrong secret", "admin", "guess", http.StatusUnauthorized},
+		{"empty secret denies", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.GET("/keys", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, middleware.AdminAuth(tt.secret))
+
+			req := httptest.NewRequest(http.MethodGet, "/keys", nil)
+			req.Header.Set("X-Admin-Secret", tt.provided)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestAuthenticate_StoresClientIP(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.Authenticate(mocks.NewMockAPIKeyStore(t), password.NewAttemptLimiter(60, 100, time.Minute), slog.New(slog.NewTextHandler(os.Stdout, nil))))
+	e.GET("/test", func(c echo.Context) error {
+		return c.String(http.StatusOK, auth.ClientIPFromContext(c.Request().Context()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "203.0.113.9:52100"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "203.0.113.9", rec.Body.String())
+}
+
+func TestAuthenticate_ThrottlesUnknownKeys(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+	store.EXPECT().FindByHash(mock.Anything, mock.Anything).Return(nil, pgx.ErrNoRows).Times(2)
+	store.EXPECT().FindByHash(mock.Anything, auth.HashKey("usk_valid")).
+		Return(&domain.APIKey{ID: 1, Name: "ci"}, nil).Once()
+
+	e := echo.New()
+	e.Use(middleware.Authenticate(store, password.NewAttemptLimiter(0.001, 2, time.Minute), slog.New(slog.NewTextHandler(os.Stdout, nil))))
+	e.GET("/test", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	send := func(key, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-API-Key", key)
+		req.RemoteAddr = ip + ":52100"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, send("usk_guess1", "203.0.113.9").Code)
+	assert.Equal(t, http.StatusUnauthorized, send("usk_guess2", "203.0.113.9").Code)
+	rec := send("usk_guess3", "203.0.113.9")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "no lookup once the client is throttled")
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, send("usk_valid", "198.51.100.4").Code, "other clients are unaffected")
+}
+
+func TestAuthenticate_ValidKeysAreNotThrottled(t *testing.T) {
+	store := mocks.NewMockAPIKeyStore(t)
+	store.EXPECT().FindByHash(mock.Anything, auth.HashKey("usk_valid")).
+		Return(&domain.APIKey{ID: 1, Name: "ci"}, nil).Times(5)
+
+	e := echo.New()
+	e.Use(middleware.Authenticate(store, password.NewAttemptLimiter(0.001, 1, time.Minute), slog.New(slog.NewTextHandler(os.Stdout, nil))))
+	e.GET("/test", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	for range 5 {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-API-Key", "usk_valid")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
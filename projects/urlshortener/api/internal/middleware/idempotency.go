@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+var (
+	errInvalidIdempotencyKey  = map[string]string{"error": "idempotency key must be 1-255 characters"}
+	errIdempotencyKeyReused   = map[string]string{"error": "idempotency key was used with a different request"}
+	errIdempotencyKeyInFlight = map[string]string{"error": "a request with this idempotency key is in progress"}
+	errIdempotencyInternal    = map[string]string{"error": "internal server error"}
+)
+
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key, fingerprint string) (*domain.IdempotentResponse, error)
+	Complete(ctx context.Context, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, key string) error
+}
+
+// Server errors are not stored, so they can be retried.
+func Idempotency(store IdempotencyStore, logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(idempotencyKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return c.JSON(http.StatusBadRequest, errInvalidIdempotencyKey)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			fingerprint := requestFingerprint(c.Request().Method, c.Path(), c.Request().URL.RawQuery, body)
+
+			// Client-chosen keys are only unique per API key or client IP.
+			if apiKey := auth.FromContext(ctx); apiKey != nil {
+				key = strconv.FormatInt(apiKey.ID, 10) + ":" + key
+			} else {
+				key = "ip:" + c.RealIP() + ":" + key
+			}
+
+			prev, err := store.Reserve(ctx, key, fingerprint)
+			if err != nil {
+				logger.Error("failed to reserve idempotency key", slog.String("error", err.Error()))
+				return c.JSON(http.StatusInternalServerError, errIdempotencyInternal)
+			}
+			if prev != nil {
+				switch {
+				case prev.Fingerprint != fingerprint:
+					return c.JSON(http.StatusUnprocessableEntity, errIdempotencyKeyReused)
+				case prev.Pending():
+					return c.JSON(http.StatusConflict, errIdempotencyKeyInFlight)
+				}
+				c.Response().Header().Set(idempotentReplayedHeader, "true")
+				return c.JSONBlob(prev.StatusCode, prev.Body)
+			}
+
+			capture := &responseCapture{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = capture
+
+			err = next(c)
+
+			// A retry must find the outcome even if the client gave up.
+			ctx = context.WithoutCancel(ctx)
+			status := c.Response().Status
+			if err != nil || status >= http.StatusInternalServerError {
+				if rerr := store.Release(ctx, key); rerr != nil {
+					logger.Error("failed to release idempotency key", slog.String("error", rerr.Error()))
+				}
+				return err
+			}
+			if cerr := store.Complete(ctx, key, status, capture.body.Bytes()); cerr != nil {
+				logger.Error("failed to store idempotent response", slog.String("error", cerr.Error()))
+			}
+			return nil
+		}
+	}
+}
+
+// An empty query is left out so stored fingerprints stay valid.
+func requestFingerprint(method, path, query string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	if query != "" {
+		h.Write([]byte("?" + query))
+	}
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type responseCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseCapture) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,222 @@
+package middleware_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/middleware"
+	"urlshortener/internal/middleware/mocks"
+)
+
+func newIdempotentEcho(t *testing.T, store *mocks.MockIdempotencyStore, status int, calls *int) *echo.Echo {
+	t.Helper()
+	e := echo.New()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	e.POST("/urls", func(c echo.Context) error {
+		*calls++
+		return c.JSON(status, map[string]string{"short_code": "abc123"})
+	}, middleware.Idempotency(store, logger))
+	return e
+}
+
+func idempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/urls", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	return req
+}
+
+func TestIdempotency_NoHeaderPassesThrough(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("", `{"url":"https://example.com"}`))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_FirstRequestStoresResponse(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).Return(nil, nil)
+	store.EXPECT().Complete(mock.Anything, "ip:192.0.2.1:key-1", http.StatusCreated,
+		mock.MatchedBy(func(b []byte) bool { return strings.Contains(string(b), "abc123") }),
+	).Return(nil)
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("key-1", `{"url":"https://example.com"}`))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_RetryReplaysResponse(t *testing.T) {
+	var fingerprint string
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).
+		Run(func(_ context.Context, _ string, fp string) { fingerprint = fp }).
+		Return(nil, nil).Once()
+	store.EXPECT().Complete(mock.Anything, "ip:192.0.2.1:key-1", http.StatusCreated, mock.Anything).Return(nil).Once()
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+	e.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", `{"url":"https://example.com"}`))
+
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).Return(&domain.IdempotentResponse{
+		Fingerprint: fingerprint,
+		StatusCode:  http.StatusCreated,
+		Body:        []byte(`{"short_code":"abc123"}`),
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("key-1", `{"url":"https://example.com"}`))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, `{"short_code":"abc123"}`, rec.Body.String())
+}
+
+func TestIdempotency_MismatchedBodyReturns422(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).Return(&domain.IdempotentResponse{
+		Fingerprint: "other",
+		StatusCode:  http.StatusCreated,
+		Body:        []byte(`{}`),
+	}, nil)
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("key-1", `{"url":"https://other.com"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_MismatchedQueryReturns422(t *testing.T) {
+	var fingerprint string
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).
+		Run(func(_ context.Context, _ string, fp string) { fingerprint = fp }).
+		Return(nil, nil).Once()
+	store.EXPECT().Complete(mock.Anything, "ip:192.0.2.1:key-1", http.StatusCreated, mock.Anything).Return(nil).Once()
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+	req := idempotentRequest("key-1", `{"url":"https://example.com"}`)
+	req.URL.RawQuery = "mode=partial"
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).Return(&domain.IdempotentResponse{
+		Fingerprint: fingerprint,
+		StatusCode:  http.StatusCreated,
+		Body:        []byte(`{}`),
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("key-1", `{"url":"https://example.com"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_InFlightReturns409(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, fp string) (*domain.IdempotentResponse, error) {
+			return &domain.IdempotentResponse{Fingerprint: fp}, nil
+		})
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("key-1", `{"url":"https://example.com"}`))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:192.0.2.1:key-1", mock.Anything).Return(nil, nil)
+	store.EXPECT().Release(mock.Anything, "ip:192.0.2.1:key-1").Return(nil)
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusInternalServerError, &calls)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest("key-1", `{"url":"https://example.com"}`))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, idempotentRequest(strings.Repeat("k", 256), `{}`))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_KeysScopedPerAPIKey(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "7:key-1", mock.Anything).Return(nil, nil)
+	store.EXPECT().Complete(mock.Anything, "7:key-1", http.StatusCreated, mock.Anything).Return(nil)
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	req := idempotentRequest("key-1", `{"url":"https://example.com"}`)
+	req = req.WithContext(auth.NewContext(req.Context(), &domain.APIKey{ID: 7}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestIdempotency_AnonymousKeysScopedPerClientIP(t *testing.T) {
+	store := mocks.NewMockIdempotencyStore(t)
+	store.EXPECT().Reserve(mock.Anything, "ip:203.0.113.5:key-1", mock.Anything).Return(nil, nil)
+	store.EXPECT().Complete(mock.Anything, "ip:203.0.113.5:key-1", http.StatusCreated, mock.Anything).Return(nil)
+	store.EXPECT().Reserve(mock.Anything, "ip:198.51.100.8:key-1", mock.Anything).Return(nil, nil)
+	store.EXPECT().Complete(mock.Anything, "ip:198.51.100.8:key-1", http.StatusCreated, mock.Anything).Return(nil)
+
+	var calls int
+	e := newIdempotentEcho(t, store, http.StatusCreated, &calls)
+
+	for _, ip := range []string{"203.0.113.5", "198.51.100.8"} {
+		req := idempotentRequest("key-1", `{"url":"https://example.com"}`)
+		req.RemoteAddr = ip + ":41000"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	}
+	assert.Equal(t, 2, calls, "the same key from another client is a new request")
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyStore is an autogenerated mock type for the APIKeyStore type
+type MockAPIKeyStore struct {
+	mock.Mock
+}
+
+type MockAPIKeyStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyStore) EXPECT() *MockAPIKeyStore_Expecter {
+	return &MockAPIKeyStore_Expecter{mock: &_m.Mock}
+}
+
+// FindByHash provides a mock function with given fields: ctx, keyHash
+func (_m *MockAPIKeyStore) FindByHash(ctx context.Context, keyHash []byte) (*domain.APIKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 *domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (*domain.APIKey, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *domain.APIKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyStore_FindByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHash'
+type MockAPIKeyStore_FindByHash_Call struct {
+	*mock.Call
+}
+
+// FindByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyHash []byte
+func (_e *MockAPIKeyStore_Expecter) FindByHash(ctx interface{}, keyHash interface{}) *MockAPIKeyStore_FindByHash_Call {
+	return &MockAPIKeyStore_FindByHash_Call{Call: _e.mock.On("FindByHash", ctx, keyHash)}
+}
+
+func (_c *MockAPIKeyStore_FindByHash_Call) Run(run func(ctx context.Context, keyHash []byte)) *MockAPIKeyStore_FindByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyStore_FindByHash_Call) Return(_a0 *domain.APIKey, _a1 error) *MockAPIKeyStore_FindByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyStore_FindByHash_Call) RunAndReturn(run func(context.Context, []byte) (*domain.APIKey, error)) *MockAPIKeyStore_FindByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyStore creates a new instance of MockAPIKeyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyStore {
+	mock := &MockAPIKeyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,193 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIdempotencyStore is an autogenerated mock type for the IdempotencyStore type
+type MockIdempotencyStore struct {
+	mock.Mock
+}
+
+type MockIdempotencyStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyStore) EXPECT() *MockIdempotencyStore_Expecter {
+	return &MockIdempotencyStore_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, key, statusCode, body
+func (_m *MockIdempotencyStore) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	ret := _m.Called(ctx, key, statusCode, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyStore_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockIdempotencyStore_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - statusCode int
+//   - body []byte
+func (_e *MockIdempotencyStore_Expecter) Complete(ctx interface{}, key interface{}, statusCode interface{}, body interface{}) *MockIdempotencyStore_Complete_Call {
+	return &MockIdempotencyStore_Complete_Call{Call: _e.mock.On("Complete", ctx, key, statusCode, body)}
+}
+
+func (_c *MockIdempotencyStore_Complete_Call) Run(run func(ctx context.Context, key string, statusCode int, body []byte)) *MockIdempotencyStore_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Complete_Call) Return(_a0 error) *MockIdempotencyStore_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Complete_Call) RunAndReturn(run func(context.Context, string, int, []byte) error) *MockIdempotencyStore_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyStore) Release(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyStore_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockIdempotencyStore_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyStore_Expecter) Release(ctx interface{}, key interface{}) *MockIdempotencyStore_Release_Call {
+	return &MockIdempotencyStore_Release_Call{Call: _e.mock.On("Release", ctx, key)}
+}
+
+func (_c *MockIdempotencyStore_Release_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyStore_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Release_Call) Return(_a0 error) *MockIdempotencyStore_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Release_Call) RunAndReturn(run func(context.Context, string) error) *MockIdempotencyStore_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, key, fingerprint
+func (_m *MockIdempotencyStore) Reserve(ctx context.Context, key string, fingerprint string) (*domain.IdempotentResponse, error) {
+	ret := _m.Called(ctx, key, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 *domain.IdempotentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.IdempotentResponse, error)); ok {
+		return rf(ctx, key, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.IdempotentResponse); ok {
+		r0 = rf(ctx, key, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IdempotentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type MockIdempotencyStore_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - fingerprint string
+func (_e *MockIdempotencyStore_Expecter) Reserve(ctx interface{}, key interface{}, fingerprint interface{}) *MockIdempotencyStore_Reserve_Call {
+	return &MockIdempotencyStore_Reserve_Call{Call: _e.mock.On("Reserve", ctx, key, fingerprint)}
+}
+
+func (_c *MockIdempotencyStore_Reserve_Call) Run(run func(ctx context.Context, key string, fingerprint string)) *MockIdempotencyStore_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Reserve_Call) Return(_a0 *domain.IdempotentResponse, _a1 error) *MockIdempotencyStore_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_Reserve_Call) RunAndReturn(run func(context.Context, string, string) (*domain.IdempotentResponse, error)) *MockIdempotencyStore_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyStore creates a new instance of MockIdempotencyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
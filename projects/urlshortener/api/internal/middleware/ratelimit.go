@@ -4,12 +4,14 @@ import (
 	"crypto/subtle"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"golang.org/x/time/rate"
 
+	"urlshortener/internal/auth"
 	"urlshortener/internal/config"
 )
 
@@ -51,12 +53,10 @@ func RateLimit(cfg *config.RateLimitConfig, logger *slog.Logger) echo.Middleware
 			provided := c.Request().Header.Get(bypassHeader)
 			return subtle.ConstantTimeCompare([]byte(provided), secret) == 1
 		},
-		IdentifierExtractor: func(c echo.Context) (string, error) {
-			return c.RealIP(), nil
-		},
+		IdentifierExtractor: rateLimitIdentifier,
 		DenyHandler: func(c echo.Context, identifier string, err error) error {
 			logger.Warn("rate limit exceeded",
-				slog.String("ip", identifier),
+				slog.String("identifier", identifier),
 				slog.String("path", c.Path()),
 			)
 			c.Response().Header().Set("Retry-After", retryAfterHeader)
@@ -68,3 +68,13 @@ func RateLimit(cfg *config.RateLimitConfig, logger *slog.Logger) echo.Middleware
 		},
 	})
 }
+
+// rateLimitIdentifier buckets authenticated requests by API key, so clients
+// behind a shared NAT or proxy do not throttle each other. Anonymous requests
+// fall back to the client IP.
+func rateLimitIdentifier(c echo.Context) (string, error) {
+	if key := auth.FromContext(c.Request().Context()); key != nil {
+		return "key:" + strconv.FormatInt(key.ID, 10), nil
+	}
+	return c.RealIP(), nil
+}
@@ -6,13 +6,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"urlshortener/internal/auth"
 	"urlshortener/internal/config"
+	"urlshortener/internal/domain"
 	"urlshortener/internal/middleware"
 )
 
@@ -226,3 +229,40 @@ func TestRateLimit_BypassDisabledWhenSecretEmpty(t *testing.T) {
 
 	assert.Equal(t, http.StatusTooManyRequests, rec2.Code, "bypass should be disabled when secret is empty")
 }
+
+func TestRateLimit_APIKeysHaveSeparateLimits(t *testing.T) {
+	e := echo.New()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	cfg := &config.RateLimitConfig{
+		RPS:           0.1,
+		Burst:         1,
+		ExpireMinutes: 1,
+	}
+
+	// Stands in for Authenticate, which runs before RateLimit.
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, _ := strconv.ParseInt(c.Request().Header.Get("X-Key-ID"), 10, 64)
+			ctx := auth.NewContext(c.Request().Context(), &domain.APIKey{ID: id})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	e.Use(middleware.RateLimit(cfg, logger))
+	e.GET("/test", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	send := func(keyID string) int {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "192.168.1.9:12345" // shared NAT address
+		req.Header.Set("X-Key-ID", keyID)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("1"), "key 1 first request should succeed")
+	assert.Equal(t, http.StatusOK, send("2"), "key 2 shares the IP but not the limit")
+	assert.Equal(t, http.StatusTooManyRequests, send("1"), "key 1 second request should be limited")
+}
@@ -0,0 +1,68 @@
+package password
+
+import (
+	"sync"
+	"time"
+)
+
+// Successful attempts give their token back, so only failures count.
+type AttemptLimiter struct {
+	mu          sync.Mutex
+	perSecond   float64
+	burst       float64
+	expiresIn   time.Duration
+	lastCleanup time.Time
+	visitors    map[string]*attempts
+}
+
+type attempts struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+func NewAttemptLimiter(perMinute float64, burst int, expiresIn time.Duration) *AttemptLimiter {
+	return &AttemptLimiter{
+		perSecond: perMinute / 60,
+		burst:     float64(burst),
+		expiresIn: expiresIn,
+		visitors:  make(map[string]*attempts),
+	}
+}
+
+// Reserving up front keeps concurrent guesses within the burst.
+func (l *AttemptLimiter) Reserve(key string) (refund func(), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastCleanup) > l.expiresIn {
+		l.cleanup(now)
+	}
+
+	a, found := l.visitors[key]
+	if !found {
+		a = &attempts{tokens: l.burst, lastSeen: now}
+		l.visitors[key] = a
+	}
+	a.tokens = min(l.burst, a.tokens+now.Sub(a.lastSeen).Seconds()*l.perSecond)
+	a.lastSeen = now
+
+	if a.tokens < 1 {
+		return nil, false
+	}
+	a.tokens--
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		a.tokens = min(l.burst, a.tokens+1)
+	}, true
+}
+
+func (l *AttemptLimiter) cleanup(now time.Time) {
+	for key, a := range l.visitors {
+		if now.Sub(a.lastSeen) > l.expiresIn {
+			delete(l.visitors, key)
+		}
+	}
+	l.lastCleanup = now
+}
@@ -0,0 +1,80 @@
+package password_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/password"
+)
+
+func TestAttemptLimiter_BlocksAfterBurst(t *testing.T) {
+	l := password.NewAttemptLimiter(0.001, 3, time.Minute)
+
+	for range 3 {
+		_, ok := l.Reserve("abc123")
+		assert.True(t, ok)
+	}
+
+	_, ok := l.Reserve("abc123")
+	assert.False(t, ok)
+}
+
+func TestAttemptLimiter_RefundKeepsAttempts(t *testing.T) {
+	l := password.NewAttemptLimiter(0.001, 1, time.Minute)
+
+	for range 5 {
+		refund, ok := l.Reserve("abc123")
+		require.True(t, ok)
+		refund()
+	}
+
+	_, ok := l.Reserve("abc123")
+	assert.True(t, ok)
+	_, ok = l.Reserve("abc123")
+	assert.False(t, ok)
+}
+
+// Concurrent guesses all reserve before any of them is checked, so they can
+// never get past the burst together.
+func TestAttemptLimiter_ConcurrentGuesses(t *testing.T) {
+	l := password.NewAttemptLimiter(0.001, 3, time.Minute)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			if _, ok := l.Reserve("abc123"); ok {
+				allowed.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), allowed.Load())
+}
+
+func TestAttemptLimiter_PerCode(t *testing.T) {
+	l := password.NewAttemptLimiter(0.001, 1, time.Minute)
+
+	l.Reserve("abc123")
+
+	_, ok := l.Reserve("abc123")
+	assert.False(t, ok)
+	_, ok = l.Reserve("xyz789")
+	assert.True(t, ok)
+}
+
+func TestAttemptLimiter_Refills(t *testing.T) {
+	l := password.NewAttemptLimiter(60*1000, 1, time.Minute) // one token per millisecond
+
+	l.Reserve("abc123")
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := l.Reserve("abc123")
+	assert.True(t, ok)
+}
@@ -0,0 +1,69 @@
+package password
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Hashes are encoded as "pbkdf2-sha256$<iterations>$<salt>$<key>" so the
+// parameters can be raised later without invalidating stored hashes.
+const (
+	scheme     = "pbkdf2-sha256"
+	iterations = 100_000
+	saltLength = 16
+	keyLength  = 32
+)
+
+var encoding = base64.RawStdEncoding
+
+func Hash(password string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, keyLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	return strings.Join([]string{
+		scheme,
+		strconv.Itoa(iterations),
+		encoding.EncodeToString(salt),
+		encoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// Verify reports whether password matches hash. Malformed hashes never match.
+func Verify(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != scheme {
+		return false
+	}
+
+	iter, err := strconv.Atoi(parts[1])
+	if err != nil || iter <= 0 {
+		return false
+	}
+	salt, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := encoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	got, err := pbkdf2.Key(sha256.New, password, salt, iter, len(want))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
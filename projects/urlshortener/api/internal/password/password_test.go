@@ -0,0 +1,45 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/password"
+)
+
+func TestHashAndVerify(t *testing.T) {
+	hash, err := password.Hash("correct horse")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(hash, "pbkdf2-sha256$"))
+	assert.NotContains(t, hash, "correct horse")
+	assert.True(t, password.Verify(hash, "correct horse"))
+	assert.False(t, password.Verify(hash, "battery staple"))
+}
+
+func TestHash_Salted(t *testing.T) {
+	a, err := password.Hash("secret")
+	require.NoError(t, err)
+	b, err := password.Hash("secret")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+}
+
+func TestVerify_MalformedHash(t *testing.T) {
+	tests := []string{
+		"",
+		"secret",
+		"md5$1$c2FsdA$a2V5",
+		"pbkdf2-sha256$abc$c2FsdA$a2V5",
+		"pbkdf2-sha256$0$c2FsdA$a2V5",
+		"pbkdf2-sha256$1000$!!!$a2V5",
+	}
+
+	for _, hash := range tests {
+		assert.False(t, password.Verify(hash, "secret"), hash)
+	}
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDestinationSource is an autogenerated mock type for the DestinationSource type
+type MockDestinationSource struct {
+	mock.Mock
+}
+
+type MockDestinationSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDestinationSource) EXPECT() *MockDestinationSource_Expecter {
+	return &MockDestinationSource_Expecter{mock: &_m.Mock}
+}
+
+// FailoverDestinations provides a mock function with given fields: ctx
+func (_m *MockDestinationSource) FailoverDestinations(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailoverDestinations")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDestinationSource_FailoverDestinations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailoverDestinations'
+type MockDestinationSource_FailoverDestinations_Call struct {
+	*mock.Call
+}
+
+// FailoverDestinations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDestinationSource_Expecter) FailoverDestinations(ctx interface{}) *MockDestinationSource_FailoverDestinations_Call {
+	return &MockDestinationSource_FailoverDestinations_Call{Call: _e.mock.On("FailoverDestinations", ctx)}
+}
+
+func (_c *MockDestinationSource_FailoverDestinations_Call) Run(run func(ctx context.Context)) *MockDestinationSource_FailoverDestinations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDestinationSource_FailoverDestinations_Call) Return(_a0 []string, _a1 error) *MockDestinationSource_FailoverDestinations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDestinationSource_FailoverDestinations_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockDestinationSource_FailoverDestinations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDestinationSource creates a new instance of MockDestinationSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDestinationSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDestinationSource {
+	mock := &MockDestinationSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
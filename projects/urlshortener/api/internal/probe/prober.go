@@ -0,0 +1,186 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"urlshortener/internal/config"
+	"urlshortener/internal/validation"
+)
+
+const userAgent = "urlshortener-probe/1.0"
+
+type DestinationSource interface {
+	FailoverDestinations(ctx context.Context) ([]string, error)
+}
+
+type BusinessRecorder interface {
+	RecordBusiness(t time.Time, name string, value float64, labelsJSON []byte)
+}
+
+// Only failed requests and 5xx answers are unhealthy; redirects and 405 are not.
+type Prober struct {
+	source   DestinationSource
+	recorder BusinessRecorder
+	logger   *slog.Logger
+	cfg      *config.ProbeConfig
+	client   *http.Client
+
+	mu        sync.RWMutex
+	unhealthy map[string]bool
+
+	wg           sync.WaitGroup
+	shutdownOnce sync.Once
+	cancel       context.CancelFunc
+}
+
+func NewProber(source DestinationSource, recorder BusinessRecorder, cfg *config.ProbeConfig, dialer *net.Dialer, logger *slog.Logger) *Prober {
+	return &Prober{
+		source:   source,
+		recorder: recorder,
+		logger:   logger,
+		cfg:      cfg,
+		client: &http.Client{
+			Transport: validation.NewTransport(dialer),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		unhealthy: make(map[string]bool),
+		cancel:    func() {},
+	}
+}
+
+func (p *Prober) Healthy(url string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.unhealthy[url]
+}
+
+func (p *Prober) Start(ctx context.Context) {
+	if !p.cfg.Enabled {
+		p.logger.Info("destination probing disabled")
+		return
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+	interval := time.Duration(p.cfg.IntervalSeconds) * time.Second
+
+	p.wg.Add(1)
+	go p.run(ctx, interval)
+
+	p.logger.Info("destination prober started",
+		slog.Int("interval_s", p.cfg.IntervalSeconds),
+		slog.Int("concurrency", p.cfg.Concurrency))
+}
+
+func (p *Prober) Close() {
+	p.shutdownOnce.Do(func() {
+		p.cancel()
+		p.wg.Wait()
+	})
+}
+
+func (p *Prober) run(ctx context.Context, interval time.Duration) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.ProbeAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Prober) ProbeAll(ctx context.Context) {
+	urls, err := p.source.FailoverDestinations(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			p.logger.Error("failed to list destinations to probe", slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	healthy := make([]bool, len(urls))
+	sem := make(chan struct{}, max(1, p.cfg.Concurrency))
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			healthy[i] = p.probe(ctx, url)
+		}()
+	}
+	wg.Wait()
+
+	// Probes cut short by shutdown say nothing about the destinations.
+	if ctx.Err() != nil {
+		return
+	}
+
+	unhealthy := make(map[string]bool)
+	for i, url := range urls {
+		if !healthy[i] {
+			unhealthy[url] = true
+		}
+	}
+
+	p.mu.Lock()
+	previous := p.unhealthy
+	p.unhealthy = unhealthy
+	p.mu.Unlock()
+
+	for url := range unhealthy {
+		if !previous[url] {
+			p.logger.Warn("destination unhealthy", slog.String("url", url))
+		}
+	}
+	for url := range previous {
+		if !unhealthy[url] {
+			p.logger.Info("destination recovered", slog.String("url", url))
+		}
+	}
+}
+
+func (p *Prober) probe(ctx context.Context, url string) bool {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(p.cfg.TimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	status := 0
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err == nil {
+		req.Header.Set("User-Agent", userAgent)
+		var resp *http.Response
+		resp, err = p.client.Do(req)
+		if err == nil {
+			status = resp.StatusCode
+			_ = resp.Body.Close()
+		}
+	}
+	healthy := err == nil && status < http.StatusInternalServerError
+
+	labels := fmt.Appendf(nil, `{"url":%q,"status_code":%d,"healthy":%t}`, url, status, healthy)
+	p.recorder.RecordBusiness(time.Now(), "destination_probe", float64(time.Since(start).Milliseconds()), labels)
+
+	return healthy
+}
@@ -0,0 +1,189 @@
+package probe_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/config"
+	"urlshortener/internal/probe"
+	"urlshortener/internal/probe/mocks"
+)
+
+func newTestProber(t *testing.T, cfg *config.ProbeConfig, urls []string) (*probe.Prober, *mocks.MockBusinessRecorder) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	source := mocks.NewMockDestinationSource(t)
+	source.EXPECT().FailoverDestinations(mock.Anything).Return(urls, nil).Maybe()
+	recorder := mocks.NewMockBusinessRecorder(t)
+	return probe.NewProber(source, recorder, cfg, &net.Dialer{}, logger), recorder
+}
+
+func statusServer(t *testing.T, status int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProbeAll_ClassifiesDestinations(t *testing.T) {
+	ok := statusServer(t, http.StatusOK)
+	redirect := statusServer(t, http.StatusFound)
+	noHead := statusServer(t, http.StatusMethodNotAllowed)
+	failing := statusServer(t, http.StatusServiceUnavailable)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	urls := []string{ok.URL, redirect.URL, noHead.URL, failing.URL, down.URL}
+	prober, recorder := newTestProber(t, &config.ProbeConfig{TimeoutMs: 1000, Concurrency: 2}, urls)
+
+	var mu sync.Mutex
+	probed := make(map[string]int)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "destination_probe", mock.Anything, mock.Anything).
+		Run(func(_ time.Time, _ string, _ float64, labels []byte) {
+			mu.Lock()
+			probed[string(labels)]++
+			mu.Unlock()
+		}).Return()
+
+	prober.ProbeAll(context.Background())
+
+	assert.True(t, prober.Healthy(ok.URL))
+	assert.True(t, prober.Healthy(redirect.URL))
+	assert.True(t, prober.Healthy(noHead.URL))
+	assert.False(t, prober.Healthy(failing.URL))
+	assert.False(t, prober.Healthy(down.URL))
+	assert.True(t, prober.Healthy("https://never-probed.example.com"))
+
+	assert.Len(t, probed, len(urls))
+	assert.Equal(t, 1, probed[`{"url":"`+failing.URL+`","status_code":503,"healthy":false}`])
+	assert.Equal(t, 1, probed[`{"url":"`+down.URL+`","status_code":0,"healthy":false}`])
+	assert.Equal(t, 1, probed[`{"url":"`+redirect.URL+`","status_code":302,"healthy":true}`])
+}
+
+func TestProbeAll_Recovers(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusBadGateway)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	prober, recorder := newTestProber(t, &config.ProbeConfig{TimeoutMs: 1000, Concurrency: 1}, []string{srv.URL})
+	recorder.EXPECT().RecordBusiness(mock.Anything, "destination_probe", mock.Anything, mock.Anything).Return()
+
+	prober.ProbeAll(context.Background())
+	assert.False(t, prober.Healthy(srv.URL))
+
+	status.Store(http.StatusOK)
+	prober.ProbeAll(context.Background())
+	assert.True(t, prober.Healthy(srv.URL))
+}
+
+func TestProbeAll_TimeoutIsUnhealthy(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	prober, recorder := newTestProber(t, &config.ProbeConfig{TimeoutMs: 50, Concurrency: 1}, []string{slow.URL})
+	recorder.EXPECT().RecordBusiness(mock.Anything, "destination_probe", mock.Anything, mock.Anything).Return()
+
+	prober.ProbeAll(context.Background())
+	assert.False(t, prober.Healthy(slow.URL))
+}
+
+func TestProbeAll_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 12)
+	for i := range urls {
+		urls[i] = srv.URL + "/" + string(rune('a'+i))
+	}
+	prober, recorder := newTestProber(t, &config.ProbeConfig{TimeoutMs: 1000, Concurrency: 3}, urls)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "destination_probe", mock.Anything, mock.Anything).Return().Times(len(urls))
+
+	prober.ProbeAll(context.Background())
+
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Positive(t, peak.Load())
+}
+
+func TestProbeAll_CanceledRoundKeepsState(t *testing.T) {
+	failing := statusServer(t, http.StatusInternalServerError)
+	prober, recorder := newTestProber(t, &config.ProbeConfig{TimeoutMs: 1000, Concurrency: 1}, []string{failing.URL})
+	recorder.EXPECT().RecordBusiness(mock.Anything, "destination_probe", mock.Anything, mock.Anything).Return().Maybe()
+
+	prober.ProbeAll(context.Background())
+	require.False(t, prober.Healthy(failing.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	prober.ProbeAll(ctx)
+	assert.False(t, prober.Healthy(failing.URL))
+}
+
+func TestStartAndClose(t *testing.T) {
+	var probes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		probes.Add(1)
+	}))
+	defer srv.Close()
+
+	prober, recorder := newTestProber(t, &config.ProbeConfig{Enabled: true, IntervalSeconds: 60, TimeoutMs: 1000, Concurrency: 1}, []string{srv.URL})
+	recorder.EXPECT().RecordBusiness(mock.Anything, "destination_probe", mock.Anything, mock.Anything).Return()
+
+	prober.Start(context.Background())
+	// The first round runs right away rather than after one interval.
+	assert.Eventually(t, func() bool { return probes.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		prober.Close()
+		prober.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return")
+	}
+}
+
+func TestStart_Disabled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	prober := probe.NewProber(mocks.NewMockDestinationSource(t), mocks.NewMockBusinessRecorder(t), &config.ProbeConfig{}, &net.Dialer{}, logger)
+
+	prober.Start(context.Background())
+	prober.Close()
+	assert.True(t, prober.Healthy("https://example.com"))
+}
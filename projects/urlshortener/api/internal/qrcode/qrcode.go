@@ -0,0 +1,75 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+type Level int
+
+const (
+	Low      Level = iota // ~7% of codewords can be restored
+	Medium                // ~15%
+	Quartile              // ~25%
+	High                  // ~30%
+)
+
+var (
+	ErrEmpty        = errors.New("no data to encode")
+	ErrTooLong      = errors.New("data too long for a qr code")
+	ErrInvalidLevel = errors.New("invalid error correction level")
+)
+
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "L":
+		return Low, nil
+	case "M":
+		return Medium, nil
+	case "Q":
+		return Quartile, nil
+	case "H":
+		return High, nil
+	}
+	return 0, ErrInvalidLevel
+}
+
+// The library names levels one step higher than the spec from Q on.
+var recoveryLevels = [...]goqrcode.RecoveryLevel{
+	Low:      goqrcode.Low,
+	Medium:   goqrcode.Medium,
+	Quartile: goqrcode.High,
+	High:     goqrcode.Highest,
+}
+
+type Code struct {
+	size    int
+	modules [][]bool // dark modules, indexed by row and column
+}
+
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < Low || level > High {
+		return nil, ErrInvalidLevel
+	}
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	q, err := goqrcode.New(string(data), recoveryLevels[level])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTooLong, err)
+	}
+	q.DisableBorder = true
+	modules := q.Bitmap()
+	return &Code{size: len(modules), modules: modules}, nil
+}
+
+func (c *Code) Size() int {
+	return c.size
+}
+
+func (c *Code) Dark(x, y int) bool {
+	return x >= 0 && x < c.size && y >= 0 && y < c.size && c.modules[y][x]
+}
@@ -0,0 +1,99 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_Version(t *testing.T) {
+	c, err := Encode(bytes.Repeat([]byte("a"), 17), Low)
+	require.NoError(t, err)
+	assert.Equal(t, 21, c.Size())
+
+	c, err = Encode(bytes.Repeat([]byte("a"), 18), Low)
+	require.NoError(t, err)
+	assert.Equal(t, 25, c.Size())
+
+	_, err = Encode(bytes.Repeat([]byte("a"), 1274), High)
+	assert.ErrorIs(t, err, ErrTooLong)
+
+	_, err = Encode([]byte("a"), Level(7))
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+
+	_, err = Encode(nil, Low)
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestEncode_Levels(t *testing.T) {
+	// 64 bytes fit version 4 at L, 5 at M, 6 at Q and 7 at H.
+	data := bytes.Repeat([]byte("a"), 64)
+	for level, size := range map[Level]int{Low: 33, Medium: 37, Quartile: 41, High: 45} {
+		c, err := Encode(data, level)
+		require.NoError(t, err)
+		assert.Equal(t, size, c.Size(), "level %d", level)
+	}
+}
+
+// The code starts at the top left finder pattern, without a quiet zone.
+func TestEncode_NoBorder(t *testing.T) {
+	c, err := Encode([]byte("http://localhost:8080/abc123"), Medium)
+	require.NoError(t, err)
+
+	for i := range 7 {
+		assert.True(t, c.Dark(i, 0), "finder pattern top edge %d", i)
+		assert.True(t, c.Dark(0, i), "finder pattern left edge %d", i)
+	}
+	assert.False(t, c.Dark(7, 0), "separator")
+	assert.False(t, c.Dark(-1, 0))
+	assert.False(t, c.Dark(c.Size(), 0))
+}
+
+func TestPNG(t *testing.T) {
+	c, err := Encode([]byte("http://localhost:8080/abc123"), Medium)
+	require.NoError(t, err)
+
+	out, err := c.PNG(256, 4)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(out))
+	require.NoError(t, err)
+	modules := c.Size() + 8
+	scale := 256 / modules
+	assert.Equal(t, scale*modules, img.Bounds().Dx())
+
+	// The quiet zone is light and the top left finder pattern dark.
+	r, _, _, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	r, _, _, _ = img.At(4*scale, 4*scale).RGBA()
+	assert.Zero(t, r)
+}
+
+func TestPNG_TooSmall(t *testing.T) {
+	c, err := Encode([]byte("http://localhost:8080/abc123"), Medium)
+	require.NoError(t, err)
+
+	out, err := c.PNG(10, 0)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, c.Size(), img.Bounds().Dx(), "one pixel per module at least")
+}
+
+func TestSVG(t *testing.T) {
+	c, err := Encode([]byte("http://localhost:8080/abc123"), Medium)
+	require.NoError(t, err)
+
+	out := string(c.SVG(256, 2))
+	modules := c.Size() + 4
+	assert.True(t, strings.HasPrefix(out, "<svg "))
+	assert.Contains(t, out, fmt.Sprintf(`width="%d" viewBox="0 0 %d %d"`, 256/modules*modules, modules, modules))
+	assert.Contains(t, out, "M2 2h7v1h-7z", "top row of the top left finder pattern")
+	assert.True(t, strings.HasSuffix(out, "</svg>"))
+}
@@ -0,0 +1,66 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// scale returns the pixels per module for an image of about size pixels with
+// margin light modules on each side. Codes that do not fit get one pixel per
+// module, so the image can be larger than requested but never unreadable.
+func (c *Code) scale(size, margin int) (scale, total int) {
+	modules := c.size + 2*margin
+	scale = max(1, size/modules)
+	return scale, scale * modules
+}
+
+// PNG renders the code as a black on white PNG with margin light modules around
+// it, as large as fits in size pixels square.
+func (c *Code) PNG(size, margin int) ([]byte, error) {
+	scale, total := c.scale(size, margin)
+	img := image.NewPaletted(image.Rect(0, 0, total, total), color.Palette{color.White, color.Black})
+	for y := range total {
+		for x := range total {
+			if c.Dark(x/scale-margin, y/scale-margin) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the code as an SVG document with the same geometry as PNG. The
+// dark modules form a single path, merged into horizontal runs.
+func (c *Code) SVG(size, margin int) []byte {
+	_, total := c.scale(size, margin)
+	modules := c.size + 2*margin
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" height="%d" width="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		total, total, modules, modules)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="#FFFFFF"/><path fill="#000000" d="`)
+	for y := range c.size {
+		for x := 0; x < c.size; x++ {
+			if !c.Dark(x, y) {
+				continue
+			}
+			run := 1
+			for c.Dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", x+margin, y+margin, run, run)
+			x += run - 1
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes()
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"urlshortener/internal/domain"
+)
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+type APIKeyRow struct {
+	Name    string
+	Prefix  string
+	KeyHash []byte
+	Scopes  []string
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, k APIKeyRow) (*domain.APIKey, error) {
+	key := domain.APIKey{Name: k.Name, Prefix: k.Prefix, Scopes: k.Scopes}
+	err := r.pool.QueryRow(ctx,
+		"INSERT INTO api_keys (name, prefix, key_hash, scopes) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		k.Name, k.Prefix, k.KeyHash, k.Scopes,
+	).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return &key, nil
+}
+
+// FindByHash returns the live key with the given hash. Returns pgx.ErrNoRows
+// for unknown and revoked keys.
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash []byte) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.pool.QueryRow(ctx,
+		"SELECT id, name, prefix, scopes, created_at FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL",
+		keyHash,
+	).Scan(&key.ID, &key.Name, &key.Prefix, &key.Scopes, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	rows, err := r.pool.Query(ctx,
+		"SELECT id, name, prefix, scopes, created_at FROM api_keys WHERE revoked_at IS NULL ORDER BY id",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []domain.APIKey{}
+	for rows.Next() {
+		var key domain.APIKey
+		if err := rows.Scan(&key.ID, &key.Name, &key.Prefix, &key.Scopes, &key.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Revoke disables a key. Its links keep their owner. Returns pgx.ErrNoRows if
+// no live key matches.
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) error {
+	tag, err := r.pool.Exec(ctx,
+		"UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"urlshortener/internal/domain"
+)
+
+// Protected links are recorded without their destinations.
+func auditSnapshot(row string) string {
+	return `(to_jsonb(` + row + `) - 'password_hash' - 'deleted_at' - ` + auditDestinations(row) + `)
+		|| jsonb_build_object('password_protected', ` + row + `.password_hash IS NOT NULL)`
+}
+
+func auditDestinations(row string) string {
+	return `CASE WHEN ` + row + `.password_hash IS NULL THEN ARRAY[]::text[]
+		ELSE ARRAY['original_url', 'rules', 'variants', 'backups'] END`
+}
+
+func auditActiveAction(param string) string {
+	return `CASE WHEN ` + param + `::boolean THEN 'reactivate' ELSE 'deactivate' END`
+}
+
+// Client IPs are only returned for changes made by owner.
+func (r *URLRepository) History(ctx context.Context, shortCode string, owner *int64, after int64, limit int) ([]domain.AuditEntry, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT a.id, a.action, a.actor_key_id, CASE WHEN a.actor_key_id = $2 THEN COALESCE(a.actor_ip, '') ELSE '' END,
+			a.created_at, a.old_value, a.new_value
+		FROM url_audit a JOIN urls u ON u.short_code = a.short_code
+		WHERE a.short_code = $1 AND u.owner_key_id = $2 AND a.id > $3
+		ORDER BY a.id LIMIT $4`,
+		shortCode, owner, after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := rows.Scan(&e.ID, &e.Action, &e.ActorKeyID, &e.ActorIP, &e.CreatedAt, &e.OldValue, &e.NewValue); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
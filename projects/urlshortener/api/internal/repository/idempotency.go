@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"urlshortener/internal/domain"
+)
+
+// Frees keys claimed by a request whose process died mid-request.
+const idempotencyLockTimeout = time.Minute
+
+type IdempotencyRepository struct {
+	pool *pgxpool.Pool
+	ttl  time.Duration
+}
+
+func NewIdempotencyRepository(pool *pgxpool.Pool, ttl time.Duration) *IdempotencyRepository {
+	return &IdempotencyRepository{pool: pool, ttl: ttl}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key, fingerprint string) (*domain.IdempotentResponse, error) {
+	now := time.Now()
+	var claimed string
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO idempotency_keys (key, fingerprint, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, response = NULL, created_at = EXCLUDED.created_at
+			WHERE idempotency_keys.created_at < $4
+				OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < $5)
+		RETURNING key`,
+		key, fingerprint, now, now.Add(-r.ttl), now.Add(-idempotencyLockTimeout),
+	).Scan(&claimed)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	rec := domain.IdempotentResponse{}
+	var status *int
+	err = r.pool.QueryRow(ctx,
+		"SELECT fingerprint, status_code, response FROM idempotency_keys WHERE key = $1",
+		key,
+	).Scan(&rec.Fingerprint, &status, &rec.Body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Released between the two statements; report it as still in flight
+		// so the client retries instead of racing the releasing request.
+		return &domain.IdempotentResponse{Fingerprint: fingerprint}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if status != nil {
+		rec.StatusCode = *status
+	}
+	return &rec, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	_, err := r.pool.Exec(ctx,
+		"UPDATE idempotency_keys SET status_code = $2, response = $3 WHERE key = $1",
+		key, statusCode, body,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	_, err := r.pool.Exec(ctx,
+		"DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL",
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := r.pool.Exec(ctx,
+		"DELETE FROM idempotency_keys WHERE created_at < $1",
+		time.Now().Add(-r.ttl),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
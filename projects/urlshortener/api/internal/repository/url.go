@@ -2,15 +2,25 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"urlshortener/internal/config"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/routing"
 )
 
+const uniqueViolation = "23505"
+
+var ErrDuplicateShortCode = errors.New("short code already exists")
+
 type URLRepository struct {
 	pool *pgxpool.Pool
 }
@@ -57,27 +67,342 @@ func (r *URLRepository) NextID(ctx context.Context) (uint, error) {
 	return id, nil
 }
 
-func (r *URLRepository) Create(ctx context.Context, shortCode, originalURL string) error {
-	_, err := r.pool.Exec(ctx,
-		"INSERT INTO urls (short_code, original_url, created_at) VALUES ($1, $2, NOW())",
-		shortCode, originalURL,
+func (r *URLRepository) Create(ctx context.Context, u URLRow, actor domain.Actor) error {
+	rules, err := encodeRules(u.Rules)
+	if err != nil {
+		return err
+	}
+	variants, err := encodeList(u.Variants)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx,
+		`WITH created AS (
+			INSERT INTO urls (short_code, original_url, created_at, expires_at, password_hash, owner_key_id, redirect_status,
+				query_policy, utm, rules, variants, sticky, backups, tags, campaign, active_from, prelaunch_url)
+			VALUES ($1, $2, NOW(), $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''),
+				$15, NULLIF($16, ''))
+			RETURNING *
+		)
+		INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, new_value)
+		SELECT short_code, 'create', $17, NULLIF($18, ''), `+auditSnapshot("created")+` FROM created`,
+		u.ShortCode, u.OriginalURL, u.ExpiresAt, u.PasswordHash, u.OwnerKeyID, u.RedirectStatus,
+		u.QueryPolicy, encodeUTM(u.UTM), rules, variants, u.Sticky, u.Backups, u.Tags, u.Campaign,
+		u.ActiveFrom, u.PrelaunchURL, actor.KeyID, actor.IP,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateShortCode
+		}
 		return fmt.Errorf("failed to create url: %w", err)
 	}
 	return nil
 }
 
-func (r *URLRepository) FindByShortCode(ctx context.Context, shortCode string) (string, error) {
-	var originalURL string
-	err := r.pool.QueryRow(ctx,
-		"SELECT original_url FROM urls WHERE short_code = $1",
+const urlColumns = `short_code, original_url, created_at, expires_at, active, COALESCE(password_hash, ''),
+	owner_key_id, redirect_status, query_policy, COALESCE(utm, ''), rules, variants, sticky, backups,
+	tags, COALESCE(campaign, ''), active_from, COALESCE(prelaunch_url, '')`
+
+func scanURL(row pgx.Row) (*domain.URL, error) {
+	var (
+		u             domain.URL
+		utm           string
+		rules, splits []byte
+	)
+	err := row.Scan(&u.ShortCode, &u.OriginalURL, &u.CreatedAt, &u.ExpiresAt, &u.Active, &u.PasswordHash,
+		&u.OwnerKeyID, &u.RedirectStatus, &u.QueryPolicy, &utm, &rules, &splits, &u.Sticky, &u.Backups,
+		&u.Tags, &u.Campaign, &u.ActiveFrom, &u.PrelaunchURL)
+	if err != nil {
+		return nil, err
+	}
+
+	u.UTM = decodeUTM(utm)
+	if u.Rules, err = decodeRules(rules); err != nil {
+		return nil, err
+	}
+	if splits != nil {
+		if err := json.Unmarshal(splits, &u.Variants); err != nil {
+			return nil, fmt.Errorf("failed to decode variants: %w", err)
+		}
+	}
+	return &u, nil
+}
+
+func (r *URLRepository) FindByShortCode(ctx context.Context, shortCode string) (*domain.URL, error) {
+	return scanURL(r.pool.QueryRow(ctx,
+		`SELECT `+urlColumns+` FROM urls WHERE short_code = $1 AND deleted_at IS NULL`,
 		shortCode,
-	).Scan(&originalURL)
+	))
+}
+
+func (r *URLRepository) FindByShortCodes(ctx context.Context, shortCodes []string) ([]*domain.URL, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+urlColumns+` FROM urls WHERE short_code = ANY($1) AND deleted_at IS NULL`,
+		shortCodes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find urls: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []*domain.URL
+	for rows.Next() {
+		u, err := scanURL(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan url: %w", err)
+		}
+		urls = append(urls, u)
+	}
+	return urls, rows.Err()
+}
+
+// Deleted links keep their rows, so their codes count as taken.
+func (r *URLRepository) TakenShortCodes(ctx context.Context, shortCodes []string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT short_code FROM urls WHERE short_code = ANY($1)`, shortCodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find short codes: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *URLRepository) FindByOriginalURLs(ctx context.Context, urls []string, owner *int64) (map[string]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT ON (original_url) original_url, short_code FROM urls
+		WHERE original_url = ANY($1) AND owner_key_id IS NOT DISTINCT FROM $2
+			AND deleted_at IS NULL AND active AND expires_at IS NULL AND password_hash IS NULL
+			AND redirect_status = 302 AND query_policy = 'drop' AND utm IS NULL AND rules IS NULL
+			AND variants IS NULL AND backups IS NULL AND tags IS NULL AND campaign IS NULL
+			AND active_from IS NULL
+		ORDER BY original_url, created_at`,
+		urls, owner,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find urls: %w", err)
+	}
+	defer rows.Close()
+
+	codes := make(map[string]string)
+	for rows.Next() {
+		var originalURL, shortCode string
+		if err := rows.Scan(&originalURL, &shortCode); err != nil {
+			return nil, fmt.Errorf("failed to scan url: %w", err)
+		}
+		codes[originalURL] = shortCode
+	}
+	return codes, rows.Err()
+}
+
+func (r *URLRepository) FailoverDestinations(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT unnest(array_prepend(original_url, backups)) FROM urls
+		WHERE backups IS NOT NULL AND deleted_at IS NULL AND active`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find failover destinations: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// $1 is the owner, $2 the tag and $3 the campaign; empty ones match every link.
+const linkFilter = `deleted_at IS NULL AND (owner_key_id IS NULL OR owner_key_id = $1) AND ` + tagFilter
+
+const ownedLinkFilter = `deleted_at IS NULL AND owner_key_id = $1 AND ` + tagFilter
+
+const tagFilter = `($2 = '' OR tags @> ARRAY[$2]) AND ($3 = '' OR campaign = $3)`
+
+func (r *URLRepository) List(ctx context.Context, filter domain.LinkFilter, owner *int64, after string, limit int) ([]*domain.URL, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+urlColumns+` FROM urls
+		WHERE `+linkFilter+` AND short_code > $4
+		ORDER BY short_code LIMIT $5`,
+		owner, filter.Tag, filter.Campaign, after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []*domain.URL
+	for rows.Next() {
+		u, err := scanURL(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan url: %w", err)
+		}
+		urls = append(urls, u)
+	}
+	return urls, rows.Err()
+}
+
+const exportFetch = `FETCH 1000 FROM export_cursor`
+
+// A repeatable read cursor keeps a long export consistent with one fetch in memory.
+func (r *URLRepository) Export(ctx context.Context, owner *int64, after string, fn func(*domain.URL) error) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin export: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`DECLARE export_cursor NO SCROLL CURSOR FOR
+		SELECT `+urlColumns+` FROM urls
+		WHERE deleted_at IS NULL AND (owner_key_id IS NULL OR owner_key_id = $1) AND short_code > $2
+		ORDER BY short_code`,
+		owner, after,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+
+	for {
+		rows, err := tx.Query(ctx, exportFetch)
+		if err != nil {
+			return fmt.Errorf("failed to fetch urls: %w", err)
+		}
+		fetched, err := exportRows(rows, fn)
+		if err != nil {
+			return err
+		}
+		if fetched == 0 {
+			return nil
+		}
+	}
+}
+
+func exportRows(rows pgx.Rows, fn func(*domain.URL) error) (int, error) {
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		u, err := scanURL(rows)
+		if err != nil {
+			return n, fmt.Errorf("failed to scan url: %w", err)
+		}
+		if err := fn(u); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+func (r *URLRepository) SetActiveByFilter(ctx context.Context, filter domain.LinkFilter, active bool, owner *int64, actor domain.Actor) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`WITH changed AS (
+			UPDATE urls SET active = $4
+			WHERE `+ownedLinkFilter+` AND active <> $4
+			RETURNING short_code
+		), audit AS (
+			INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, old_value, new_value)
+			SELECT short_code, `+auditActiveAction("$4")+`, $5, NULLIF($6, ''),
+				jsonb_build_object('active', NOT $4::boolean), jsonb_build_object('active', $4::boolean)
+			FROM changed
+		)
+		SELECT short_code FROM changed`,
+		owner, filter.Tag, filter.Campaign, active, actor.KeyID, actor.IP,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update urls: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *URLRepository) DeleteByFilter(ctx context.Context, filter domain.LinkFilter, owner *int64, actor domain.Actor) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`WITH old AS (
+			SELECT * FROM urls WHERE `+ownedLinkFilter+` FOR UPDATE
+		), deleted AS (
+			UPDATE urls SET deleted_at = NOW(), active = FALSE
+			FROM old WHERE urls.short_code = old.short_code
+			RETURNING urls.short_code
+		), audit AS (
+			INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, old_value)
+			SELECT short_code, 'delete', $4, NULLIF($5, ''), `+auditSnapshot("old")+`
+			FROM old JOIN deleted USING (short_code)
+		)
+		SELECT short_code FROM deleted`,
+		owner, filter.Tag, filter.Campaign, actor.KeyID, actor.IP,
+	)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to delete urls: %w", err)
 	}
-	return originalURL, nil
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// Anonymous links have no owner, so they can never be changed.
+func (r *URLRepository) UpdateOriginalURL(ctx context.Context, shortCode, originalURL string, owner *int64, actor domain.Actor) (*domain.URL, error) {
+	return scanURL(r.pool.QueryRow(ctx,
+		`WITH old AS (
+			SELECT short_code, original_url, password_hash FROM urls
+			WHERE short_code = $1 AND deleted_at IS NULL AND owner_key_id = $3
+			FOR UPDATE
+		), updated AS (
+			UPDATE urls SET original_url = $2
+			FROM old WHERE urls.short_code = old.short_code
+			RETURNING urls.*
+		), audit AS (
+			INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, old_value, new_value)
+			SELECT short_code, 'update', $4, NULLIF($5, ''),
+				jsonb_build_object('original_url', old.original_url) - `+auditDestinations("old")+`,
+				jsonb_build_object('original_url', $2::text) - `+auditDestinations("old")+`
+			FROM old
+		)
+		SELECT `+urlColumns+` FROM updated`,
+		shortCode, originalURL, owner, actor.KeyID, actor.IP,
+	))
+}
+
+// The row is kept so the short code can never be reused.
+func (r *URLRepository) Delete(ctx context.Context, shortCode string, owner *int64, actor domain.Actor) error {
+	tag, err := r.pool.Exec(ctx,
+		`WITH old AS (
+			SELECT * FROM urls
+			WHERE short_code = $1 AND deleted_at IS NULL AND owner_key_id = $2
+			FOR UPDATE
+		), deleted AS (
+			UPDATE urls SET deleted_at = NOW(), active = FALSE
+			FROM old WHERE urls.short_code = old.short_code
+			RETURNING urls.short_code
+		)
+		INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, old_value)
+		SELECT short_code, 'delete', $3, NULLIF($4, ''), `+auditSnapshot("old")+`
+		FROM old JOIN deleted USING (short_code)`,
+		shortCode, owner, actor.KeyID, actor.IP,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete url: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *URLRepository) SetActive(ctx context.Context, shortCode string, active bool, owner *int64, actor domain.Actor) error {
+	tag, err := r.pool.Exec(ctx,
+		`WITH old AS (
+			SELECT short_code, active FROM urls
+			WHERE short_code = $1 AND deleted_at IS NULL AND owner_key_id = $3
+			FOR UPDATE
+		), changed AS (
+			UPDATE urls SET active = $2
+			FROM old WHERE urls.short_code = old.short_code
+			RETURNING urls.short_code
+		)
+		INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, old_value, new_value)
+		SELECT short_code, `+auditActiveAction("$2")+`, $4, NULLIF($5, ''),
+			jsonb_build_object('active', old.active), jsonb_build_object('active', $2::boolean)
+		FROM old JOIN changed USING (short_code)`,
+		shortCode, active, owner, actor.KeyID, actor.IP,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
 }
 
 func (r *URLRepository) NextIDs(ctx context.Context, count int) ([]uint, error) {
@@ -102,25 +427,142 @@ func (r *URLRepository) NextIDs(ctx context.Context, count int) ([]uint, error)
 }
 
 type URLRow struct {
-	ShortCode   string
-	OriginalURL string
+	ShortCode      string
+	OriginalURL    string
+	ExpiresAt      *time.Time
+	PasswordHash   string // only stored by Create
+	OwnerKeyID     *int64
+	RedirectStatus int
+	QueryPolicy    string
+	UTM            map[string]string
+	Rules          *routing.Set
+	Variants       []domain.Variant
+	Sticky         bool
+	Backups        []string
+	Tags           []string
+	Campaign       string
+	ActiveFrom     *time.Time
+	PrelaunchURL   string
 }
 
-func (r *URLRepository) CreateBatch(ctx context.Context, urls []URLRow) error {
+func (r *URLRepository) CreateBatch(ctx context.Context, urls []URLRow, actor domain.Actor) error {
 	now := time.Now()
 	rows := make([][]any, len(urls))
+	codes := make([]string, len(urls))
 	for i, u := range urls {
-		rows[i] = []any{u.ShortCode, u.OriginalURL, now}
+		codes[i] = u.ShortCode
+		rules, err := encodeRules(u.Rules)
+		if err != nil {
+			return err
+		}
+		variants, err := encodeList(u.Variants)
+		if err != nil {
+			return err
+		}
+		rows[i] = []any{
+			u.ShortCode, u.OriginalURL, now, u.ExpiresAt, u.OwnerKeyID, int16(u.RedirectStatus),
+			u.QueryPolicy, encodeUTM(u.UTM), rules, variants, u.Sticky, u.Backups,
+			u.Tags, nullIfEmpty(u.Campaign), u.ActiveFrom, nullIfEmpty(u.PrelaunchURL),
+		}
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	_, err := r.pool.CopyFrom(
+	_, err = tx.CopyFrom(
 		ctx,
 		pgx.Identifier{"urls"},
-		[]string{"short_code", "original_url", "created_at"},
+		[]string{
+			"short_code", "original_url", "created_at", "expires_at", "owner_key_id", "redirect_status",
+			"query_policy", "utm", "rules", "variants", "sticky", "backups",
+			"tags", "campaign", "active_from", "prelaunch_url",
+		},
 		pgx.CopyFromRows(rows),
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateShortCode
+		}
 		return fmt.Errorf("failed to batch insert urls: %w", err)
 	}
-	return nil
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO url_audit (short_code, action, actor_key_id, actor_ip, new_value)
+		SELECT short_code, 'create', $2, NULLIF($3, ''), `+auditSnapshot("urls")+`
+		FROM urls WHERE short_code = ANY($1)`,
+		codes, actor.KeyID, actor.IP,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to audit urls: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func encodeUTM(utm map[string]string) *string {
+	if len(utm) == 0 {
+		return nil
+	}
+	values := make(url.Values, len(utm))
+	for key, value := range utm {
+		values.Set(key, value)
+	}
+	encoded := values.Encode()
+	return &encoded
+}
+
+func decodeUTM(encoded string) map[string]string {
+	values, err := url.ParseQuery(encoded)
+	if err != nil || len(values) == 0 {
+		return nil
+	}
+	utm := make(map[string]string, len(values))
+	for key := range values {
+		utm[key] = values.Get(key)
+	}
+	return utm
+}
+
+func encodeRules(rules *routing.Set) ([]byte, error) {
+	return encodeList(rules.Rules())
+}
+
+func encodeList[T any](list []T) ([]byte, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %T: %w", list, err)
+	}
+	return encoded, nil
+}
+
+func decodeRules(encoded []byte) (*routing.Set, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+	var rules []routing.Rule
+	if err := json.Unmarshal(encoded, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode rules: %w", err)
+	}
+	set, err := routing.Compile(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rules: %w", err)
+	}
+	return set, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
 }
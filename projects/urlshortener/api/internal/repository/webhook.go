@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"urlshortener/internal/domain"
+)
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+type QueuedEvent struct {
+	Type    string
+	Payload []byte
+}
+
+type DeliveryJob struct {
+	ID       int64
+	URL      string
+	Secret   string
+	Event    string
+	Payload  []byte
+	Attempts int
+}
+
+type DeliveryResult struct {
+	ID            int64
+	Delivered     bool
+	Dead          bool
+	NextAttemptAt time.Time
+	LastStatus    int
+	LastError     string
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, url string, events []string, secret string) (*domain.Webhook, error) {
+	hook := domain.Webhook{URL: url, Events: events}
+	err := r.pool.QueryRow(ctx,
+		"INSERT INTO webhooks (url, events, secret) VALUES ($1, $2, $3) RETURNING id, created_at",
+		url, events, secret,
+	).Scan(&hook.ID, &hook.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return &hook, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := r.pool.Query(ctx, "SELECT id, url, events, created_at FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	hooks := []domain.Webhook{}
+	for rows.Next() {
+		var hook domain.Webhook
+		if err := rows.Scan(&hook.ID, &hook.URL, &hook.Events, &hook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *WebhookRepository) SubscribedEvents(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, "SELECT DISTINCT unnest(events) FROM webhooks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribed events: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Deliveries(ctx context.Context, status string, webhookID, after int64, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at,
+			COALESCE(last_status, 0), COALESCE(last_error, ''), created_at
+		FROM webhook_deliveries
+		WHERE ($1::text = '' OR status = $1) AND ($2::bigint = 0 OR webhook_id = $2) AND id > $3
+		ORDER BY id LIMIT $4`,
+		status, webhookID, after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var (
+			d    domain.WebhookDelivery
+			next time.Time
+		)
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &next,
+			&d.LastStatus, &d.LastError, &d.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		if d.Status == domain.DeliveryPending {
+			d.NextAttemptAt = &next
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) Retry(ctx context.Context, id int64) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1 AND status = 'dead'`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to retry delivery: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Enqueue(ctx context.Context, events []QueuedEvent) error {
+	types := make([]string, len(events))
+	payloads := make([]string, len(events))
+	for i, e := range events {
+		types[i], payloads[i] = e.Type, string(e.Payload)
+	}
+
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO webhook_deliveries (webhook_id, event, payload)
+		SELECT w.id, e.type, e.payload::jsonb
+		FROM unnest($1::text[], $2::text[]) WITH ORDINALITY AS e(type, payload, n)
+		JOIN webhooks w ON e.type = ANY(w.events)
+		ORDER BY e.n, w.id`,
+		types, payloads,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhook events: %w", err)
+	}
+	return nil
+}
+
+// The lease hides claimed deliveries from other workers, so a worker that dies
+// mid-delivery only delays them.
+func (r *WebhookRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]DeliveryJob, error) {
+	rows, err := r.pool.Query(ctx,
+		`UPDATE webhook_deliveries d SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhooks w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, w.url, w.secret, d.event, d.payload, d.attempts`,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []DeliveryJob
+	for rows.Next() {
+		var job DeliveryJob
+		if err := rows.Scan(&job.ID, &job.URL, &job.Secret, &job.Event, &job.Payload, &job.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *WebhookRepository) Complete(ctx context.Context, results []DeliveryResult) error {
+	var (
+		delivered  []int64
+		failed     []int64
+		dead       []bool
+		next       []time.Time
+		lastStatus []int32
+		lastError  []string
+	)
+	for _, res := range results {
+		if res.Delivered {
+			delivered = append(delivered, res.ID)
+			continue
+		}
+		failed = append(failed, res.ID)
+		dead = append(dead, res.Dead)
+		next = append(next, res.NextAttemptAt)
+		lastStatus = append(lastStatus, int32(res.LastStatus))
+		lastError = append(lastError, res.LastError)
+	}
+
+	_, err := r.pool.Exec(ctx,
+		`WITH delivered AS (
+			DELETE FROM webhook_deliveries WHERE id = ANY($1)
+		)
+		UPDATE webhook_deliveries d SET
+			attempts = d.attempts + 1,
+			status = CASE WHEN f.dead THEN 'dead' ELSE 'pending' END,
+			next_attempt_at = f.next_attempt_at,
+			last_status = NULLIF(f.last_status, 0),
+			last_error = NULLIF(f.last_error, '')
+		FROM unnest($2::bigint[], $3::boolean[], $4::timestamptz[], $5::int[], $6::text[])
+			AS f(id, dead, next_attempt_at, last_status, last_error)
+		WHERE d.id = f.id`,
+		delivered, failed, dead, next, lastStatus, lastError,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete deliveries: %w", err)
+	}
+	return nil
+}
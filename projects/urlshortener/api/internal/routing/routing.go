@@ -0,0 +1,232 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // rules name IANA time zones; the runtime image has no zoneinfo
+)
+
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceMobile  = "mobile" // matches iOS, Android and other mobile devices
+	DeviceDesktop = "desktop"
+)
+
+const clockLayout = "15:04"
+
+var (
+	ErrNoCondition     = errors.New("rule has no condition")
+	ErrInvalidDevice   = errors.New("invalid device class")
+	ErrInvalidLanguage = errors.New("invalid language tag")
+	ErrInvalidWindow   = errors.New("invalid time window")
+)
+
+type Rule struct {
+	URL       string      `json:"url"`
+	Device    string      `json:"device,omitempty"`
+	Languages []string    `json:"languages,omitempty"`
+	Window    *TimeWindow `json:"time_window,omitempty"`
+}
+
+// A window whose end is before its start spans midnight.
+type TimeWindow struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Timezone string `json:"timezone,omitempty"` // IANA name, UTC if empty
+}
+
+type Request struct {
+	UserAgent      string
+	AcceptLanguage string
+}
+
+type Set struct {
+	rules    []Rule
+	compiled []compiled
+}
+
+type compiled struct {
+	device    string
+	languages []string
+	window    bool
+	from, to  int // minutes after midnight
+	location  *time.Location
+}
+
+func Compile(rules []Rule) (*Set, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	set := &Set{rules: rules, compiled: make([]compiled, len(rules))}
+	for i, rule := range rules {
+		c, err := compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		set.compiled[i] = c
+	}
+	return set, nil
+}
+
+func compile(rule Rule) (compiled, error) {
+	var c compiled
+	if rule.Device == "" && len(rule.Languages) == 0 && rule.Window == nil {
+		return c, ErrNoCondition
+	}
+
+	switch rule.Device {
+	case "", DeviceIOS, DeviceAndroid, DeviceMobile, DeviceDesktop:
+		c.device = rule.Device
+	default:
+		return c, ErrInvalidDevice
+	}
+
+	for _, lang := range rule.Languages {
+		if !validLanguage(lang) {
+			return c, ErrInvalidLanguage
+		}
+		c.languages = append(c.languages, strings.ToLower(lang))
+	}
+
+	if w := rule.Window; w != nil {
+		from, errFrom := time.Parse(clockLayout, w.From)
+		to, errTo := time.Parse(clockLayout, w.To)
+		location, errLoc := time.LoadLocation(w.Timezone)
+		if errFrom != nil || errTo != nil || errLoc != nil || from.Equal(to) {
+			return c, ErrInvalidWindow
+		}
+		c.window = true
+		c.from = from.Hour()*60 + from.Minute()
+		c.to = to.Hour()*60 + to.Minute()
+		c.location = location
+	}
+	return c, nil
+}
+
+func validLanguage(tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for sub := range strings.SplitSeq(tag, "-") {
+		if sub == "" || len(sub) > 8 {
+			return false
+		}
+		for _, r := range sub {
+			if !isAlphanumeric(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9'
+}
+
+func (s *Set) Rules() []Rule {
+	if s == nil {
+		return nil
+	}
+	return s.rules
+}
+
+func (s *Set) Size() int {
+	size := 0
+	for _, rule := range s.Rules() {
+		size += len(rule.URL) + 64
+	}
+	return size
+}
+
+func (s *Set) Match(req Request, now time.Time) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+
+	device := DeviceClass(req.UserAgent)
+	language := PreferredLanguage(req.AcceptLanguage)
+	for i, c := range s.compiled {
+		if c.matches(device, language, now) {
+			return s.rules[i].URL, true
+		}
+	}
+	return "", false
+}
+
+func (c *compiled) matches(device, language string, now time.Time) bool {
+	if c.device != "" && !deviceMatches(c.device, device) {
+		return false
+	}
+	if len(c.languages) > 0 && !languageMatches(c.languages, language) {
+		return false
+	}
+	if c.window {
+		local := now.In(c.location)
+		minute := local.Hour()*60 + local.Minute()
+		if c.from < c.to {
+			return c.from <= minute && minute < c.to
+		}
+		return minute >= c.from || minute < c.to
+	}
+	return true
+}
+
+func deviceMatches(want, device string) bool {
+	if want == DeviceMobile {
+		return device != DeviceDesktop
+	}
+	return want == device
+}
+
+// "de" matches "de-CH" but "de-CH" does not match "de".
+func languageMatches(tags []string, language string) bool {
+	for _, tag := range tags {
+		if language == tag || strings.HasPrefix(language, tag+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+func DeviceClass(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"), strings.Contains(userAgent, "iPod"):
+		return DeviceIOS
+	case strings.Contains(userAgent, "Android"):
+		return DeviceAndroid
+	case strings.Contains(userAgent, "Mobile"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+func PreferredLanguage(header string) string {
+	best, bestQ := "", 0.0
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ {
+			best, bestQ = strings.ToLower(tag), q
+		}
+	}
+	return best
+}
@@ -0,0 +1,143 @@
+package routing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/routing"
+)
+
+const (
+	iPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1"
+	androidUA = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Mobile Safari/537.36"
+	desktopUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+)
+
+var noon = time.Date(2025, time.June, 2, 12, 0, 0, 0, time.UTC)
+
+func TestDeviceClass(t *testing.T) {
+	assert.Equal(t, routing.DeviceIOS, routing.DeviceClass(iPhoneUA))
+	assert.Equal(t, routing.DeviceIOS, routing.DeviceClass("Mozilla/5.0 (iPad; CPU OS 16_0 like Mac OS X)"))
+	assert.Equal(t, routing.DeviceAndroid, routing.DeviceClass(androidUA))
+	assert.Equal(t, routing.DeviceMobile, routing.DeviceClass("Mozilla/5.0 (Mobile; rv:48.0) Gecko/48.0 Firefox/48.0 KAIOS/2.5"))
+	assert.Equal(t, routing.DeviceDesktop, routing.DeviceClass(desktopUA))
+	assert.Equal(t, routing.DeviceDesktop, routing.DeviceClass(""))
+}
+
+func TestPreferredLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                              "",
+		"de-CH":                         "de-ch",
+		"fr;q=0.5, en-US, de;q=0.9":     "en-us",
+		"fr;q=0.9, de;q=0.9":            "fr",
+		"*, es;q=0.1":                   "es",
+		"en;q=0, it;q=0.2":              "it",
+		"nl;q=oops, pt-BR;q=0.3":        "pt-br",
+		" sv ; q=0.8 ,  da ; q=0.85 ":   "da",
+		"ja;q=0.7,zh-Hant-TW;q=0.71, *": "zh-hant-tw",
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, routing.PreferredLanguage(header), header)
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		rule routing.Rule
+		err  error
+	}{
+		{routing.Rule{URL: "https://example.com"}, routing.ErrNoCondition},
+		{routing.Rule{URL: "https://example.com", Device: "tablet"}, routing.ErrInvalidDevice},
+		{routing.Rule{URL: "https://example.com", Languages: []string{"de", ""}}, routing.ErrInvalidLanguage},
+		{routing.Rule{URL: "https://example.com", Languages: []string{"en_US"}}, routing.ErrInvalidLanguage},
+		{routing.Rule{URL: "https://example.com", Window: &routing.TimeWindow{From: "25:00", To: "17:00"}}, routing.ErrInvalidWindow},
+		{routing.Rule{URL: "https://example.com", Window: &routing.TimeWindow{From: "09:00", To: "09:00"}}, routing.ErrInvalidWindow},
+		{routing.Rule{URL: "https://example.com", Window: &routing.TimeWindow{From: "09:00", To: "17:00", Timezone: "Mars/Olympus"}}, routing.ErrInvalidWindow},
+	}
+	for _, tt := range tests {
+		_, err := routing.Compile([]routing.Rule{tt.rule})
+		assert.ErrorIs(t, err, tt.err, tt.rule)
+	}
+}
+
+func TestCompile_Empty(t *testing.T) {
+	set, err := routing.Compile(nil)
+	require.NoError(t, err)
+	assert.Nil(t, set)
+
+	_, matched := set.Match(routing.Request{UserAgent: iPhoneUA}, noon)
+	assert.False(t, matched)
+	assert.Nil(t, set.Rules())
+	assert.Zero(t, set.Size())
+}
+
+func TestMatch_FirstMatchingRuleWins(t *testing.T) {
+	set, err := routing.Compile([]routing.Rule{
+		{URL: "https://apps.apple.com/app/id1", Device: routing.DeviceIOS},
+		{URL: "https://play.google.com/store/apps/details?id=app", Device: routing.DeviceAndroid},
+		{URL: "https://m.example.com", Device: routing.DeviceMobile},
+		{URL: "https://example.de", Languages: []string{"de"}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		req  routing.Request
+		want string
+	}{
+		{routing.Request{UserAgent: iPhoneUA, AcceptLanguage: "de"}, "https://apps.apple.com/app/id1"},
+		{routing.Request{UserAgent: androidUA}, "https://play.google.com/store/apps/details?id=app"},
+		{routing.Request{UserAgent: "Opera Mini Mobile"}, "https://m.example.com"},
+		{routing.Request{UserAgent: desktopUA, AcceptLanguage: "de-AT,en;q=0.5"}, "https://example.de"},
+	}
+	for _, tt := range tests {
+		got, matched := set.Match(tt.req, noon)
+		assert.True(t, matched, tt.req)
+		assert.Equal(t, tt.want, got, tt.req)
+	}
+
+	_, matched := set.Match(routing.Request{UserAgent: desktopUA, AcceptLanguage: "en-US"}, noon)
+	assert.False(t, matched)
+}
+
+func TestMatch_AllConditionsMustHold(t *testing.T) {
+	set, err := routing.Compile([]routing.Rule{
+		{URL: "https://example.ch/app", Device: routing.DeviceMobile, Languages: []string{"de-CH", "FR"}},
+	})
+	require.NoError(t, err)
+
+	_, matched := set.Match(routing.Request{UserAgent: iPhoneUA, AcceptLanguage: "de-CH"}, noon)
+	assert.True(t, matched)
+	_, matched = set.Match(routing.Request{UserAgent: androidUA, AcceptLanguage: "fr-CA"}, noon)
+	assert.True(t, matched)
+	_, matched = set.Match(routing.Request{UserAgent: iPhoneUA, AcceptLanguage: "de"}, noon)
+	assert.False(t, matched, "a more general language does not match")
+	_, matched = set.Match(routing.Request{UserAgent: desktopUA, AcceptLanguage: "de-CH"}, noon)
+	assert.False(t, matched)
+}
+
+func TestMatch_TimeWindow(t *testing.T) {
+	set, err := routing.Compile([]routing.Rule{
+		{URL: "https://example.com/open", Window: &routing.TimeWindow{From: "09:00", To: "17:30", Timezone: "Europe/Berlin"}},
+		{URL: "https://example.com/night", Window: &routing.TimeWindow{From: "22:00", To: "06:00"}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2025, time.June, 2, 7, 0, 0, 0, time.UTC), "https://example.com/open"},   // 09:00 in Berlin
+		{time.Date(2025, time.June, 2, 15, 29, 0, 0, time.UTC), "https://example.com/open"}, // 17:29 in Berlin
+		{time.Date(2025, time.June, 2, 23, 0, 0, 0, time.UTC), "https://example.com/night"},
+		{time.Date(2025, time.June, 3, 5, 59, 0, 0, time.UTC), "https://example.com/night"},
+		{time.Date(2025, time.June, 2, 15, 30, 0, 0, time.UTC), ""},
+		{time.Date(2025, time.June, 2, 6, 0, 0, 0, time.UTC), ""},
+	}
+	for _, tt := range tests {
+		got, _ := set.Match(routing.Request{}, tt.now)
+		assert.Equal(t, tt.want, got, tt.now)
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+)
+
+var (
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrAPIKeyNameMissing = errors.New("api key name is required")
+	ErrInvalidScope      = errors.New("invalid scope")
+)
+
+type APIKeyService struct {
+	repo APIKeyRepository
+}
+
+func NewAPIKeyService(repo APIKeyRepository) *APIKeyService {
+	return &APIKeyService{repo: repo}
+}
+
+// CreateKey issues a new key. The returned key is the only copy in clear text;
+// only its hash is stored.
+func (s *APIKeyService) CreateKey(ctx context.Context, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrAPIKeyNameMissing
+	}
+	if len(req.Scopes) == 0 {
+		return nil, ErrInvalidScope
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(domain.Scopes, scope) {
+			return nil, ErrInvalidScope
+		}
+	}
+
+	plain, err := auth.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.repo.Create(ctx, repository.APIKeyRow{
+		Name:    name,
+		Prefix:  plain[:auth.PrefixLength],
+		KeyHash: auth.HashKey(plain),
+		Scopes:  slices.Compact(slices.Sorted(slices.Values(req.Scopes))),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &domain.CreateAPIKeyResponse{APIKey: *key, Key: plain}, nil
+}
+
+func (s *APIKeyService) ListKeys(ctx context.Context) ([]domain.APIKey, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *APIKeyService) RevokeKey(ctx context.Context, id int64) error {
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
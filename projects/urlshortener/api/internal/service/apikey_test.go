@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func TestCreateKey_StoresHashOnly(t *testing.T) {
+	var stored repository.APIKeyRow
+	repo := mocks.NewMockAPIKeyRepository(t)
+	repo.EXPECT().Create(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, k repository.APIKeyRow) (*domain.APIKey, error) {
+			stored = k
+			return &domain.APIKey{ID: 1, Name: k.Name, Prefix: k.Prefix, Scopes: k.Scopes, CreatedAt: time.Now()}, nil
+		})
+
+	svc := service.NewAPIKeyService(repo)
+
+	resp, err := svc.CreateKey(context.Background(), &domain.CreateAPIKeyRequest{
+		Name:   " ci ",
+		Scopes: []string{domain.ScopeStatsRead, domain.ScopeLinksCreate, domain.ScopeStatsRead},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ci", stored.Name)
+	assert.Equal(t, auth.HashKey(resp.Key), stored.KeyHash)
+	assert.NotContains(t, string(stored.KeyHash), resp.Key)
+	assert.Equal(t, resp.Key[:auth.PrefixLength], stored.Prefix)
+	assert.Equal(t, []string{domain.ScopeLinksCreate, domain.ScopeStatsRead}, stored.Scopes)
+}
+
+func TestCreateKey_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.CreateAPIKeyRequest
+		wantErr error
+	}{
+		{"missing name", domain.CreateAPIKeyRequest{Scopes: []string{domain.ScopeLinksCreate}}, service.ErrAPIKeyNameMissing},
+		{"no scopes", domain.CreateAPIKeyRequest{Name: "ci"}, service.ErrInvalidScope},
+		{"unknown scope", domain.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"links:*"}}, service.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewAPIKeyService(mocks.NewMockAPIKeyRepository(t))
+			_, err := svc.CreateKey(context.Background(), &tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRevokeKey_NotFound(t *testing.T) {
+	repo := mocks.NewMockAPIKeyRepository(t)
+	repo.EXPECT().Revoke(mock.Anything, int64(9)).Return(pgx.ErrNoRows)
+
+	svc := service.NewAPIKeyService(repo)
+
+	err := svc.RevokeKey(context.Background(), 9)
+	assert.ErrorIs(t, err, service.ErrAPIKeyNotFound)
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+)
+
+// actorOf returns who is making the request, for the audit log.
+func actorOf(ctx context.Context) domain.Actor {
+	return domain.Actor{KeyID: ownerOf(ctx), IP: auth.ClientIPFromContext(ctx)}
+}
+
+// History returns one page of the changes made to a link, oldest first, with
+// who made them and when. cursor and limit work as in ListURLs. Links owned
+// by another key, unknown links and links without recorded changes return
+// ErrURLNotFound. Deleted links keep their history.
+func (s *URLService) History(ctx context.Context, shortCode, cursor string, limit int) (*domain.HistoryPage, error) {
+	after, err := decodeIDCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	entries, err := s.repo.History(ctx, shortCode, ownerOf(ctx), after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+	if len(entries) == 0 && after == 0 {
+		return nil, ErrURLNotFound
+	}
+
+	page := &domain.HistoryPage{ShortCode: shortCode, Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.NextCursor = encodeIDCursor(entries[limit-1].ID)
+	}
+	if page.Entries == nil {
+		page.Entries = []domain.AuditEntry{}
+	}
+	return page, nil
+}
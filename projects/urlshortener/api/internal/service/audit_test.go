@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func newHistoryService(t *testing.T, repo *mocks.MockRepository) *service.URLService {
+	return service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url",
+		mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+}
+
+func TestDeleteURL_RecordsActor(t *testing.T) {
+	owner := int64(7)
+	ctx := auth.WithClientIP(auth.NewContext(context.Background(), &domain.APIKey{ID: owner}), "203.0.113.9")
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "abc123", &owner, domain.Actor{KeyID: &owner, IP: "203.0.113.9"}).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("abc123").Return()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deleted", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	require.NoError(t, svc.DeleteURL(ctx, "abc123"))
+}
+
+func TestSetURLsActive_RecordsAnonymousActor(t *testing.T) {
+	filter := domain.LinkFilter{Campaign: "spring"}
+	ctx := auth.WithClientIP(context.Background(), "198.51.100.4")
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().SetActiveByFilter(mock.Anything, filter, false, (*int64)(nil), domain.Actor{IP: "198.51.100.4"}).Return(nil, nil)
+
+	n, err := newHistoryService(t, repo).SetURLsActive(ctx, filter, false)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestHistory_Pages(t *testing.T) {
+	entries := []domain.AuditEntry{
+		{ID: 3, Action: domain.AuditCreate},
+		{ID: 5, Action: domain.AuditUpdate},
+		{ID: 9, Action: domain.AuditDeactivate},
+	}
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().History(mock.Anything, "abc123", (*int64)(nil), int64(0), 3).Return(entries, nil)
+	repo.EXPECT().History(mock.Anything, "abc123", (*int64)(nil), int64(5), 3).Return(entries[2:], nil)
+	svc := newHistoryService(t, repo)
+
+	page, err := svc.History(context.Background(), "abc123", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", page.ShortCode)
+	assert.Equal(t, entries[:2], page.Entries)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = svc.History(context.Background(), "abc123", page.NextCursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, entries[2:], page.Entries)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestHistory_NotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().History(mock.Anything, "nope", (*int64)(nil), int64(0), 51).Return(nil, nil)
+
+	_, err := newHistoryService(t, repo).History(context.Background(), "nope", "", 0)
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
+func TestHistory_InvalidCursor(t *testing.T) {
+	svc := newHistoryService(t, mocks.NewMockRepository(t))
+
+	_, err := svc.History(context.Background(), "abc123", "!!", 0)
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+
+	_, err = svc.History(context.Background(), "abc123", "YWJj", 0) // "abc"
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"sync"
+
+	"urlshortener/internal/domain"
+)
+
+// Codes sharing a stripe only cost each other the occasional skipped fill.
+const cacheFillStripes = 256
+
+// cacheFillGuard keeps a reader that missed the cache from filling it with a
+// row read before a concurrent change.
+type cacheFillGuard struct {
+	stripes [cacheFillStripes]struct {
+		mu         sync.Mutex
+		generation uint64
+	}
+}
+
+// Call generation before reading the row.
+func (g *cacheFillGuard) generation(shortCode string) uint64 {
+	s := &g.stripes[cacheFillStripe(shortCode)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generation
+}
+
+func (g *cacheFillGuard) fill(cache Cache, u *domain.URL, generation uint64) {
+	s := &g.stripes[cacheFillStripe(u.ShortCode)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generation == generation {
+		cache.Set(u)
+	}
+}
+
+func (g *cacheFillGuard) invalidate(cache Cache, shortCode string, replacement *domain.URL) {
+	s := &g.stripes[cacheFillStripe(shortCode)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	// Delete is applied synchronously; Set alone may be buffered for keys
+	// that are not cached yet.
+	cache.Delete(shortCode)
+	if replacement != nil {
+		cache.Set(replacement)
+	}
+}
+
+func cacheFillStripe(shortCode string) int {
+	h := uint32(2166136261)
+	for i := range len(shortCode) {
+		h ^= uint32(shortCode[i])
+		h *= 16777619
+	}
+	return int(h % cacheFillStripes)
+}
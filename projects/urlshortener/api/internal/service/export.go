@@ -0,0 +1,61 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"urlshortener/internal/domain"
+)
+
+func (s *URLService) ExportURLs(ctx context.Context, after string, fn func(*domain.ExportedLink) error) error {
+	owner := ownerOf(ctx)
+	exported := 0
+	err := s.repo.Export(ctx, owner, after, func(u *domain.URL) error {
+		if err := fn(exportedLink(u, owner)); err != nil {
+			return err
+		}
+		exported++
+		return nil
+	})
+	if exported > 0 {
+		s.recorder.RecordBusiness(time.Now(), "urls_exported", float64(exported), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export urls: %w", err)
+	}
+	return nil
+}
+
+// Protected destinations are withheld unless owner created the link.
+func exportedLink(u *domain.URL, owner *int64) *domain.ExportedLink {
+	link := &domain.ExportedLink{
+		ShortCode:      u.ShortCode,
+		OriginalURL:    u.OriginalURL,
+		CreatedAt:      u.CreatedAt,
+		ExpiresAt:      u.ExpiresAt,
+		Active:         u.Active,
+		Protected:      u.Protected(),
+		OwnerKeyID:     u.OwnerKeyID,
+		RedirectStatus: cmp.Or(u.RedirectStatus, http.StatusFound),
+		QueryPolicy:    cmp.Or(u.QueryPolicy, domain.QueryDrop),
+		UTM:            u.UTM,
+		Rules:          u.Rules.Rules(),
+		Variants:       u.Variants,
+		Sticky:         u.Sticky,
+		Backups:        u.Backups,
+		Tags:           u.Tags,
+		Campaign:       u.Campaign,
+		ActiveFrom:     u.ActiveFrom,
+		PrelaunchURL:   u.PrelaunchURL,
+	}
+	if link.Protected && (owner == nil || u.OwnerKeyID == nil || *u.OwnerKeyID != *owner) {
+		link.OriginalURL = ""
+		link.Rules = nil
+		link.Variants = nil
+		link.Backups = nil
+	}
+	return link
+}
@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+// exportRows makes the repository yield urls.
+func exportRows(repo *mocks.MockRepository, owner *int64, after string, urls ...*domain.URL) {
+	repo.EXPECT().Export(mock.Anything, owner, after, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *int64, _ string, fn func(*domain.URL) error) error {
+			for _, u := range urls {
+				if err := fn(u); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func TestExportURLs(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	exportRows(repo, nil, "abc",
+		&domain.URL{ShortCode: "abd", OriginalURL: "https://example.com", CreatedAt: created, Active: true, PasswordHash: "hash", Tags: []string{"email"}},
+		&domain.URL{ShortCode: "abe", OriginalURL: "https://example.org", CreatedAt: created, RedirectStatus: 301, QueryPolicy: domain.QueryAppend},
+	)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_exported", float64(2), []byte(nil)).Return()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	var links []*domain.ExportedLink
+	err := svc.ExportURLs(context.Background(), "abc", func(link *domain.ExportedLink) error {
+		links = append(links, link)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+
+	assert.Equal(t, &domain.ExportedLink{
+		ShortCode:      "abd",
+		CreatedAt:      created,
+		Active:         true,
+		Protected:      true,
+		RedirectStatus: 302,
+		QueryPolicy:    domain.QueryDrop,
+		Tags:           []string{"email"},
+	}, links[0], "defaults filled in and the password and destination withheld")
+	assert.Equal(t, 301, links[1].RedirectStatus)
+	assert.Equal(t, domain.QueryAppend, links[1].QueryPolicy)
+}
+
+func TestExportURLs_Owner(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	owner := int64(42)
+	exportRows(repo, &owner, "",
+		&domain.URL{ShortCode: "mine", OriginalURL: "https://example.com", OwnerKeyID: &owner, PasswordHash: "hash", Backups: []string{"https://example.org"}},
+		&domain.URL{ShortCode: "anon", OriginalURL: "https://example.net", PasswordHash: "hash", Backups: []string{"https://example.org"}},
+	)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_exported", float64(2), []byte(nil)).Return()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	var links []*domain.ExportedLink
+	ctx := auth.NewContext(context.Background(), &domain.APIKey{ID: owner})
+	err := svc.ExportURLs(ctx, "", func(link *domain.ExportedLink) error {
+		links = append(links, link)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, "https://example.com", links[0].OriginalURL, "own protected link")
+	assert.Equal(t, []string{"https://example.org"}, links[0].Backups)
+	assert.Empty(t, links[1].OriginalURL, "anonymous protected link")
+	assert.Empty(t, links[1].Backups)
+}
+
+func TestExportURLs_StopsOnWriteError(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	exportRows(repo, nil, "",
+		&domain.URL{ShortCode: "abc"},
+		&domain.URL{ShortCode: "abd"},
+	)
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url", mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	errGone := errors.New("client gone")
+	err := svc.ExportURLs(context.Background(), "", func(*domain.ExportedLink) error { return errGone })
+	assert.ErrorIs(t, err, errGone)
+}
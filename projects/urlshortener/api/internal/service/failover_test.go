@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func failoverLink() *domain.URL {
+	return &domain.URL{
+		ShortCode:   "abc123",
+		OriginalURL: "https://primary.example.com",
+		Active:      true,
+		Backups:     []string{"https://backup1.example.com", "https://backup2.example.com"},
+	}
+}
+
+func TestGetOriginalURL_PrimaryHealthy(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(failoverLink(), true)
+
+	health := mocks.NewMockHealthChecker(t)
+	health.EXPECT().Healthy("https://primary.example.com").Return(true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "redirects", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), health, anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://primary.example.com", redirect.URL)
+	assert.True(t, redirect.Conditional, "a failover link must not be cached by clients")
+}
+
+func TestGetOriginalURL_FailsOverToFirstHealthyBackup(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(failoverLink(), true)
+
+	health := mocks.NewMockHealthChecker(t)
+	health.EXPECT().Healthy("https://primary.example.com").Return(false)
+	health.EXPECT().Healthy("https://backup1.example.com").Return(false)
+	health.EXPECT().Healthy("https://backup2.example.com").Return(true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "failover", float64(1),
+		[]byte(`{"short_code":"abc123","original_url":"https://primary.example.com","destination":"https://backup2.example.com"}`)).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "redirects", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), health, anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://backup2.example.com", redirect.URL)
+}
+
+func TestGetOriginalURL_AllUnhealthyKeepsPrimary(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(failoverLink(), true)
+
+	health := mocks.NewMockHealthChecker(t)
+	health.EXPECT().Healthy(mock.Anything).Return(false)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "redirects", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), health, anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://primary.example.com", redirect.URL)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"urlshortener/internal/domain"
+)
+
+var labelsImport = []byte(`{"method":"import"}`)
+
+// Entries must be validated as batch entries.
+func (s *URLService) ImportURLs(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.ImportResult, error) {
+	responses, errs, err := s.createEach(ctx, reqs, labelsImport)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.ImportResult, len(reqs))
+	for i := range results {
+		if errs[i] != nil {
+			results[i].Error = errs[i].Error()
+			continue
+		}
+		results[i].CreateURLResponse = &responses[i]
+	}
+	return results, nil
+}
+
+// If an alias is taken between the check and the insert, the entries are
+// retried one by one so only that entry fails.
+func (s *URLService) createEach(ctx context.Context, reqs []domain.CreateURLRequest, labels []byte) ([]domain.CreateURLResponse, []error, error) {
+	errs, err := s.checkAliases(ctx, reqs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pending []int
+	for i := range reqs {
+		if errs[i] == nil {
+			pending = append(pending, i)
+		}
+	}
+
+	responses := make([]domain.CreateURLResponse, len(reqs))
+	if len(pending) == 0 {
+		return responses, errs, nil
+	}
+
+	batch := make([]domain.CreateURLRequest, len(pending))
+	for j, i := range pending {
+		batch[j] = reqs[i]
+	}
+	created, err := s.createBatch(ctx, batch, labels)
+	if err == nil {
+		for j, i := range pending {
+			responses[i] = created[j]
+		}
+		return responses, errs, nil
+	}
+	if !isAliasError(err) {
+		return nil, nil, err
+	}
+
+	for _, i := range pending {
+		created, err := s.createBatch(ctx, reqs[i:i+1], labels)
+		switch {
+		case err == nil:
+			responses[i] = created[0]
+		case isAliasError(err):
+			errs[i] = err
+		default:
+			return nil, nil, err
+		}
+	}
+	return responses, errs, nil
+}
+
+func (s *URLService) checkAliases(ctx context.Context, reqs []domain.CreateURLRequest) ([]error, error) {
+	errs := make([]error, len(reqs))
+
+	var aliases []string
+	for i := range reqs {
+		alias := reqs[i].Alias
+		switch {
+		case alias == "":
+		case s.shortener.IsGenerated(alias):
+			errs[i] = ErrAliasReserved
+		case slices.Contains(aliases, alias):
+			errs[i] = ErrAliasTaken
+		default:
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		return errs, nil
+	}
+
+	taken, err := s.repo.TakenShortCodes(ctx, aliases)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check aliases: %w", err)
+	}
+	for i := range reqs {
+		if errs[i] == nil && slices.Contains(taken, reqs[i].Alias) {
+			errs[i] = ErrAliasTaken
+		}
+	}
+	return errs, nil
+}
+
+func isAliasError(err error) bool {
+	return errors.Is(err, ErrAliasTaken) || errors.Is(err, ErrAliasReserved)
+}
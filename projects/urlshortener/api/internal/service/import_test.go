@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+var labelsImport = []byte(`{"method":"import"}`)
+
+func TestImportURLs_SingleBatch(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextIDs(mock.Anything, 2).Return([]uint{1, 2}, nil)
+	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(rows []repository.URLRow) bool { return len(rows) == 2 }), mock.Anything).Return(nil)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(1)).Return("code1", nil)
+	shortener.EXPECT().Generate(uint(2)).Return("code2", nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(2), labelsImport).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	results, err := svc.ImportURLs(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com/a"},
+		{URL: "https://example.com/b"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "code1", results[0].ShortCode)
+	assert.Equal(t, "code2", results[1].ShortCode)
+	assert.Empty(t, results[1].Error)
+}
+
+func TestImportURLs_TakenAliasFailsOnlyItsRow(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().TakenShortCodes(mock.Anything, []string{"free", "taken"}).Return([]string{"taken"}, nil).Once()
+	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(rows []repository.URLRow) bool {
+		return len(rows) == 1 && rows[0].ShortCode == "free"
+	}), mock.Anything).Return(nil).Once()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated(mock.Anything).Return(false)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return().Once()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), labelsImport).Return().Once()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	results, err := svc.ImportURLs(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com/a", Alias: "free"},
+		{URL: "https://example.com/b", Alias: "taken"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "free", results[0].ShortCode)
+	assert.Nil(t, results[1].CreateURLResponse)
+	assert.Equal(t, service.ErrAliasTaken.Error(), results[1].Error)
+}
+
+func TestImportURLs_RepeatedAndReservedAliasesFailWithoutQuery(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().TakenShortCodes(mock.Anything, []string{"sale"}).Return(nil, nil).Once()
+	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(rows []repository.URLRow) bool {
+		return len(rows) == 1 && rows[0].ShortCode == "sale"
+	}), mock.Anything).Return(nil).Once()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("sale").Return(false)
+	shortener.EXPECT().IsGenerated("abc123").Return(true)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return().Once()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), labelsImport).Return().Once()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	results, err := svc.ImportURLs(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com/a", Alias: "sale"},
+		{URL: "https://example.com/b", Alias: "sale"},
+		{URL: "https://example.com/c", Alias: "abc123"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "sale", results[0].ShortCode)
+	assert.Equal(t, service.ErrAliasTaken.Error(), results[1].Error)
+	assert.Equal(t, service.ErrAliasReserved.Error(), results[2].Error)
+}
+
+func TestImportURLs_DatabaseError(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextIDs(mock.Anything, 1).Return(nil, errors.New("db down"))
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url", mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.ImportURLs(context.Background(), []domain.CreateURLRequest{{URL: "https://example.com/a"}})
+	assert.Error(t, err)
+}
@@ -4,26 +4,80 @@ import (
 	"context"
 	"time"
 
+	"urlshortener/internal/domain"
 	"urlshortener/internal/repository"
 )
 
 type Repository interface {
 	NextID(ctx context.Context) (uint, error)
-	Create(ctx context.Context, shortCode, originalURL string) error
-	FindByShortCode(ctx context.Context, shortCode string) (string, error)
+	Create(ctx context.Context, u repository.URLRow, actor domain.Actor) error
+	FindByShortCode(ctx context.Context, shortCode string) (*domain.URL, error)
+	FindByShortCodes(ctx context.Context, shortCodes []string) ([]*domain.URL, error)
+	TakenShortCodes(ctx context.Context, shortCodes []string) ([]string, error)
+	FindByOriginalURLs(ctx context.Context, urls []string, owner *int64) (map[string]string, error)
+	UpdateOriginalURL(ctx context.Context, shortCode, originalURL string, owner *int64, actor domain.Actor) (*domain.URL, error)
+	Delete(ctx context.Context, shortCode string, owner *int64, actor domain.Actor) error
+	SetActive(ctx context.Context, shortCode string, active bool, owner *int64, actor domain.Actor) error
 	NextIDs(ctx context.Context, count int) ([]uint, error)
-	CreateBatch(ctx context.Context, urls []repository.URLRow) error
+	CreateBatch(ctx context.Context, urls []repository.URLRow, actor domain.Actor) error
+	List(ctx context.Context, filter domain.LinkFilter, owner *int64, after string, limit int) ([]*domain.URL, error)
+	SetActiveByFilter(ctx context.Context, filter domain.LinkFilter, active bool, owner *int64, actor domain.Actor) ([]string, error)
+	DeleteByFilter(ctx context.Context, filter domain.LinkFilter, owner *int64, actor domain.Actor) ([]string, error)
+	Export(ctx context.Context, owner *int64, after string, fn func(*domain.URL) error) error
+	History(ctx context.Context, shortCode string, owner *int64, after int64, limit int) ([]domain.AuditEntry, error)
 }
 
 type Cache interface {
-	Get(shortCode string) (string, bool)
-	Set(shortCode, originalURL string)
+	Get(shortCode string) (*domain.URL, bool)
+	Set(u *domain.URL)
+	Delete(shortCode string)
+}
+
+type ImageCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, img []byte)
+}
+
+type LinkInfoGetter interface {
+	GetURLInfo(ctx context.Context, shortCode string) (*domain.URLInfo, error)
 }
 
 type CodeGenerator interface {
 	Generate(id uint) (string, error)
+	IsGenerated(code string) bool
 }
 
 type BusinessRecorder interface {
 	RecordBusiness(t time.Time, name string, value float64, labelsJSON []byte)
 }
+
+type HealthChecker interface {
+	Healthy(url string) bool
+}
+
+type AttemptLimiter interface {
+	Reserve(shortCode string) (refund func(), ok bool)
+}
+
+// Publish must not block.
+type EventPublisher interface {
+	Publish(e domain.Event)
+}
+
+type WebhookSubscriptions interface {
+	RefreshSubscriptions(ctx context.Context)
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, url string, events []string, secret string) (*domain.Webhook, error)
+	List(ctx context.Context) ([]domain.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+	Deliveries(ctx context.Context, status string, webhookID, after int64, limit int) ([]domain.WebhookDelivery, error)
+	Retry(ctx context.Context, id int64) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, k repository.APIKeyRow) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"urlshortener/internal/domain"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+var labelsBulk = []byte(`{"method":"bulk"}`)
+
+var (
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrFilterRequired = errors.New("tag or campaign required")
+)
+
+func (s *URLService) ListURLs(ctx context.Context, filter domain.LinkFilter, cursor string, limit int) (*domain.URLPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	// One extra row tells whether another page follows.
+	urls, err := s.repo.List(ctx, filter, ownerOf(ctx), after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+
+	page := &domain.URLPage{URLs: make([]domain.URLInfo, 0, min(len(urls), limit))}
+	if len(urls) > limit {
+		urls = urls[:limit]
+		page.NextCursor = encodeCursor(urls[limit-1].ShortCode)
+	}
+
+	now := time.Now()
+	for _, u := range urls {
+		page.URLs = append(page.URLs, *s.info(u, now))
+	}
+	return page, nil
+}
+
+// An empty filter is refused so a missing query parameter cannot switch off
+// every link.
+func (s *URLService) SetURLsActive(ctx context.Context, filter domain.LinkFilter, active bool) (int, error) {
+	if filter.Empty() {
+		return 0, ErrFilterRequired
+	}
+
+	codes, err := s.repo.SetActiveByFilter(ctx, filter, active, ownerOf(ctx), actorOf(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to update urls: %w", err)
+	}
+
+	now := time.Now()
+	for _, code := range codes {
+		s.fills.invalidate(s.cache, code, nil)
+		s.publish(domain.EventLinkUpdated, domain.LinkEvent{ShortCode: code, Active: &active}, now)
+	}
+
+	metric := "urls_deactivated"
+	if active {
+		metric = "urls_reactivated"
+	}
+	if len(codes) > 0 {
+		s.recorder.RecordBusiness(now, metric, float64(len(codes)), labelsBulk)
+	}
+
+	return len(codes), nil
+}
+
+func (s *URLService) DeleteURLs(ctx context.Context, filter domain.LinkFilter) (int, error) {
+	if filter.Empty() {
+		return 0, ErrFilterRequired
+	}
+
+	codes, err := s.repo.DeleteByFilter(ctx, filter, ownerOf(ctx), actorOf(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete urls: %w", err)
+	}
+
+	now := time.Now()
+	for _, code := range codes {
+		s.fills.invalidate(s.cache, code, nil)
+		s.publish(domain.EventLinkDeleted, domain.LinkEvent{ShortCode: code}, now)
+	}
+	if len(codes) > 0 {
+		s.recorder.RecordBusiness(now, "urls_deleted", float64(len(codes)), labelsBulk)
+	}
+
+	return len(codes), nil
+}
+
+func tagsOf(req *domain.CreateURLRequest) []string {
+	if len(req.Tags) == 0 {
+		return nil
+	}
+	return slices.Compact(slices.Sorted(slices.Values(req.Tags)))
+}
+
+// Cursors are opaque to clients so the keyset column can change later.
+func encodeCursor(shortCode string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(shortCode))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	after, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	return string(after), nil
+}
+
+func encodeIDCursor(id int64) string {
+	return encodeCursor(strconv.FormatInt(id, 10))
+}
+
+func decodeIDCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
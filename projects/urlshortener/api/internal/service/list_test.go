@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func newListService(t *testing.T, repo *mocks.MockRepository, cache *mocks.MockCache, recorder *mocks.MockBusinessRecorder) *service.URLService {
+	return service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+}
+
+func TestListURLs_Pages(t *testing.T) {
+	filter := domain.LinkFilter{Tag: "black-friday"}
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().List(mock.Anything, filter, (*int64)(nil), "", 3).Return([]*domain.URL{
+		{ShortCode: "a", OriginalURL: "https://example.com/a", Active: true, Tags: []string{"black-friday"}},
+		{ShortCode: "b", OriginalURL: "https://example.com/b", Active: true, PasswordHash: "hash"},
+		{ShortCode: "c", OriginalURL: "https://example.com/c", Active: true},
+	}, nil)
+
+	svc := newListService(t, repo, mocks.NewMockCache(t), mocks.NewMockBusinessRecorder(t))
+
+	page, err := svc.ListURLs(context.Background(), filter, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 2)
+	assert.Equal(t, "http://short.url/a", page.URLs[0].ShortURL)
+	assert.Equal(t, []string{"black-friday"}, page.URLs[0].Tags)
+	assert.Empty(t, page.URLs[1].OriginalURL, "protected destinations must stay hidden")
+	require.NotEmpty(t, page.NextCursor)
+
+	// The cursor resumes after the last code of the page.
+	repo.EXPECT().List(mock.Anything, filter, (*int64)(nil), "b", 3).Return([]*domain.URL{
+		{ShortCode: "c", OriginalURL: "https://example.com/c", Active: true},
+	}, nil)
+
+	page, err = svc.ListURLs(context.Background(), filter, page.NextCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestListURLs_Limit(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().List(mock.Anything, domain.LinkFilter{}, (*int64)(nil), "", 51).Return(nil, nil).Once()
+	repo.EXPECT().List(mock.Anything, domain.LinkFilter{}, (*int64)(nil), "", 201).Return(nil, nil).Once()
+
+	svc := newListService(t, repo, mocks.NewMockCache(t), mocks.NewMockBusinessRecorder(t))
+
+	page, err := svc.ListURLs(context.Background(), domain.LinkFilter{}, "", 0)
+	require.NoError(t, err)
+	assert.NotNil(t, page.URLs, "an empty page must encode as []")
+
+	_, err = svc.ListURLs(context.Background(), domain.LinkFilter{}, "", 10000)
+	require.NoError(t, err)
+}
+
+func TestListURLs_InvalidCursor(t *testing.T) {
+	svc := newListService(t, mocks.NewMockRepository(t), mocks.NewMockCache(t), mocks.NewMockBusinessRecorder(t))
+
+	_, err := svc.ListURLs(context.Background(), domain.LinkFilter{}, "not a cursor!", 10)
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+}
+
+func TestListURLs_RepositoryError(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().List(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	svc := newListService(t, repo, mocks.NewMockCache(t), mocks.NewMockBusinessRecorder(t))
+
+	_, err := svc.ListURLs(context.Background(), domain.LinkFilter{}, base64.RawURLEncoding.EncodeToString([]byte("abc")), 10)
+	assert.Error(t, err)
+}
+
+func TestSetURLsActive_DeactivatesCampaign(t *testing.T) {
+	filter := domain.LinkFilter{Campaign: "Black Friday"}
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().SetActiveByFilter(mock.Anything, filter, false, (*int64)(nil), mock.Anything).Return([]string{"a", "b"}, nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("a").Return()
+	cache.EXPECT().Delete("b").Return()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deactivated", float64(2), []byte(`{"method":"bulk"}`)).Return()
+
+	svc := newListService(t, repo, cache, recorder)
+
+	n, err := svc.SetURLsActive(context.Background(), filter, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestSetURLsActive_NothingChanged(t *testing.T) {
+	filter := domain.LinkFilter{Tag: "spring"}
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().SetActiveByFilter(mock.Anything, filter, true, (*int64)(nil), mock.Anything).Return(nil, nil)
+
+	svc := newListService(t, repo, mocks.NewMockCache(t), mocks.NewMockBusinessRecorder(t))
+
+	n, err := svc.SetURLsActive(context.Background(), filter, true)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestBulkOperations_RequireFilter(t *testing.T) {
+	svc := newListService(t, mocks.NewMockRepository(t), mocks.NewMockCache(t), mocks.NewMockBusinessRecorder(t))
+
+	_, err := svc.SetURLsActive(context.Background(), domain.LinkFilter{}, false)
+	assert.ErrorIs(t, err, service.ErrFilterRequired)
+
+	_, err = svc.DeleteURLs(context.Background(), domain.LinkFilter{})
+	assert.ErrorIs(t, err, service.ErrFilterRequired)
+}
+
+func TestDeleteURLs(t *testing.T) {
+	filter := domain.LinkFilter{Tag: "spring", Campaign: "Launch"}
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().DeleteByFilter(mock.Anything, filter, (*int64)(nil), mock.Anything).Return([]string{"a"}, nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("a").Return()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deleted", float64(1), []byte(`{"method":"bulk"}`)).Return()
+
+	svc := newListService(t, repo, cache, recorder)
+
+	n, err := svc.DeleteURLs(context.Background(), filter)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestCreateShortURL_TagsNormalized(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(row repository.URLRow) bool {
+		return assert.ObjectsAreEqual([]string{"bf", "email"}, row.Tags) && row.Campaign == "Black Friday"
+	}), mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	// Tagged links are never deduplicated, or the tags would be lost.
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{
+		URL:      "https://example.com",
+		Dedupe:   true,
+		Tags:     []string{"email", "bf", "email"},
+		Campaign: "Black Friday",
+	})
+	require.NoError(t, err)
+}
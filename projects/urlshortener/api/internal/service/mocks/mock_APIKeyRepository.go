@@ -0,0 +1,203 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "urlshortener/internal/repository"
+)
+
+// MockAPIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+type MockAPIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepository_Expecter {
+	return &MockAPIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, k
+func (_m *MockAPIKeyRepository) Create(ctx context.Context, k repository.APIKeyRow) (*domain.APIKey, error) {
+	ret := _m.Called(ctx, k)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.APIKeyRow) (*domain.APIKey, error)); ok {
+		return rf(ctx, k)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.APIKeyRow) *domain.APIKey); ok {
+		r0 = rf(ctx, k)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.APIKeyRow) error); ok {
+		r1 = rf(ctx, k)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAPIKeyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - k repository.APIKeyRow
+func (_e *MockAPIKeyRepository_Expecter) Create(ctx interface{}, k interface{}) *MockAPIKeyRepository_Create_Call {
+	return &MockAPIKeyRepository_Create_Call{Call: _e.mock.On("Create", ctx, k)}
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Run(run func(ctx context.Context, k repository.APIKeyRow)) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.APIKeyRow))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Return(_a0 *domain.APIKey, _a1 error) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) RunAndReturn(run func(context.Context, repository.APIKeyRow) (*domain.APIKey, error)) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockAPIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.APIKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAPIKeyRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAPIKeyRepository_Expecter) List(ctx interface{}) *MockAPIKeyRepository_List_Call {
+	return &MockAPIKeyRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockAPIKeyRepository_List_Call) Run(run func(ctx context.Context)) *MockAPIKeyRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_List_Call) Return(_a0 []domain.APIKey, _a1 error) *MockAPIKeyRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_List_Call) RunAndReturn(run func(context.Context) ([]domain.APIKey, error)) *MockAPIKeyRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *MockAPIKeyRepository) Revoke(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockAPIKeyRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockAPIKeyRepository_Expecter) Revoke(ctx interface{}, id interface{}) *MockAPIKeyRepository_Revoke_Call {
+	return &MockAPIKeyRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id)}
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Run(run func(ctx context.Context, id int64)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Return(_a0 error) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) RunAndReturn(run func(context.Context, int64) error) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyRepository creates a new instance of MockAPIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,90 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockAttemptLimiter is an autogenerated mock type for the AttemptLimiter type
+type MockAttemptLimiter struct {
+	mock.Mock
+}
+
+type MockAttemptLimiter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttemptLimiter) EXPECT() *MockAttemptLimiter_Expecter {
+	return &MockAttemptLimiter_Expecter{mock: &_m.Mock}
+}
+
+// Reserve provides a mock function with given fields: shortCode
+func (_m *MockAttemptLimiter) Reserve(shortCode string) (func(), bool) {
+	ret := _m.Called(shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 func()
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (func(), bool)); ok {
+		return rf(shortCode)
+	}
+	if rf, ok := ret.Get(0).(func(string) func()); ok {
+		r0 = rf(shortCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(shortCode)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockAttemptLimiter_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type MockAttemptLimiter_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - shortCode string
+func (_e *MockAttemptLimiter_Expecter) Reserve(shortCode interface{}) *MockAttemptLimiter_Reserve_Call {
+	return &MockAttemptLimiter_Reserve_Call{Call: _e.mock.On("Reserve", shortCode)}
+}
+
+func (_c *MockAttemptLimiter_Reserve_Call) Run(run func(shortCode string)) *MockAttemptLimiter_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAttemptLimiter_Reserve_Call) Return(refund func(), ok bool) *MockAttemptLimiter_Reserve_Call {
+	_c.Call.Return(refund, ok)
+	return _c
+}
+
+func (_c *MockAttemptLimiter_Reserve_Call) RunAndReturn(run func(string) (func(), bool)) *MockAttemptLimiter_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttemptLimiter creates a new instance of MockAttemptLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttemptLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttemptLimiter {
+	mock := &MockAttemptLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
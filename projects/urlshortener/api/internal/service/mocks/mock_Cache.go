@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockCache is an autogenerated mock type for the Cache type
 type MockCache struct {
@@ -17,23 +21,58 @@ func (_m *MockCache) EXPECT() *MockCache_Expecter {
 	return &MockCache_Expecter{mock: &_m.Mock}
 }
 
+// Delete provides a mock function with given fields: shortCode
+func (_m *MockCache) Delete(shortCode string) {
+	_m.Called(shortCode)
+}
+
+// MockCache_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCache_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - shortCode string
+func (_e *MockCache_Expecter) Delete(shortCode interface{}) *MockCache_Delete_Call {
+	return &MockCache_Delete_Call{Call: _e.mock.On("Delete", shortCode)}
+}
+
+func (_c *MockCache_Delete_Call) Run(run func(shortCode string)) *MockCache_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_Delete_Call) Return() *MockCache_Delete_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockCache_Delete_Call) RunAndReturn(run func(string)) *MockCache_Delete_Call {
+	_c.Run(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: shortCode
-func (_m *MockCache) Get(shortCode string) (string, bool) {
+func (_m *MockCache) Get(shortCode string) (*domain.URL, bool) {
 	ret := _m.Called(shortCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 string
+	var r0 *domain.URL
 	var r1 bool
-	if rf, ok := ret.Get(0).(func(string) (string, bool)); ok {
+	if rf, ok := ret.Get(0).(func(string) (*domain.URL, bool)); ok {
 		return rf(shortCode)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
+	if rf, ok := ret.Get(0).(func(string) *domain.URL); ok {
 		r0 = rf(shortCode)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.URL)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) bool); ok {
@@ -63,19 +102,19 @@ func (_c *MockCache_Get_Call) Run(run func(shortCode string)) *MockCache_Get_Cal
 	return _c
 }
 
-func (_c *MockCache_Get_Call) Return(_a0 string, _a1 bool) *MockCache_Get_Call {
+func (_c *MockCache_Get_Call) Return(_a0 *domain.URL, _a1 bool) *MockCache_Get_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockCache_Get_Call) RunAndReturn(run func(string) (string, bool)) *MockCache_Get_Call {
+func (_c *MockCache_Get_Call) RunAndReturn(run func(string) (*domain.URL, bool)) *MockCache_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Set provides a mock function with given fields: shortCode, originalURL
-func (_m *MockCache) Set(shortCode string, originalURL string) {
-	_m.Called(shortCode, originalURL)
+// Set provides a mock function with given fields: u
+func (_m *MockCache) Set(u *domain.URL) {
+	_m.Called(u)
 }
 
 // MockCache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
@@ -84,15 +123,14 @@ type MockCache_Set_Call struct {
 }
 
 // Set is a helper method to define mock.On call
-//   - shortCode string
-//   - originalURL string
-func (_e *MockCache_Expecter) Set(shortCode interface{}, originalURL interface{}) *MockCache_Set_Call {
-	return &MockCache_Set_Call{Call: _e.mock.On("Set", shortCode, originalURL)}
+//   - u *domain.URL
+func (_e *MockCache_Expecter) Set(u interface{}) *MockCache_Set_Call {
+	return &MockCache_Set_Call{Call: _e.mock.On("Set", u)}
 }
 
-func (_c *MockCache_Set_Call) Run(run func(shortCode string, originalURL string)) *MockCache_Set_Call {
+func (_c *MockCache_Set_Call) Run(run func(u *domain.URL)) *MockCache_Set_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(*domain.URL))
 	})
 	return _c
 }
@@ -102,7 +140,7 @@ func (_c *MockCache_Set_Call) Return() *MockCache_Set_Call {
 	return _c
 }
 
-func (_c *MockCache_Set_Call) RunAndReturn(run func(string, string)) *MockCache_Set_Call {
+func (_c *MockCache_Set_Call) RunAndReturn(run func(*domain.URL)) *MockCache_Set_Call {
 	_c.Run(run)
 	return _c
 }
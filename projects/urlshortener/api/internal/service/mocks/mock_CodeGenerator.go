@@ -73,6 +73,52 @@ func (_c *MockCodeGenerator_Generate_Call) RunAndReturn(run func(uint) (string,
 	return _c
 }
 
+// IsGenerated provides a mock function with given fields: code
+func (_m *MockCodeGenerator) IsGenerated(code string) bool {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsGenerated")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockCodeGenerator_IsGenerated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsGenerated'
+type MockCodeGenerator_IsGenerated_Call struct {
+	*mock.Call
+}
+
+// IsGenerated is a helper method to define mock.On call
+//   - code string
+func (_e *MockCodeGenerator_Expecter) IsGenerated(code interface{}) *MockCodeGenerator_IsGenerated_Call {
+	return &MockCodeGenerator_IsGenerated_Call{Call: _e.mock.On("IsGenerated", code)}
+}
+
+func (_c *MockCodeGenerator_IsGenerated_Call) Run(run func(code string)) *MockCodeGenerator_IsGenerated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCodeGenerator_IsGenerated_Call) Return(_a0 bool) *MockCodeGenerator_IsGenerated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCodeGenerator_IsGenerated_Call) RunAndReturn(run func(string) bool) *MockCodeGenerator_IsGenerated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockCodeGenerator creates a new instance of MockCodeGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCodeGenerator(t interface {
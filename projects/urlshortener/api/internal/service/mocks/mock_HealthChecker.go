@@ -0,0 +1,78 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockHealthChecker is an autogenerated mock type for the HealthChecker type
+type MockHealthChecker struct {
+	mock.Mock
+}
+
+type MockHealthChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHealthChecker) EXPECT() *MockHealthChecker_Expecter {
+	return &MockHealthChecker_Expecter{mock: &_m.Mock}
+}
+
+// Healthy provides a mock function with given fields: url
+func (_m *MockHealthChecker) Healthy(url string) bool {
+	ret := _m.Called(url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Healthy")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(url)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockHealthChecker_Healthy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Healthy'
+type MockHealthChecker_Healthy_Call struct {
+	*mock.Call
+}
+
+// Healthy is a helper method to define mock.On call
+//   - url string
+func (_e *MockHealthChecker_Expecter) Healthy(url interface{}) *MockHealthChecker_Healthy_Call {
+	return &MockHealthChecker_Healthy_Call{Call: _e.mock.On("Healthy", url)}
+}
+
+func (_c *MockHealthChecker_Healthy_Call) Run(run func(url string)) *MockHealthChecker_Healthy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockHealthChecker_Healthy_Call) Return(_a0 bool) *MockHealthChecker_Healthy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHealthChecker_Healthy_Call) RunAndReturn(run func(string) bool) *MockHealthChecker_Healthy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHealthChecker creates a new instance of MockHealthChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHealthChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHealthChecker {
+	mock := &MockHealthChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
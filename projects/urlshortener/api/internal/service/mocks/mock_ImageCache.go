@@ -0,0 +1,124 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockImageCache is an autogenerated mock type for the ImageCache type
+type MockImageCache struct {
+	mock.Mock
+}
+
+type MockImageCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImageCache) EXPECT() *MockImageCache_Expecter {
+	return &MockImageCache_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: key
+func (_m *MockImageCache) Get(key string) ([]byte, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []byte
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) ([]byte, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockImageCache_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockImageCache_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - key string
+func (_e *MockImageCache_Expecter) Get(key interface{}) *MockImageCache_Get_Call {
+	return &MockImageCache_Get_Call{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *MockImageCache_Get_Call) Run(run func(key string)) *MockImageCache_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockImageCache_Get_Call) Return(_a0 []byte, _a1 bool) *MockImageCache_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockImageCache_Get_Call) RunAndReturn(run func(string) ([]byte, bool)) *MockImageCache_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: key, img
+func (_m *MockImageCache) Set(key string, img []byte) {
+	_m.Called(key, img)
+}
+
+// MockImageCache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockImageCache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - key string
+//   - img []byte
+func (_e *MockImageCache_Expecter) Set(key interface{}, img interface{}) *MockImageCache_Set_Call {
+	return &MockImageCache_Set_Call{Call: _e.mock.On("Set", key, img)}
+}
+
+func (_c *MockImageCache_Set_Call) Run(run func(key string, img []byte)) *MockImageCache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockImageCache_Set_Call) Return() *MockImageCache_Set_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockImageCache_Set_Call) RunAndReturn(run func(string, []byte)) *MockImageCache_Set_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockImageCache creates a new instance of MockImageCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImageCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImageCache {
+	mock := &MockImageCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
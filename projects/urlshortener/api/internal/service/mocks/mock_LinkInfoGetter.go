@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLinkInfoGetter is an autogenerated mock type for the LinkInfoGetter type
+type MockLinkInfoGetter struct {
+	mock.Mock
+}
+
+type MockLinkInfoGetter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLinkInfoGetter) EXPECT() *MockLinkInfoGetter_Expecter {
+	return &MockLinkInfoGetter_Expecter{mock: &_m.Mock}
+}
+
+// GetURLInfo provides a mock function with given fields: ctx, shortCode
+func (_m *MockLinkInfoGetter) GetURLInfo(ctx context.Context, shortCode string) (*domain.URLInfo, error) {
+	ret := _m.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLInfo")
+	}
+
+	var r0 *domain.URLInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.URLInfo, error)); ok {
+		return rf(ctx, shortCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.URLInfo); ok {
+		r0 = rf(ctx, shortCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.URLInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLinkInfoGetter_GetURLInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLInfo'
+type MockLinkInfoGetter_GetURLInfo_Call struct {
+	*mock.Call
+}
+
+// GetURLInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+func (_e *MockLinkInfoGetter_Expecter) GetURLInfo(ctx interface{}, shortCode interface{}) *MockLinkInfoGetter_GetURLInfo_Call {
+	return &MockLinkInfoGetter_GetURLInfo_Call{Call: _e.mock.On("GetURLInfo", ctx, shortCode)}
+}
+
+func (_c *MockLinkInfoGetter_GetURLInfo_Call) Run(run func(ctx context.Context, shortCode string)) *MockLinkInfoGetter_GetURLInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLinkInfoGetter_GetURLInfo_Call) Return(_a0 *domain.URLInfo, _a1 error) *MockLinkInfoGetter_GetURLInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLinkInfoGetter_GetURLInfo_Call) RunAndReturn(run func(context.Context, string) (*domain.URLInfo, error)) *MockLinkInfoGetter_GetURLInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLinkInfoGetter creates a new instance of MockLinkInfoGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLinkInfoGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLinkInfoGetter {
+	mock := &MockLinkInfoGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,9 +4,11 @@ package mocks
 
 import (
 	context "context"
-	repository "urlshortener/internal/repository"
+	domain "urlshortener/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	repository "urlshortener/internal/repository"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -22,17 +24,17 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: ctx, shortCode, originalURL
-func (_m *MockRepository) Create(ctx context.Context, shortCode string, originalURL string) error {
-	ret := _m.Called(ctx, shortCode, originalURL)
+// Create provides a mock function with given fields: ctx, u, actor
+func (_m *MockRepository) Create(ctx context.Context, u repository.URLRow, actor domain.Actor) error {
+	ret := _m.Called(ctx, u, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, shortCode, originalURL)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.URLRow, domain.Actor) error); ok {
+		r0 = rf(ctx, u, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,15 +49,15 @@ type MockRepository_Create_Call struct {
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - shortCode string
-//   - originalURL string
-func (_e *MockRepository_Expecter) Create(ctx interface{}, shortCode interface{}, originalURL interface{}) *MockRepository_Create_Call {
-	return &MockRepository_Create_Call{Call: _e.mock.On("Create", ctx, shortCode, originalURL)}
+//   - u repository.URLRow
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) Create(ctx interface{}, u interface{}, actor interface{}) *MockRepository_Create_Call {
+	return &MockRepository_Create_Call{Call: _e.mock.On("Create", ctx, u, actor)}
 }
 
-func (_c *MockRepository_Create_Call) Run(run func(ctx context.Context, shortCode string, originalURL string)) *MockRepository_Create_Call {
+func (_c *MockRepository_Create_Call) Run(run func(ctx context.Context, u repository.URLRow, actor domain.Actor)) *MockRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(repository.URLRow), args[2].(domain.Actor))
 	})
 	return _c
 }
@@ -65,22 +67,22 @@ func (_c *MockRepository_Create_Call) Return(_a0 error) *MockRepository_Create_C
 	return _c
 }
 
-func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_Create_Call {
+func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, repository.URLRow, domain.Actor) error) *MockRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateBatch provides a mock function with given fields: ctx, urls
-func (_m *MockRepository) CreateBatch(ctx context.Context, urls []repository.URLRow) error {
-	ret := _m.Called(ctx, urls)
+// CreateBatch provides a mock function with given fields: ctx, urls, actor
+func (_m *MockRepository) CreateBatch(ctx context.Context, urls []repository.URLRow, actor domain.Actor) error {
+	ret := _m.Called(ctx, urls, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBatch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []repository.URLRow) error); ok {
-		r0 = rf(ctx, urls)
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.URLRow, domain.Actor) error); ok {
+		r0 = rf(ctx, urls, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -96,13 +98,14 @@ type MockRepository_CreateBatch_Call struct {
 // CreateBatch is a helper method to define mock.On call
 //   - ctx context.Context
 //   - urls []repository.URLRow
-func (_e *MockRepository_Expecter) CreateBatch(ctx interface{}, urls interface{}) *MockRepository_CreateBatch_Call {
-	return &MockRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, urls)}
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) CreateBatch(ctx interface{}, urls interface{}, actor interface{}) *MockRepository_CreateBatch_Call {
+	return &MockRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, urls, actor)}
 }
 
-func (_c *MockRepository_CreateBatch_Call) Run(run func(ctx context.Context, urls []repository.URLRow)) *MockRepository_CreateBatch_Call {
+func (_c *MockRepository_CreateBatch_Call) Run(run func(ctx context.Context, urls []repository.URLRow, actor domain.Actor)) *MockRepository_CreateBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]repository.URLRow))
+		run(args[0].(context.Context), args[1].([]repository.URLRow), args[2].(domain.Actor))
 	})
 	return _c
 }
@@ -112,28 +115,249 @@ func (_c *MockRepository_CreateBatch_Call) Return(_a0 error) *MockRepository_Cre
 	return _c
 }
 
-func (_c *MockRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, []repository.URLRow) error) *MockRepository_CreateBatch_Call {
+func (_c *MockRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, []repository.URLRow, domain.Actor) error) *MockRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, shortCode, owner, actor
+func (_m *MockRepository) Delete(ctx context.Context, shortCode string, owner *int64, actor domain.Actor) error {
+	ret := _m.Called(ctx, shortCode, owner, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64, domain.Actor) error); ok {
+		r0 = rf(ctx, shortCode, owner, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - owner *int64
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) Delete(ctx interface{}, shortCode interface{}, owner interface{}, actor interface{}) *MockRepository_Delete_Call {
+	return &MockRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, shortCode, owner, actor)}
+}
+
+func (_c *MockRepository_Delete_Call) Run(run func(ctx context.Context, shortCode string, owner *int64, actor domain.Actor)) *MockRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*int64), args[3].(domain.Actor))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Delete_Call) Return(_a0 error) *MockRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Delete_Call) RunAndReturn(run func(context.Context, string, *int64, domain.Actor) error) *MockRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByFilter provides a mock function with given fields: ctx, filter, owner, actor
+func (_m *MockRepository) DeleteByFilter(ctx context.Context, filter domain.LinkFilter, owner *int64, actor domain.Actor) ([]string, error) {
+	ret := _m.Called(ctx, filter, owner, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByFilter")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, *int64, domain.Actor) ([]string, error)); ok {
+		return rf(ctx, filter, owner, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, *int64, domain.Actor) []string); ok {
+		r0 = rf(ctx, filter, owner, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LinkFilter, *int64, domain.Actor) error); ok {
+		r1 = rf(ctx, filter, owner, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_DeleteByFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByFilter'
+type MockRepository_DeleteByFilter_Call struct {
+	*mock.Call
+}
+
+// DeleteByFilter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LinkFilter
+//   - owner *int64
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) DeleteByFilter(ctx interface{}, filter interface{}, owner interface{}, actor interface{}) *MockRepository_DeleteByFilter_Call {
+	return &MockRepository_DeleteByFilter_Call{Call: _e.mock.On("DeleteByFilter", ctx, filter, owner, actor)}
+}
+
+func (_c *MockRepository_DeleteByFilter_Call) Run(run func(ctx context.Context, filter domain.LinkFilter, owner *int64, actor domain.Actor)) *MockRepository_DeleteByFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LinkFilter), args[2].(*int64), args[3].(domain.Actor))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteByFilter_Call) Return(_a0 []string, _a1 error) *MockRepository_DeleteByFilter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_DeleteByFilter_Call) RunAndReturn(run func(context.Context, domain.LinkFilter, *int64, domain.Actor) ([]string, error)) *MockRepository_DeleteByFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function with given fields: ctx, owner, after, fn
+func (_m *MockRepository) Export(ctx context.Context, owner *int64, after string, fn func(*domain.URL) error) error {
+	ret := _m.Called(ctx, owner, after, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, string, func(*domain.URL) error) error); ok {
+		r0 = rf(ctx, owner, after, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockRepository_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner *int64
+//   - after string
+//   - fn func(*domain.URL) error
+func (_e *MockRepository_Expecter) Export(ctx interface{}, owner interface{}, after interface{}, fn interface{}) *MockRepository_Export_Call {
+	return &MockRepository_Export_Call{Call: _e.mock.On("Export", ctx, owner, after, fn)}
+}
+
+func (_c *MockRepository_Export_Call) Run(run func(ctx context.Context, owner *int64, after string, fn func(*domain.URL) error)) *MockRepository_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*int64), args[2].(string), args[3].(func(*domain.URL) error))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Export_Call) Return(_a0 error) *MockRepository_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Export_Call) RunAndReturn(run func(context.Context, *int64, string, func(*domain.URL) error) error) *MockRepository_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByOriginalURLs provides a mock function with given fields: ctx, urls, owner
+func (_m *MockRepository) FindByOriginalURLs(ctx context.Context, urls []string, owner *int64) (map[string]string, error) {
+	ret := _m.Called(ctx, urls, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOriginalURLs")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *int64) (map[string]string, error)); ok {
+		return rf(ctx, urls, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *int64) map[string]string); ok {
+		r0 = rf(ctx, urls, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, *int64) error); ok {
+		r1 = rf(ctx, urls, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_FindByOriginalURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOriginalURLs'
+type MockRepository_FindByOriginalURLs_Call struct {
+	*mock.Call
+}
+
+// FindByOriginalURLs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - urls []string
+//   - owner *int64
+func (_e *MockRepository_Expecter) FindByOriginalURLs(ctx interface{}, urls interface{}, owner interface{}) *MockRepository_FindByOriginalURLs_Call {
+	return &MockRepository_FindByOriginalURLs_Call{Call: _e.mock.On("FindByOriginalURLs", ctx, urls, owner)}
+}
+
+func (_c *MockRepository_FindByOriginalURLs_Call) Run(run func(ctx context.Context, urls []string, owner *int64)) *MockRepository_FindByOriginalURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(*int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_FindByOriginalURLs_Call) Return(_a0 map[string]string, _a1 error) *MockRepository_FindByOriginalURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_FindByOriginalURLs_Call) RunAndReturn(run func(context.Context, []string, *int64) (map[string]string, error)) *MockRepository_FindByOriginalURLs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // FindByShortCode provides a mock function with given fields: ctx, shortCode
-func (_m *MockRepository) FindByShortCode(ctx context.Context, shortCode string) (string, error) {
+func (_m *MockRepository) FindByShortCode(ctx context.Context, shortCode string) (*domain.URL, error) {
 	ret := _m.Called(ctx, shortCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByShortCode")
 	}
 
-	var r0 string
+	var r0 *domain.URL
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.URL, error)); ok {
 		return rf(ctx, shortCode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.URL); ok {
 		r0 = rf(ctx, shortCode)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.URL)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -164,12 +388,195 @@ func (_c *MockRepository_FindByShortCode_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepository_FindByShortCode_Call) Return(_a0 string, _a1 error) *MockRepository_FindByShortCode_Call {
+func (_c *MockRepository_FindByShortCode_Call) Return(_a0 *domain.URL, _a1 error) *MockRepository_FindByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_FindByShortCode_Call) RunAndReturn(run func(context.Context, string) (*domain.URL, error)) *MockRepository_FindByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByShortCodes provides a mock function with given fields: ctx, shortCodes
+func (_m *MockRepository) FindByShortCodes(ctx context.Context, shortCodes []string) ([]*domain.URL, error) {
+	ret := _m.Called(ctx, shortCodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByShortCodes")
+	}
+
+	var r0 []*domain.URL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*domain.URL, error)); ok {
+		return rf(ctx, shortCodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*domain.URL); ok {
+		r0 = rf(ctx, shortCodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.URL)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, shortCodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_FindByShortCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByShortCodes'
+type MockRepository_FindByShortCodes_Call struct {
+	*mock.Call
+}
+
+// FindByShortCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCodes []string
+func (_e *MockRepository_Expecter) FindByShortCodes(ctx interface{}, shortCodes interface{}) *MockRepository_FindByShortCodes_Call {
+	return &MockRepository_FindByShortCodes_Call{Call: _e.mock.On("FindByShortCodes", ctx, shortCodes)}
+}
+
+func (_c *MockRepository_FindByShortCodes_Call) Run(run func(ctx context.Context, shortCodes []string)) *MockRepository_FindByShortCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_FindByShortCodes_Call) Return(_a0 []*domain.URL, _a1 error) *MockRepository_FindByShortCodes_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_FindByShortCode_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockRepository_FindByShortCode_Call {
+func (_c *MockRepository_FindByShortCodes_Call) RunAndReturn(run func(context.Context, []string) ([]*domain.URL, error)) *MockRepository_FindByShortCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function with given fields: ctx, shortCode, owner, after, limit
+func (_m *MockRepository) History(ctx context.Context, shortCode string, owner *int64, after int64, limit int) ([]domain.AuditEntry, error) {
+	ret := _m.Called(ctx, shortCode, owner, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64, int64, int) ([]domain.AuditEntry, error)); ok {
+		return rf(ctx, shortCode, owner, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64, int64, int) []domain.AuditEntry); ok {
+		r0 = rf(ctx, shortCode, owner, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int64, int64, int) error); ok {
+		r1 = rf(ctx, shortCode, owner, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockRepository_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - owner *int64
+//   - after int64
+//   - limit int
+func (_e *MockRepository_Expecter) History(ctx interface{}, shortCode interface{}, owner interface{}, after interface{}, limit interface{}) *MockRepository_History_Call {
+	return &MockRepository_History_Call{Call: _e.mock.On("History", ctx, shortCode, owner, after, limit)}
+}
+
+func (_c *MockRepository_History_Call) Run(run func(ctx context.Context, shortCode string, owner *int64, after int64, limit int)) *MockRepository_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*int64), args[3].(int64), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_History_Call) Return(_a0 []domain.AuditEntry, _a1 error) *MockRepository_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_History_Call) RunAndReturn(run func(context.Context, string, *int64, int64, int) ([]domain.AuditEntry, error)) *MockRepository_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter, owner, after, limit
+func (_m *MockRepository) List(ctx context.Context, filter domain.LinkFilter, owner *int64, after string, limit int) ([]*domain.URL, error) {
+	ret := _m.Called(ctx, filter, owner, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.URL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, *int64, string, int) ([]*domain.URL, error)); ok {
+		return rf(ctx, filter, owner, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, *int64, string, int) []*domain.URL); ok {
+		r0 = rf(ctx, filter, owner, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.URL)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LinkFilter, *int64, string, int) error); ok {
+		r1 = rf(ctx, filter, owner, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LinkFilter
+//   - owner *int64
+//   - after string
+//   - limit int
+func (_e *MockRepository_Expecter) List(ctx interface{}, filter interface{}, owner interface{}, after interface{}, limit interface{}) *MockRepository_List_Call {
+	return &MockRepository_List_Call{Call: _e.mock.On("List", ctx, filter, owner, after, limit)}
+}
+
+func (_c *MockRepository_List_Call) Run(run func(ctx context.Context, filter domain.LinkFilter, owner *int64, after string, limit int)) *MockRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LinkFilter), args[2].(*int64), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_List_Call) Return(_a0 []*domain.URL, _a1 error) *MockRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_List_Call) RunAndReturn(run func(context.Context, domain.LinkFilter, *int64, string, int) ([]*domain.URL, error)) *MockRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -289,6 +696,239 @@ func (_c *MockRepository_NextIDs_Call) RunAndReturn(run func(context.Context, in
 	return _c
 }
 
+// SetActive provides a mock function with given fields: ctx, shortCode, active, owner, actor
+func (_m *MockRepository) SetActive(ctx context.Context, shortCode string, active bool, owner *int64, actor domain.Actor) error {
+	ret := _m.Called(ctx, shortCode, active, owner, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *int64, domain.Actor) error); ok {
+		r0 = rf(ctx, shortCode, active, owner, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActive'
+type MockRepository_SetActive_Call struct {
+	*mock.Call
+}
+
+// SetActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - active bool
+//   - owner *int64
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) SetActive(ctx interface{}, shortCode interface{}, active interface{}, owner interface{}, actor interface{}) *MockRepository_SetActive_Call {
+	return &MockRepository_SetActive_Call{Call: _e.mock.On("SetActive", ctx, shortCode, active, owner, actor)}
+}
+
+func (_c *MockRepository_SetActive_Call) Run(run func(ctx context.Context, shortCode string, active bool, owner *int64, actor domain.Actor)) *MockRepository_SetActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(*int64), args[4].(domain.Actor))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetActive_Call) Return(_a0 error) *MockRepository_SetActive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetActive_Call) RunAndReturn(run func(context.Context, string, bool, *int64, domain.Actor) error) *MockRepository_SetActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetActiveByFilter provides a mock function with given fields: ctx, filter, active, owner, actor
+func (_m *MockRepository) SetActiveByFilter(ctx context.Context, filter domain.LinkFilter, active bool, owner *int64, actor domain.Actor) ([]string, error) {
+	ret := _m.Called(ctx, filter, active, owner, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActiveByFilter")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, bool, *int64, domain.Actor) ([]string, error)); ok {
+		return rf(ctx, filter, active, owner, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LinkFilter, bool, *int64, domain.Actor) []string); ok {
+		r0 = rf(ctx, filter, active, owner, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.LinkFilter, bool, *int64, domain.Actor) error); ok {
+		r1 = rf(ctx, filter, active, owner, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_SetActiveByFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActiveByFilter'
+type MockRepository_SetActiveByFilter_Call struct {
+	*mock.Call
+}
+
+// SetActiveByFilter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.LinkFilter
+//   - active bool
+//   - owner *int64
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) SetActiveByFilter(ctx interface{}, filter interface{}, active interface{}, owner interface{}, actor interface{}) *MockRepository_SetActiveByFilter_Call {
+	return &MockRepository_SetActiveByFilter_Call{Call: _e.mock.On("SetActiveByFilter", ctx, filter, active, owner, actor)}
+}
+
+func (_c *MockRepository_SetActiveByFilter_Call) Run(run func(ctx context.Context, filter domain.LinkFilter, active bool, owner *int64, actor domain.Actor)) *MockRepository_SetActiveByFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.LinkFilter), args[2].(bool), args[3].(*int64), args[4].(domain.Actor))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetActiveByFilter_Call) Return(_a0 []string, _a1 error) *MockRepository_SetActiveByFilter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_SetActiveByFilter_Call) RunAndReturn(run func(context.Context, domain.LinkFilter, bool, *int64, domain.Actor) ([]string, error)) *MockRepository_SetActiveByFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakenShortCodes provides a mock function with given fields: ctx, shortCodes
+func (_m *MockRepository) TakenShortCodes(ctx context.Context, shortCodes []string) ([]string, error) {
+	ret := _m.Called(ctx, shortCodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakenShortCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, shortCodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, shortCodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, shortCodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_TakenShortCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakenShortCodes'
+type MockRepository_TakenShortCodes_Call struct {
+	*mock.Call
+}
+
+// TakenShortCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCodes []string
+func (_e *MockRepository_Expecter) TakenShortCodes(ctx interface{}, shortCodes interface{}) *MockRepository_TakenShortCodes_Call {
+	return &MockRepository_TakenShortCodes_Call{Call: _e.mock.On("TakenShortCodes", ctx, shortCodes)}
+}
+
+func (_c *MockRepository_TakenShortCodes_Call) Run(run func(ctx context.Context, shortCodes []string)) *MockRepository_TakenShortCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_TakenShortCodes_Call) Return(_a0 []string, _a1 error) *MockRepository_TakenShortCodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_TakenShortCodes_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockRepository_TakenShortCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOriginalURL provides a mock function with given fields: ctx, shortCode, originalURL, owner, actor
+func (_m *MockRepository) UpdateOriginalURL(ctx context.Context, shortCode string, originalURL string, owner *int64, actor domain.Actor) (*domain.URL, error) {
+	ret := _m.Called(ctx, shortCode, originalURL, owner, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOriginalURL")
+	}
+
+	var r0 *domain.URL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int64, domain.Actor) (*domain.URL, error)); ok {
+		return rf(ctx, shortCode, originalURL, owner, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int64, domain.Actor) *domain.URL); ok {
+		r0 = rf(ctx, shortCode, originalURL, owner, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.URL)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *int64, domain.Actor) error); ok {
+		r1 = rf(ctx, shortCode, originalURL, owner, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpdateOriginalURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOriginalURL'
+type MockRepository_UpdateOriginalURL_Call struct {
+	*mock.Call
+}
+
+// UpdateOriginalURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortCode string
+//   - originalURL string
+//   - owner *int64
+//   - actor domain.Actor
+func (_e *MockRepository_Expecter) UpdateOriginalURL(ctx interface{}, shortCode interface{}, originalURL interface{}, owner interface{}, actor interface{}) *MockRepository_UpdateOriginalURL_Call {
+	return &MockRepository_UpdateOriginalURL_Call{Call: _e.mock.On("UpdateOriginalURL", ctx, shortCode, originalURL, owner, actor)}
+}
+
+func (_c *MockRepository_UpdateOriginalURL_Call) Run(run func(ctx context.Context, shortCode string, originalURL string, owner *int64, actor domain.Actor)) *MockRepository_UpdateOriginalURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*int64), args[4].(domain.Actor))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateOriginalURL_Call) Return(_a0 *domain.URL, _a1 error) *MockRepository_UpdateOriginalURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpdateOriginalURL_Call) RunAndReturn(run func(context.Context, string, string, *int64, domain.Actor) (*domain.URL, error)) *MockRepository_UpdateOriginalURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
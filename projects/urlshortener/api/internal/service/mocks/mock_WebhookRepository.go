@@ -0,0 +1,312 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "urlshortener/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookRepository is an autogenerated mock type for the WebhookRepository type
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+type MockWebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookRepository) EXPECT() *MockWebhookRepository_Expecter {
+	return &MockWebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, url, events, secret
+func (_m *MockWebhookRepository) Create(ctx context.Context, url string, events []string, secret string) (*domain.Webhook, error) {
+	ret := _m.Called(ctx, url, events, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) (*domain.Webhook, error)); ok {
+		return rf(ctx, url, events, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) *domain.Webhook); ok {
+		r0 = rf(ctx, url, events, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string) error); ok {
+		r1 = rf(ctx, url, events, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - events []string
+//   - secret string
+func (_e *MockWebhookRepository_Expecter) Create(ctx interface{}, url interface{}, events interface{}, secret interface{}) *MockWebhookRepository_Create_Call {
+	return &MockWebhookRepository_Create_Call{Call: _e.mock.On("Create", ctx, url, events, secret)}
+}
+
+func (_c *MockWebhookRepository_Create_Call) Run(run func(ctx context.Context, url string, events []string, secret string)) *MockWebhookRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Create_Call) Return(_a0 *domain.Webhook, _a1 error) *MockWebhookRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Create_Call) RunAndReturn(run func(context.Context, string, []string, string) (*domain.Webhook, error)) *MockWebhookRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockWebhookRepository_Delete_Call {
+	return &MockWebhookRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_Delete_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Delete_Call) Return(_a0 error) *MockWebhookRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Delete_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Deliveries provides a mock function with given fields: ctx, status, webhookID, after, limit
+func (_m *MockWebhookRepository) Deliveries(ctx context.Context, status string, webhookID int64, after int64, limit int) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, status, webhookID, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, int) ([]domain.WebhookDelivery, error)); ok {
+		return rf(ctx, status, webhookID, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, int) []domain.WebhookDelivery); ok {
+		r0 = rf(ctx, status, webhookID, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64, int) error); ok {
+		r1 = rf(ctx, status, webhookID, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_Deliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deliveries'
+type MockWebhookRepository_Deliveries_Call struct {
+	*mock.Call
+}
+
+// Deliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+//   - webhookID int64
+//   - after int64
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) Deliveries(ctx interface{}, status interface{}, webhookID interface{}, after interface{}, limit interface{}) *MockWebhookRepository_Deliveries_Call {
+	return &MockWebhookRepository_Deliveries_Call{Call: _e.mock.On("Deliveries", ctx, status, webhookID, after, limit)}
+}
+
+func (_c *MockWebhookRepository_Deliveries_Call) Run(run func(ctx context.Context, status string, webhookID int64, after int64, limit int)) *MockWebhookRepository_Deliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Deliveries_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *MockWebhookRepository_Deliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Deliveries_Call) RunAndReturn(run func(context.Context, string, int64, int64, int) ([]domain.WebhookDelivery, error)) *MockWebhookRepository_Deliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockWebhookRepository) List(ctx context.Context) ([]domain.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookRepository_Expecter) List(ctx interface{}) *MockWebhookRepository_List_Call {
+	return &MockWebhookRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockWebhookRepository_List_Call) Run(run func(ctx context.Context)) *MockWebhookRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_List_Call) Return(_a0 []domain.Webhook, _a1 error) *MockWebhookRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookRepository_List_Call) RunAndReturn(run func(context.Context) ([]domain.Webhook, error)) *MockWebhookRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Retry provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) Retry(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookRepository_Retry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Retry'
+type MockWebhookRepository_Retry_Call struct {
+	*mock.Call
+}
+
+// Retry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookRepository_Expecter) Retry(ctx interface{}, id interface{}) *MockWebhookRepository_Retry_Call {
+	return &MockWebhookRepository_Retry_Call{Call: _e.mock.On("Retry", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_Retry_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookRepository_Retry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Retry_Call) Return(_a0 error) *MockWebhookRepository_Retry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Retry_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookRepository_Retry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookRepository creates a new instance of MockWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookRepository {
+	mock := &MockWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
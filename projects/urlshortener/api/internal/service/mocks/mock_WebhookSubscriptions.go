@@ -0,0 +1,69 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookSubscriptions is an autogenerated mock type for the WebhookSubscriptions type
+type MockWebhookSubscriptions struct {
+	mock.Mock
+}
+
+type MockWebhookSubscriptions_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookSubscriptions) EXPECT() *MockWebhookSubscriptions_Expecter {
+	return &MockWebhookSubscriptions_Expecter{mock: &_m.Mock}
+}
+
+// RefreshSubscriptions provides a mock function with given fields: ctx
+func (_m *MockWebhookSubscriptions) RefreshSubscriptions(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockWebhookSubscriptions_RefreshSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshSubscriptions'
+type MockWebhookSubscriptions_RefreshSubscriptions_Call struct {
+	*mock.Call
+}
+
+// RefreshSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookSubscriptions_Expecter) RefreshSubscriptions(ctx interface{}) *MockWebhookSubscriptions_RefreshSubscriptions_Call {
+	return &MockWebhookSubscriptions_RefreshSubscriptions_Call{Call: _e.mock.On("RefreshSubscriptions", ctx)}
+}
+
+func (_c *MockWebhookSubscriptions_RefreshSubscriptions_Call) Run(run func(ctx context.Context)) *MockWebhookSubscriptions_RefreshSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookSubscriptions_RefreshSubscriptions_Call) Return() *MockWebhookSubscriptions_RefreshSubscriptions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookSubscriptions_RefreshSubscriptions_Call) RunAndReturn(run func(context.Context)) *MockWebhookSubscriptions_RefreshSubscriptions_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockWebhookSubscriptions creates a new instance of MockWebhookSubscriptions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookSubscriptions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookSubscriptions {
+	mock := &MockWebhookSubscriptions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/qrcode"
+)
+
+const (
+	minQRSize   = 64
+	maxQRMargin = 16
+)
+
+var (
+	ErrInvalidQRFormat = errors.New("invalid qr format")
+	ErrInvalidQRLevel  = errors.New("invalid qr error correction level")
+	ErrInvalidQRSize   = errors.New("invalid qr size")
+	ErrInvalidQRMargin = errors.New("invalid qr margin")
+)
+
+// QRService renders QR codes of short URLs.
+type QRService struct {
+	links    LinkInfoGetter
+	cache    ImageCache
+	recorder BusinessRecorder
+	maxSize  int
+}
+
+func NewQRService(links LinkInfoGetter, cache ImageCache, recorder BusinessRecorder, maxSize int) *QRService {
+	return &QRService{
+		links:    links,
+		cache:    cache,
+		recorder: recorder,
+		maxSize:  maxSize,
+	}
+}
+
+// QRCode returns the QR code of the short URL of shortCode, rendered as
+// opts.Format. The link must exist, but it may be inactive or expired, so
+// printed codes can be prepared ahead of a launch. Rendered codes are cached.
+func (s *QRService) QRCode(ctx context.Context, shortCode string, opts domain.QROptions) ([]byte, error) {
+	level, err := s.validate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.links.GetURLInfo(ctx, shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := fmt.Appendf(nil, `{"short_code":%q,"format":%q}`, shortCode, opts.Format)
+	s.recorder.RecordBusiness(time.Now(), "qr_codes", 1, labels)
+
+	key := fmt.Sprintf("%s|%s|%d|%s|%d", info.ShortURL, opts.Format, opts.Size, opts.Level, opts.Margin)
+	if img, found := s.cache.Get(key); found {
+		return img, nil
+	}
+
+	code, err := qrcode.Encode([]byte(info.ShortURL), level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+
+	var img []byte
+	if opts.Format == domain.QRFormatSVG {
+		img = code.SVG(opts.Size, opts.Margin)
+	} else if img, err = code.PNG(opts.Size, opts.Margin); err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(key, img)
+	return img, nil
+}
+
+func (s *QRService) validate(opts domain.QROptions) (qrcode.Level, error) {
+	if opts.Format != domain.QRFormatPNG && opts.Format != domain.QRFormatSVG {
+		return 0, ErrInvalidQRFormat
+	}
+	if opts.Size < minQRSize || opts.Size > s.maxSize {
+		return 0, ErrInvalidQRSize
+	}
+	if opts.Margin < 0 || opts.Margin > maxQRMargin {
+		return 0, ErrInvalidQRMargin
+	}
+	level, err := qrcode.ParseLevel(opts.Level)
+	if err != nil {
+		return 0, ErrInvalidQRLevel
+	}
+	return level, nil
+}
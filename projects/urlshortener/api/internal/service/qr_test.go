@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func qrOptions(format string) domain.QROptions {
+	return domain.QROptions{Format: format, Size: 256, Level: "M", Margin: 4}
+}
+
+func TestQRCode_RendersAndCaches(t *testing.T) {
+	links := mocks.NewMockLinkInfoGetter(t)
+	links.EXPECT().GetURLInfo(mock.Anything, "abc123").Return(&domain.URLInfo{ShortCode: "abc123", ShortURL: "http://short.url/abc123"}, nil)
+
+	cache := mocks.NewMockImageCache(t)
+	cache.EXPECT().Get("http://short.url/abc123|png|256|M|4").Return(nil, false)
+	var cached []byte
+	cache.EXPECT().Set("http://short.url/abc123|png|256|M|4", mock.Anything).Run(func(_ string, img []byte) { cached = img }).Return()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "qr_codes", float64(1), []byte(`{"short_code":"abc123","format":"png"}`)).Return()
+
+	svc := service.NewQRService(links, cache, recorder, 2048)
+
+	img, err := svc.QRCode(context.Background(), "abc123", qrOptions(domain.QRFormatPNG))
+	require.NoError(t, err)
+	assert.Equal(t, cached, img)
+
+	decoded, err := png.Decode(bytes.NewReader(img))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, decoded.Bounds().Dx(), 256)
+}
+
+func TestQRCode_CacheHit(t *testing.T) {
+	links := mocks.NewMockLinkInfoGetter(t)
+	links.EXPECT().GetURLInfo(mock.Anything, "abc123").Return(&domain.URLInfo{ShortCode: "abc123", ShortURL: "http://short.url/abc123"}, nil)
+
+	cache := mocks.NewMockImageCache(t)
+	cache.EXPECT().Get("http://short.url/abc123|svg|256|M|4").Return([]byte("<svg/>"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "qr_codes", float64(1), mock.Anything).Return()
+
+	svc := service.NewQRService(links, cache, recorder, 2048)
+
+	img, err := svc.QRCode(context.Background(), "abc123", qrOptions(domain.QRFormatSVG))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("<svg/>"), img)
+}
+
+func TestQRCode_NotFound(t *testing.T) {
+	links := mocks.NewMockLinkInfoGetter(t)
+	links.EXPECT().GetURLInfo(mock.Anything, "missing").Return(nil, service.ErrURLNotFound)
+
+	svc := service.NewQRService(links, mocks.NewMockImageCache(t), mocks.NewMockBusinessRecorder(t), 2048)
+
+	_, err := svc.QRCode(context.Background(), "missing", qrOptions(domain.QRFormatPNG))
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
+func TestQRCode_InvalidOptions(t *testing.T) {
+	svc := service.NewQRService(mocks.NewMockLinkInfoGetter(t), mocks.NewMockImageCache(t), mocks.NewMockBusinessRecorder(t), 1024)
+
+	tests := []struct {
+		name string
+		opts domain.QROptions
+		want error
+	}{
+		{"format", domain.QROptions{Format: "gif", Size: 256, Level: "M"}, service.ErrInvalidQRFormat},
+		{"level", domain.QROptions{Format: "png", Size: 256, Level: "X"}, service.ErrInvalidQRLevel},
+		{"too small", domain.QROptions{Format: "png", Size: 32, Level: "M"}, service.ErrInvalidQRSize},
+		{"too large", domain.QROptions{Format: "png", Size: 2048, Level: "M"}, service.ErrInvalidQRSize},
+		{"margin", domain.QROptions{Format: "svg", Size: 256, Level: "M", Margin: -1}, service.ErrInvalidQRMargin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.QRCode(context.Background(), "abc123", tt.opts)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
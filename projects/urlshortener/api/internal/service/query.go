@@ -0,0 +1,57 @@
+package service
+
+import (
+	"net/url"
+
+	"urlshortener/internal/domain"
+)
+
+// The existing query string is only re-encoded when override replaces one of
+// its parameters.
+func destination(target string, u *domain.URL, query url.Values) string {
+	passthrough := u.QueryPolicy == domain.QueryAppend || u.QueryPolicy == domain.QueryOverride
+	if len(u.UTM) == 0 && (!passthrough || len(query) == 0) {
+		return target
+	}
+
+	dest, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+
+	params := dest.Query()
+	extra := make(url.Values)
+	for key, value := range u.UTM {
+		if !params.Has(key) {
+			extra.Set(key, value)
+		}
+	}
+
+	replaced := false
+	if passthrough {
+		for key, values := range query {
+			switch {
+			case u.QueryPolicy == domain.QueryOverride && params.Has(key):
+				params[key] = values
+				replaced = true
+			case u.QueryPolicy == domain.QueryOverride || !extra.Has(key) && !params.Has(key):
+				extra[key] = values
+			}
+		}
+	}
+
+	switch {
+	case replaced:
+		for key, values := range extra {
+			params[key] = values
+		}
+		dest.RawQuery = params.Encode()
+	case len(extra) == 0:
+		return target
+	case dest.RawQuery == "":
+		dest.RawQuery = extra.Encode()
+	default:
+		dest.RawQuery += "&" + extra.Encode()
+	}
+	return dest.String()
+}
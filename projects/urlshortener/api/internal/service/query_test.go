@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func TestGetOriginalURL_QueryPassthrough(t *testing.T) {
+	utm := map[string]string{"utm_source": "stored", "utm_medium": "social"}
+
+	tests := []struct {
+		name   string
+		link   domain.URL
+		query  url.Values
+		expect string
+	}{
+		{
+			name:   "no query or utm",
+			link:   domain.URL{OriginalURL: "https://example.com/p?b=2&a=1", QueryPolicy: domain.QueryOverride},
+			expect: "https://example.com/p?b=2&a=1",
+		},
+		{
+			name:   "drop ignores the visit query",
+			link:   domain.URL{OriginalURL: "https://example.com/p", QueryPolicy: domain.QueryDrop},
+			query:  url.Values{"utm_source": {"newsletter"}},
+			expect: "https://example.com/p",
+		},
+		{
+			name:   "empty policy drops",
+			link:   domain.URL{OriginalURL: "https://example.com/p"},
+			query:  url.Values{"ref": {"x"}},
+			expect: "https://example.com/p",
+		},
+		{
+			name:   "utm is appended under drop",
+			link:   domain.URL{OriginalURL: "https://example.com/p?b=2&a=1", QueryPolicy: domain.QueryDrop, UTM: utm},
+			query:  url.Values{"utm_source": {"newsletter"}},
+			expect: "https://example.com/p?b=2&a=1&utm_medium=social&utm_source=stored",
+		},
+		{
+			name:   "utm does not replace destination parameters",
+			link:   domain.URL{OriginalURL: "https://example.com/p?utm_source=dest", UTM: utm},
+			expect: "https://example.com/p?utm_source=dest&utm_medium=social",
+		},
+		{
+			name:   "append keeps destination and utm values",
+			link:   domain.URL{OriginalURL: "https://example.com/p?ref=dest", QueryPolicy: domain.QueryAppend, UTM: utm},
+			query:  url.Values{"ref": {"visit"}, "utm_source": {"newsletter"}, "page": {"2"}},
+			expect: "https://example.com/p?ref=dest&page=2&utm_medium=social&utm_source=stored",
+		},
+		{
+			name:   "override replaces utm values",
+			link:   domain.URL{OriginalURL: "https://example.com/p?b=2&a=1", QueryPolicy: domain.QueryOverride, UTM: utm},
+			query:  url.Values{"utm_source": {"newsletter"}},
+			expect: "https://example.com/p?b=2&a=1&utm_medium=social&utm_source=newsletter",
+		},
+		{
+			name:   "override replaces destination parameters",
+			link:   domain.URL{OriginalURL: "https://example.com/p?ref=dest&keep=1#top", QueryPolicy: domain.QueryOverride},
+			query:  url.Values{"ref": {"visit"}, "extra": {"a b"}},
+			expect: "https://example.com/p?extra=a+b&keep=1&ref=visit#top",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := tt.link
+			link.ShortCode = "abc123"
+			link.Active = true
+
+			cache := mocks.NewMockCache(t)
+			cache.EXPECT().Get("abc123").Return(&link, true)
+
+			recorder := mocks.NewMockBusinessRecorder(t)
+			recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+			svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+			redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Query: tt.query})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, redirect.URL)
+		})
+	}
+}
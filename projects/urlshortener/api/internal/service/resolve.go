@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"urlshortener/internal/domain"
+)
+
+func (s *URLService) ResolveURLs(ctx context.Context, codes []string) (*domain.ResolveResponse, error) {
+	// Misses stay nil until fetched.
+	found := make(map[string]*domain.URL, len(codes))
+	var misses []string
+	for _, code := range codes {
+		if _, seen := found[code]; seen {
+			continue
+		}
+		u, hit := s.cache.Get(code)
+		found[code] = u
+		if !hit {
+			misses = append(misses, code)
+		}
+	}
+
+	if len(misses) > 0 {
+		generations := make([]uint64, len(misses))
+		for i, code := range misses {
+			generations[i] = s.fills.generation(code)
+		}
+		urls, err := s.repo.FindByShortCodes(ctx, misses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find urls: %w", err)
+		}
+		index := make(map[string]int, len(misses))
+		for i, code := range misses {
+			index[code] = i
+		}
+		for _, u := range urls {
+			found[u.ShortCode] = u
+			s.fills.fill(s.cache, u, generations[index[u.ShortCode]])
+		}
+	}
+
+	now := time.Now()
+	s.recorder.RecordBusiness(now, "urls_resolved", float64(len(found)), nil)
+
+	resp := &domain.ResolveResponse{URLs: make([]domain.URLInfo, 0, len(found)), Missing: []string{}}
+	for _, code := range codes {
+		u, pending := found[code]
+		if !pending {
+			continue
+		}
+		delete(found, code)
+		if u == nil {
+			resp.Missing = append(resp.Missing, code)
+			continue
+		}
+		resp.URLs = append(resp.URLs, *s.info(u, now))
+	}
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func TestResolveURLs(t *testing.T) {
+	cached := &domain.URL{ShortCode: "hot", OriginalURL: "https://example.com/hot", Active: true}
+	stored := &domain.URL{ShortCode: "cold", OriginalURL: "https://example.com/cold", Active: true, PasswordHash: "hash"}
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("cold").Return(nil, false).Once()
+	cache.EXPECT().Get("hot").Return(cached, true).Once()
+	cache.EXPECT().Get("gone").Return(nil, false).Once()
+	cache.EXPECT().Set(stored).Return().Once()
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByShortCodes(mock.Anything, []string{"cold", "gone"}).Return([]*domain.URL{stored}, nil).Once()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_resolved", float64(3), []byte(nil)).Return()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.ResolveURLs(context.Background(), []string{"cold", "hot", "gone", "hot"})
+	require.NoError(t, err)
+
+	require.Len(t, resp.URLs, 2)
+	assert.Equal(t, "cold", resp.URLs[0].ShortCode)
+	assert.Empty(t, resp.URLs[0].OriginalURL, "withheld for protected links")
+	assert.True(t, resp.URLs[0].Protected)
+	assert.Equal(t, "hot", resp.URLs[1].ShortCode)
+	assert.Equal(t, "https://example.com/hot", resp.URLs[1].OriginalURL)
+	assert.Equal(t, "http://short.url/hot", resp.URLs[1].ShortURL)
+	assert.Equal(t, []string{"gone"}, resp.Missing)
+}
+
+func TestResolveURLs_AllCached(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("hot").Return(&domain.URL{ShortCode: "hot"}, true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_resolved", float64(1), []byte(nil)).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.ResolveURLs(context.Background(), []string{"hot"})
+	require.NoError(t, err)
+	assert.Len(t, resp.URLs, 1)
+	assert.Empty(t, resp.Missing)
+}
+
+func TestResolveURLs_RepositoryError(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("cold").Return(nil, false)
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByShortCodes(mock.Anything, []string{"cold"}).Return(nil, errors.New("db down"))
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.ResolveURLs(context.Background(), []string{"cold"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"urlshortener/internal/domain"
+)
+
+var ErrURLNotYetActive = errors.New("url is not yet active")
+
+// NotYetActiveError reports a visit to a scheduled link that has no pre-launch
+// URL. It matches ErrURLNotYetActive.
+type NotYetActiveError struct {
+	ActiveFrom time.Time
+}
+
+func (e *NotYetActiveError) Error() string {
+	return ErrURLNotYetActive.Error()
+}
+
+func (e *NotYetActiveError) Unwrap() error {
+	return ErrURLNotYetActive
+}
+
+// prelaunch answers a visit to u before its activation: a temporary redirect
+// to its pre-launch URL, or a NotYetActiveError. The redirect is conditional,
+// so no client caches it past the launch.
+func (s *URLService) prelaunch(u *domain.URL, visit domain.Visit, now time.Time) (*domain.Redirect, error) {
+	if u.PrelaunchURL == "" {
+		return nil, &NotYetActiveError{ActiveFrom: *u.ActiveFrom}
+	}
+
+	metric := "prelaunch_redirects"
+	if visit.Preview {
+		metric = "preview"
+	}
+	labels := fmt.Appendf(nil, `{"short_code":%q,"original_url":%q}`, u.ShortCode, u.OriginalURL)
+	s.recorder.RecordBusiness(now, metric, 1, labels)
+
+	return &domain.Redirect{
+		URL:         u.PrelaunchURL,
+		Status:      http.StatusFound,
+		CreatedAt:   u.CreatedAt,
+		Conditional: true,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func scheduledLink(activeFrom time.Time, prelaunchURL string) *domain.URL {
+	return &domain.URL{
+		ShortCode:      "launch",
+		OriginalURL:    "https://example.com/product",
+		Active:         true,
+		RedirectStatus: http.StatusMovedPermanently,
+		ActiveFrom:     &activeFrom,
+		PrelaunchURL:   prelaunchURL,
+	}
+}
+
+func TestGetOriginalURL_NotYetActive(t *testing.T) {
+	activeFrom := time.Now().Add(time.Hour)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("launch").Return(scheduledLink(activeFrom, ""), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.GetOriginalURL(context.Background(), "launch", domain.Visit{})
+	require.ErrorIs(t, err, service.ErrURLNotYetActive)
+	var notYet *service.NotYetActiveError
+	require.True(t, errors.As(err, &notYet))
+	assert.Equal(t, activeFrom, notYet.ActiveFrom)
+}
+
+func TestGetOriginalURL_Prelaunch(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("launch").Return(scheduledLink(time.Now().Add(time.Hour), "https://example.com/teaser"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "prelaunch_redirects", float64(1),
+		[]byte(`{"short_code":"launch","original_url":"https://example.com/product"}`)).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "launch", domain.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/teaser", redirect.URL)
+	assert.Equal(t, http.StatusFound, redirect.Status, "the teaser is temporary even for permanent links")
+	assert.True(t, redirect.Conditional, "clients must not cache the teaser past the launch")
+}
+
+// A link cached before its launch goes live without being evicted.
+func TestGetOriginalURL_CachedLinkGoesLive(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("launch").Return(scheduledLink(time.Now().Add(-time.Second), "https://example.com/teaser"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "redirects", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "launch", domain.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/product", redirect.URL)
+	assert.Equal(t, http.StatusMovedPermanently, redirect.Status)
+}
@@ -1,15 +1,20 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 
+	"urlshortener/internal/auth"
 	"urlshortener/internal/domain"
+	"urlshortener/internal/password"
 	"urlshortener/internal/repository"
+	"urlshortener/internal/routing"
 )
 
 var (
@@ -17,7 +22,17 @@ var (
 	labelsBatch  = []byte(`{"method":"batch"}`)
 )
 
-var ErrURLNotFound = errors.New("url not found")
+var (
+	ErrURLNotFound   = errors.New("url not found")
+	ErrURLExpired    = errors.New("url expired")
+	ErrURLInactive   = errors.New("url is inactive")
+	ErrAliasTaken    = errors.New("alias already taken")
+	ErrAliasReserved = errors.New("alias is reserved")
+
+	ErrPasswordRequired = errors.New("password required")
+	ErrPasswordInvalid  = errors.New("invalid password")
+	ErrTooManyAttempts  = errors.New("too many failed password attempts")
+)
 
 type URLService struct {
 	repo      Repository
@@ -25,6 +40,10 @@ type URLService struct {
 	cache     Cache
 	baseURL   string
 	recorder  BusinessRecorder
+	attempts  AttemptLimiter
+	health    HealthChecker
+	events    EventPublisher
+	fills     cacheFillGuard
 }
 
 func NewURLService(
@@ -33,6 +52,9 @@ func NewURLService(
 	cache Cache,
 	baseURL string,
 	recorder BusinessRecorder,
+	attempts AttemptLimiter,
+	health HealthChecker,
+	events EventPublisher,
 ) *URLService {
 	return &URLService{
 		repo:      repo,
@@ -40,103 +62,564 @@ func NewURLService(
 		cache:     cache,
 		baseURL:   baseURL,
 		recorder:  recorder,
+		attempts:  attempts,
+		health:    health,
+		events:    events,
 	}
 }
 
-func (s *URLService) CreateShortURL(ctx context.Context, originalURL string) (*domain.CreateURLResponse, error) {
+func (s *URLService) CreateShortURL(ctx context.Context, req *domain.CreateURLRequest) (*domain.CreateURLResponse, error) {
+	if canDedupe(req) {
+		resp, err := s.findExisting(ctx, req.URL)
+		if err != nil || resp != nil {
+			return resp, err
+		}
+	}
+
+	var passwordHash string
+	if req.Password != "" {
+		hash, err := password.Hash(req.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		passwordHash = hash
+	}
+
+	rules, err := routing.Compile(req.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rules: %w", err)
+	}
+
+	shortCode, err := s.shortCodeFor(ctx, req.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	row := repository.URLRow{
+		ShortCode:      shortCode,
+		OriginalURL:    req.URL,
+		ExpiresAt:      expiresAt(req, now),
+		PasswordHash:   passwordHash,
+		OwnerKeyID:     ownerOf(ctx),
+		RedirectStatus: redirectStatus(req),
+		QueryPolicy:    queryPolicy(req),
+		UTM:            req.UTM,
+		Rules:          rules,
+		Variants:       variantsOf(req),
+		Sticky:         req.Sticky,
+		Backups:        req.Backups,
+		Tags:           tagsOf(req),
+		Campaign:       req.Campaign,
+		ActiveFrom:     req.ActiveFrom,
+		PrelaunchURL:   req.PrelaunchURL,
+	}
+
+	if err := s.repo.Create(ctx, row, actorOf(ctx)); err != nil {
+		if errors.Is(err, repository.ErrDuplicateShortCode) && req.Alias != "" {
+			return nil, ErrAliasTaken
+		}
+		return nil, fmt.Errorf("failed to create url: %w", err)
+	}
+
+	s.cache.Set(newURL(row, now))
+	s.recorder.RecordBusiness(now, "urls_created", 1, labelsSingle)
+	s.publish(domain.EventLinkCreated, domain.LinkEvent{ShortCode: shortCode, OriginalURL: req.URL}, now)
+
+	return s.newResponse(row), nil
+}
+
+func (s *URLService) findExisting(ctx context.Context, originalURL string) (*domain.CreateURLResponse, error) {
+	existing, err := s.repo.FindByOriginalURLs(ctx, []string{originalURL}, ownerOf(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing url: %w", err)
+	}
+
+	shortCode, found := existing[originalURL]
+	if !found {
+		return nil, nil
+	}
+
+	s.recorder.RecordBusiness(time.Now(), "urls_deduplicated", 1, labelsSingle)
+
+	resp := s.newResponse(repository.URLRow{ShortCode: shortCode, OriginalURL: originalURL})
+	resp.Deduplicated = true
+	return resp, nil
+}
+
+func expiresAt(req *domain.CreateURLRequest, now time.Time) *time.Time {
+	if req.TTL > 0 {
+		t := now.Add(time.Duration(req.TTL) * time.Second)
+		return &t
+	}
+	return req.ExpiresAt
+}
+
+func newURL(row repository.URLRow, createdAt time.Time) *domain.URL {
+	return &domain.URL{
+		ShortCode:      row.ShortCode,
+		OriginalURL:    row.OriginalURL,
+		CreatedAt:      createdAt.UTC(),
+		ExpiresAt:      row.ExpiresAt,
+		Active:         true,
+		PasswordHash:   row.PasswordHash,
+		OwnerKeyID:     row.OwnerKeyID,
+		RedirectStatus: row.RedirectStatus,
+		QueryPolicy:    row.QueryPolicy,
+		UTM:            row.UTM,
+		Rules:          row.Rules,
+		Variants:       row.Variants,
+		Sticky:         row.Sticky,
+		Backups:        row.Backups,
+		Tags:           row.Tags,
+		Campaign:       row.Campaign,
+		ActiveFrom:     row.ActiveFrom,
+		PrelaunchURL:   row.PrelaunchURL,
+	}
+}
+
+func redirectStatus(req *domain.CreateURLRequest) int {
+	return cmp.Or(req.RedirectStatus, http.StatusFound)
+}
+
+func queryPolicy(req *domain.CreateURLRequest) string {
+	return cmp.Or(req.QueryPolicy, domain.QueryDrop)
+}
+
+func ownerOf(ctx context.Context) *int64 {
+	if key := auth.FromContext(ctx); key != nil {
+		return &key.ID
+	}
+	return nil
+}
+
+func (s *URLService) newResponse(row repository.URLRow) *domain.CreateURLResponse {
+	return &domain.CreateURLResponse{
+		ShortCode:   row.ShortCode,
+		ShortURL:    s.baseURL + "/" + row.ShortCode,
+		OriginalURL: row.OriginalURL,
+		ExpiresAt:   row.ExpiresAt,
+	}
+}
+
+func (s *URLService) shortCodeFor(ctx context.Context, alias string) (string, error) {
+	if alias != "" {
+		if s.shortener.IsGenerated(alias) {
+			return "", ErrAliasReserved
+		}
+		return alias, nil
+	}
+
 	id, err := s.repo.NextID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get next id: %w", err)
+		return "", fmt.Errorf("failed to get next id: %w", err)
 	}
 
 	shortCode, err := s.shortener.Generate(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate short code: %w", err)
+		return "", fmt.Errorf("failed to generate short code: %w", err)
 	}
+	return shortCode, nil
+}
 
-	if err := s.repo.Create(ctx, shortCode, originalURL); err != nil {
-		return nil, fmt.Errorf("failed to create url: %w", err)
+func (s *URLService) GetOriginalURL(ctx context.Context, shortCode string, visit domain.Visit) (*domain.Redirect, error) {
+	now := time.Now()
+
+	u, err := s.lookup(ctx, shortCode, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.Active {
+		return nil, ErrURLInactive
+	}
+
+	if u.Expired(now) {
+		return nil, ErrURLExpired
+	}
+
+	// Checked per visit so a cached link goes live on time.
+	if u.Scheduled(now) {
+		return s.prelaunch(u, visit, now)
+	}
+
+	if u.Protected() {
+		if err := s.checkPassword(u, visit.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rules take precedence; variants split the visits no rule matched.
+	var variant string
+	target, routed := u.Rules.Match(visit.Request, now)
+	switch {
+	case routed:
+	case len(u.Variants) > 0:
+		assigned := ""
+		if u.Sticky {
+			assigned = visit.Variant
+		}
+		v := pickVariant(u.Variants, assigned)
+		target, variant = v.URL, v.Name
+	default:
+		target = s.failover(u, now)
+	}
+
+	metric := "redirects"
+	if visit.Preview {
+		metric = "preview"
+	}
+	redirectLabels := fmt.Appendf(nil, `{"short_code":%q,"original_url":%q}`, shortCode, u.OriginalURL)
+	if variant != "" {
+		redirectLabels = fmt.Appendf(nil, `{"short_code":%q,"original_url":%q,"variant":%q}`, shortCode, u.OriginalURL, variant)
+	}
+	s.recorder.RecordBusiness(now, metric, 1, redirectLabels)
+	if !visit.Preview {
+		s.publish(domain.EventLinkClicked, domain.LinkEvent{ShortCode: shortCode, Destination: target, Variant: variant}, now)
+	}
+
+	return &domain.Redirect{
+		URL:         destination(target, u, visit.Query),
+		Status:      cmp.Or(u.RedirectStatus, http.StatusFound),
+		CreatedAt:   u.CreatedAt,
+		ExpiresAt:   u.ExpiresAt,
+		Protected:   u.Protected(),
+		Conditional: u.Rules != nil || len(u.Variants) > 0 || len(u.Backups) > 0,
+		Variant:     variant,
+		Sticky:      u.Sticky && variant != "",
+	}, nil
+}
+
+// A stale probe is likelier than every destination being down, so keep the
+// original URL when none is healthy.
+func (s *URLService) failover(u *domain.URL, now time.Time) string {
+	if len(u.Backups) == 0 || s.health.Healthy(u.OriginalURL) {
+		return u.OriginalURL
+	}
+	for _, backup := range u.Backups {
+		if s.health.Healthy(backup) {
+			labels := fmt.Appendf(nil, `{"short_code":%q,"original_url":%q,"destination":%q}`, u.ShortCode, u.OriginalURL, backup)
+			s.recorder.RecordBusiness(now, "failover", 1, labels)
+			return backup
+		}
 	}
+	return u.OriginalURL
+}
 
-	s.cache.Set(shortCode, originalURL)
-	s.recorder.RecordBusiness(time.Now(), "urls_created", 1, labelsSingle)
+// Attempts are reserved before hashing to cap the CPU a guesser can burn.
+func (s *URLService) checkPassword(u *domain.URL, pw string) error {
+	if pw == "" {
+		return ErrPasswordRequired
+	}
+	refund, ok := s.attempts.Reserve(u.ShortCode)
+	if !ok {
+		return ErrTooManyAttempts
+	}
+	if !password.Verify(u.PasswordHash, pw) {
+		return ErrPasswordInvalid
+	}
+	refund()
+	return nil
+}
+
+func (s *URLService) GetURLInfo(ctx context.Context, shortCode string) (*domain.URLInfo, error) {
+	now := time.Now()
+
+	u, err := s.lookup(ctx, shortCode, now)
+	if err != nil {
+		return nil, err
+	}
+	return s.info(u, now), nil
+}
+
+func (s *URLService) info(u *domain.URL, now time.Time) *domain.URLInfo {
+	info := &domain.URLInfo{
+		ShortCode:      u.ShortCode,
+		ShortURL:       s.baseURL + "/" + u.ShortCode,
+		OriginalURL:    u.OriginalURL,
+		CreatedAt:      u.CreatedAt,
+		ExpiresAt:      u.ExpiresAt,
+		Active:         u.Active,
+		Expired:        u.Expired(now),
+		Protected:      u.Protected(),
+		RedirectStatus: cmp.Or(u.RedirectStatus, http.StatusFound),
+		QueryPolicy:    cmp.Or(u.QueryPolicy, domain.QueryDrop),
+		UTM:            u.UTM,
+		Rules:          u.Rules.Rules(),
+		Variants:       u.Variants,
+		Sticky:         u.Sticky,
+		Backups:        u.Backups,
+		Tags:           u.Tags,
+		Campaign:       u.Campaign,
+		ActiveFrom:     u.ActiveFrom,
+		PrelaunchURL:   u.PrelaunchURL,
+	}
+	if info.Protected {
+		info.OriginalURL = ""
+		info.Rules = nil
+		info.Variants = nil
+		info.Backups = nil
+	}
+	return info
+}
+
+func (s *URLService) UpdateURL(ctx context.Context, shortCode, originalURL string) (*domain.CreateURLResponse, error) {
+	u, err := s.repo.UpdateOriginalURL(ctx, shortCode, originalURL, ownerOf(ctx), actorOf(ctx))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrURLNotFound
+		}
+		return nil, fmt.Errorf("failed to update url: %w", err)
+	}
+
+	s.fills.invalidate(s.cache, shortCode, u)
+	now := time.Now()
+	s.recorder.RecordBusiness(now, "urls_updated", 1, nil)
+	s.publish(domain.EventLinkUpdated, domain.LinkEvent{ShortCode: shortCode, OriginalURL: originalURL}, now)
 
 	return &domain.CreateURLResponse{
-		ShortCode:   shortCode,
-		ShortURL:    s.baseURL + "/" + shortCode,
-		OriginalURL: originalURL,
+		ShortCode:   u.ShortCode,
+		ShortURL:    s.baseURL + "/" + u.ShortCode,
+		OriginalURL: u.OriginalURL,
+		ExpiresAt:   u.ExpiresAt,
 	}, nil
 }
 
-func (s *URLService) GetOriginalURL(ctx context.Context, shortCode string) (string, error) {
+func (s *URLService) DeleteURL(ctx context.Context, shortCode string) error {
+	if err := s.repo.Delete(ctx, shortCode, ownerOf(ctx), actorOf(ctx)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrURLNotFound
+		}
+		return fmt.Errorf("failed to delete url: %w", err)
+	}
+
+	s.fills.invalidate(s.cache, shortCode, nil)
+	now := time.Now()
+	s.recorder.RecordBusiness(now, "urls_deleted", 1, nil)
+	s.publish(domain.EventLinkDeleted, domain.LinkEvent{ShortCode: shortCode}, now)
+
+	return nil
+}
+
+func (s *URLService) SetURLActive(ctx context.Context, shortCode string, active bool) error {
+	if err := s.repo.SetActive(ctx, shortCode, active, ownerOf(ctx), actorOf(ctx)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrURLNotFound
+		}
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+
+	s.fills.invalidate(s.cache, shortCode, nil)
+
+	metric := "urls_deactivated"
+	if active {
+		metric = "urls_reactivated"
+	}
 	now := time.Now()
+	s.recorder.RecordBusiness(now, metric, 1, nil)
+	s.publish(domain.EventLinkUpdated, domain.LinkEvent{ShortCode: shortCode, Active: &active}, now)
+
+	return nil
+}
+
+func (s *URLService) lookup(ctx context.Context, shortCode string, now time.Time) (*domain.URL, error) {
 	cacheLabels := fmt.Appendf(nil, `{"short_code":%q}`, shortCode)
 
-	if url, found := s.cache.Get(shortCode); found {
+	if u, found := s.cache.Get(shortCode); found {
 		s.recorder.RecordBusiness(now, "cache_hit", 1, cacheLabels)
-		redirectLabels := fmt.Appendf(nil, `{"short_code":%q,"original_url":%q}`, shortCode, url)
-		s.recorder.RecordBusiness(now, "redirects", 1, redirectLabels)
-		return url, nil
+		return u, nil
 	}
 
 	s.recorder.RecordBusiness(now, "cache_miss", 1, cacheLabels)
 
-	url, err := s.repo.FindByShortCode(ctx, shortCode)
+	generation := s.fills.generation(shortCode)
+	u, err := s.repo.FindByShortCode(ctx, shortCode)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", ErrURLNotFound
+			return nil, ErrURLNotFound
 		}
-		return "", fmt.Errorf("failed to find url: %w", err)
+		return nil, fmt.Errorf("failed to find url: %w", err)
 	}
 
-	s.cache.Set(shortCode, url)
-	redirectLabels := fmt.Appendf(nil, `{"short_code":%q,"original_url":%q}`, shortCode, url)
-	s.recorder.RecordBusiness(now, "redirects", 1, redirectLabels)
+	s.fills.fill(s.cache, u, generation)
 
-	return url, nil
+	return u, nil
 }
 
-func (s *URLService) CreateShortURLBatch(ctx context.Context, originalURLs []string) ([]domain.CreateURLResponse, error) {
-	count := len(originalURLs)
-	if count == 0 {
+func (s *URLService) CreateShortURLBatch(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.CreateURLResponse, error) {
+	if len(reqs) == 0 {
 		return []domain.CreateURLResponse{}, nil
 	}
 
-	ids, err := s.repo.NextIDs(ctx, count)
+	responses, err := s.createBatch(ctx, reqs, labelsBatch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get next ids: %w", err)
+		return nil, err
+	}
+	s.recorder.RecordBusiness(time.Now(), "batch_size", float64(len(reqs)), nil)
+	return responses, nil
+}
+
+// CreateShortURLBatchPartial fails only the entries whose alias is taken.
+func (s *URLService) CreateShortURLBatchPartial(ctx context.Context, reqs []domain.CreateURLRequest) ([]domain.CreateURLResponse, []error, error) {
+	if len(reqs) == 0 {
+		return []domain.CreateURLResponse{}, []error{}, nil
+	}
+
+	responses, errs, err := s.createEach(ctx, reqs, labelsBatch)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.recorder.RecordBusiness(time.Now(), "batch_size", float64(len(reqs)), nil)
+	return responses, errs, nil
+}
+
+func (s *URLService) createBatch(ctx context.Context, reqs []domain.CreateURLRequest, labels []byte) ([]domain.CreateURLResponse, error) {
+	count := len(reqs)
+
+	existing, err := s.existingCodes(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Duplicates of an earlier entry point at it through sameAs.
+	codes := make([]string, count)
+	deduped := make([]bool, count)
+	sameAs := make(map[int]int)
+	firstIndex := make(map[string]int)
+	generated := 0
+
+	for i := range reqs {
+		req := &reqs[i]
+		switch {
+		case req.Alias != "":
+			if s.shortener.IsGenerated(req.Alias) {
+				return nil, ErrAliasReserved
+			}
+			codes[i] = req.Alias
+		case canDedupe(req) && existing[req.URL] != "":
+			codes[i] = existing[req.URL]
+			deduped[i] = true
+		default:
+			if canDedupe(req) {
+				if first, ok := firstIndex[req.URL]; ok {
+					sameAs[i] = first
+					deduped[i] = true
+					continue
+				}
+				firstIndex[req.URL] = i
+			}
+			generated++
+		}
 	}
 
-	urlRows := make([]repository.URLRow, count)
+	var ids []uint
+	if generated > 0 {
+		ids, err = s.repo.NextIDs(ctx, generated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next ids: %w", err)
+		}
+	}
+
+	now := time.Now()
+	owner := ownerOf(ctx)
+	urlRows := make([]repository.URLRow, 0, count)
 	responses := make([]domain.CreateURLResponse, count)
 
-	for i, originalURL := range originalURLs {
-		shortCode, err := s.shortener.Generate(ids[i])
+	for i := range reqs {
+		req := &reqs[i]
+		if deduped[i] {
+			continue
+		}
+
+		if codes[i] == "" {
+			codes[i], err = s.shortener.Generate(ids[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate short code: %w", err)
+			}
+			ids = ids[1:]
+		}
+
+		rules, err := routing.Compile(req.Rules)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate short code: %w", err)
+			return nil, fmt.Errorf("failed to compile rules: %w", err)
 		}
 
-		urlRows[i] = repository.URLRow{
-			ShortCode:   shortCode,
-			OriginalURL: originalURL,
+		row := repository.URLRow{
+			ShortCode:      codes[i],
+			OriginalURL:    req.URL,
+			ExpiresAt:      expiresAt(req, now),
+			OwnerKeyID:     owner,
+			RedirectStatus: redirectStatus(req),
+			QueryPolicy:    queryPolicy(req),
+			UTM:            req.UTM,
+			Rules:          rules,
+			Variants:       variantsOf(req),
+			Sticky:         req.Sticky,
+			Backups:        req.Backups,
+			Tags:           tagsOf(req),
+			Campaign:       req.Campaign,
+			ActiveFrom:     req.ActiveFrom,
+			PrelaunchURL:   req.PrelaunchURL,
 		}
+		urlRows = append(urlRows, row)
+		responses[i] = *s.newResponse(row)
+	}
 
-		responses[i] = domain.CreateURLResponse{
-			ShortCode:   shortCode,
-			ShortURL:    s.baseURL + "/" + shortCode,
-			OriginalURL: originalURL,
+	for i := range reqs {
+		if !deduped[i] {
+			continue
 		}
+		if first, ok := sameAs[i]; ok {
+			codes[i] = codes[first]
+		}
+		responses[i] = *s.newResponse(repository.URLRow{ShortCode: codes[i], OriginalURL: reqs[i].URL})
+		responses[i].Deduplicated = true
+	}
 
-		s.cache.Set(shortCode, originalURL)
+	if len(urlRows) > 0 {
+		if err := s.repo.CreateBatch(ctx, urlRows, actorOf(ctx)); err != nil {
+			if errors.Is(err, repository.ErrDuplicateShortCode) && generated < len(urlRows) {
+				return nil, ErrAliasTaken
+			}
+			return nil, fmt.Errorf("failed to create urls: %w", err)
+		}
 	}
 
-	if err := s.repo.CreateBatch(ctx, urlRows); err != nil {
-		return nil, fmt.Errorf("failed to create urls: %w", err)
+	// Cache only after insert so a rejected alias never overwrites its owner.
+	for _, row := range urlRows {
+		s.cache.Set(newURL(row, now))
+		s.publish(domain.EventLinkCreated, domain.LinkEvent{ShortCode: row.ShortCode, OriginalURL: row.OriginalURL}, now)
 	}
 
-	now := time.Now()
-	s.recorder.RecordBusiness(now, "urls_created", float64(count), labelsBatch)
-	s.recorder.RecordBusiness(now, "batch_size", float64(count), nil)
+	s.recorder.RecordBusiness(now, "urls_created", float64(len(urlRows)), labels)
+	if dedupedCount := count - len(urlRows); dedupedCount > 0 {
+		s.recorder.RecordBusiness(now, "urls_deduplicated", float64(dedupedCount), labels)
+	}
 
 	return responses, nil
 }
+
+func canDedupe(req *domain.CreateURLRequest) bool {
+	return req.Dedupe && req.Alias == "" && req.ExpiresAt == nil && req.TTL == 0 && req.Password == "" &&
+		redirectStatus(req) == http.StatusFound && queryPolicy(req) == domain.QueryDrop && len(req.UTM) == 0 &&
+		len(req.Rules) == 0 && len(req.Variants) == 0 && len(req.Backups) == 0 && len(req.Tags) == 0 &&
+		req.Campaign == "" && req.ActiveFrom == nil
+}
+
+func (s *URLService) existingCodes(ctx context.Context, reqs []domain.CreateURLRequest) (map[string]string, error) {
+	var urls []string
+	for i := range reqs {
+		if canDedupe(&reqs[i]) {
+			urls = append(urls, reqs[i].URL)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.repo.FindByOriginalURLs(ctx, urls, ownerOf(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing urls: %w", err)
+	}
+	return existing, nil
+}
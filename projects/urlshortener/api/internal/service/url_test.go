@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -11,21 +12,41 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"urlshortener/internal/auth"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/password"
 	"urlshortener/internal/repository"
+	"urlshortener/internal/routing"
 	"urlshortener/internal/service"
 	"urlshortener/internal/service/mocks"
 )
 
+// noOwner is the owner of anonymous requests.
+var noOwner *int64
+
+// anyEvents accepts whatever events a test publishes.
+func anyEvents(t *testing.T) *mocks.MockEventPublisher {
+	events := mocks.NewMockEventPublisher(t)
+	events.EXPECT().Publish(mock.Anything).Maybe()
+	return events
+}
+
+func linkMatching(shortCode, originalURL string) any {
+	return mock.MatchedBy(func(u *domain.URL) bool {
+		return u.ShortCode == shortCode && u.OriginalURL == originalURL
+	})
+}
+
 // CreateShortURL tests
 
 func TestCreateShortURL_Success(t *testing.T) {
 	repo := mocks.NewMockRepository(t)
 	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
-	repo.EXPECT().Create(mock.Anything, "xyz789", "https://example.com").Return(nil)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{ShortCode: "xyz789", OriginalURL: "https://example.com", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop}, mock.Anything).Return(nil)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Get("xyz789").Return("", false).Maybe()
-	cache.EXPECT().Set("xyz789", "https://example.com").Return()
+	cache.EXPECT().Get("xyz789").Return(nil, false).Maybe()
+	cache.EXPECT().Set(linkMatching("xyz789", "https://example.com")).Return()
 
 	shortener := mocks.NewMockCodeGenerator(t)
 	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
@@ -33,9 +54,9 @@ func TestCreateShortURL_Success(t *testing.T) {
 	recorder := mocks.NewMockBusinessRecorder(t)
 	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	resp, err := svc.CreateShortURL(context.Background(), "https://example.com")
+	resp, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com"})
 	require.NoError(t, err)
 
 	assert.Equal(t, "xyz789", resp.ShortCode)
@@ -53,9 +74,9 @@ func TestCreateShortURL_NextIDError(t *testing.T) {
 	shortener := mocks.NewMockCodeGenerator(t)
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.CreateShortURL(context.Background(), "https://example.com")
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com"})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
@@ -72,9 +93,9 @@ func TestCreateShortURL_GenerateError(t *testing.T) {
 
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.CreateShortURL(context.Background(), "https://example.com")
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com"})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
@@ -84,7 +105,7 @@ func TestCreateShortURL_CreateError(t *testing.T) {
 
 	repo := mocks.NewMockRepository(t)
 	repo.EXPECT().NextID(mock.Anything).Return(uint(1), nil)
-	repo.EXPECT().Create(mock.Anything, "abc123", "https://example.com").Return(expectedErr)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{ShortCode: "abc123", OriginalURL: "https://example.com", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop}, mock.Anything).Return(expectedErr)
 
 	cache := mocks.NewMockCache(t)
 	shortener := mocks.NewMockCodeGenerator(t)
@@ -92,20 +113,206 @@ func TestCreateShortURL_CreateError(t *testing.T) {
 
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.CreateShortURL(context.Background(), "https://example.com")
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com"})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
 
+func TestCreateShortURL_Alias(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{ShortCode: "spring-sale", OriginalURL: "https://example.com", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(linkMatching("spring-sale", "https://example.com")).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("spring-sale").Return(false)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{
+		URL:   "https://example.com",
+		Alias: "spring-sale",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "spring-sale", resp.ShortCode)
+	assert.Equal(t, "http://short.url/spring-sale", resp.ShortURL)
+}
+
+func TestCreateShortURL_AliasTaken(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{ShortCode: "spring-sale", OriginalURL: "https://example.com", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop}, mock.Anything).Return(repository.ErrDuplicateShortCode)
+
+	cache := mocks.NewMockCache(t)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("spring-sale").Return(false)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{
+		URL:   "https://example.com",
+		Alias: "spring-sale",
+	})
+	assert.ErrorIs(t, err, service.ErrAliasTaken)
+}
+
+func TestCreateShortURL_AliasLooksGenerated(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	cache := mocks.NewMockCache(t)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("UkLWZg").Return(true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{
+		URL:   "https://example.com",
+		Alias: "UkLWZg",
+	})
+	assert.ErrorIs(t, err, service.ErrAliasReserved)
+}
+
+func TestCreateShortURL_TTL(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(row repository.URLRow) bool {
+		return row.ExpiresAt != nil && time.Until(*row.ExpiresAt) > 59*time.Minute
+	}), mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com", TTL: 3600})
+	require.NoError(t, err)
+	require.NotNil(t, resp.ExpiresAt)
+}
+
+func TestCreateShortURL_DedupeExisting(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByOriginalURLs(mock.Anything, []string{"https://example.com"}, noOwner).
+		Return(map[string]string{"https://example.com": "old123"}, nil)
+
+	cache := mocks.NewMockCache(t)
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deduplicated", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com", Dedupe: true})
+	require.NoError(t, err)
+	assert.Equal(t, "old123", resp.ShortCode)
+	assert.True(t, resp.Deduplicated)
+}
+
+func TestCreateShortURL_DedupeNoMatch(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByOriginalURLs(mock.Anything, []string{"https://example.com"}, noOwner).Return(map[string]string{}, nil)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{ShortCode: "xyz789", OriginalURL: "https://example.com", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(linkMatching("xyz789", "https://example.com")).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com", Dedupe: true})
+	require.NoError(t, err)
+	assert.Equal(t, "xyz789", resp.ShortCode)
+	assert.False(t, resp.Deduplicated)
+}
+
+func TestCreateShortURL_QueryPolicyAndUTMSkipDedupe(t *testing.T) {
+	utm := map[string]string{"utm_campaign": "spring"}
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{
+		ShortCode:      "xyz789",
+		OriginalURL:    "https://example.com",
+		RedirectStatus: http.StatusFound,
+		QueryPolicy:    domain.QueryOverride,
+		UTM:            utm,
+	}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(linkMatching("xyz789", "https://example.com")).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{
+		URL:         "https://example.com",
+		Dedupe:      true,
+		QueryPolicy: domain.QueryOverride,
+		UTM:         utm,
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Deduplicated)
+}
+
+func TestCreateShortURL_PasswordIsHashed(t *testing.T) {
+	hashed := mock.MatchedBy(func(row repository.URLRow) bool {
+		return row.PasswordHash != "secret" && password.Verify(row.PasswordHash, "secret")
+	})
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, hashed, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.MatchedBy(func(u *domain.URL) bool { return u.Protected() })).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	// Dedupe is ignored for protected links.
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{URL: "https://example.com", Password: "secret", Dedupe: true})
+	require.NoError(t, err)
+}
+
 // GetOriginalURL tests
 
 func TestGetOriginalURL_CacheHit(t *testing.T) {
 	repo := mocks.NewMockRepository(t)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Get("abc123").Return("https://cached.example.com", true)
+	cache.EXPECT().Get("abc123").Return(&domain.URL{ShortCode: "abc123", OriginalURL: "https://cached.example.com", Active: true}, true)
 
 	shortener := mocks.NewMockCodeGenerator(t)
 
@@ -116,11 +323,11 @@ func TestGetOriginalURL_CacheHit(t *testing.T) {
 			recordedMetrics = append(recordedMetrics, name)
 		}).Return().Times(2)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	url, err := svc.GetOriginalURL(context.Background(), "abc123")
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
 	require.NoError(t, err)
-	assert.Equal(t, "https://cached.example.com", url)
+	assert.Equal(t, "https://cached.example.com", redirect.URL)
 
 	assert.Len(t, recordedMetrics, 2)
 	assert.Equal(t, "cache_hit", recordedMetrics[0])
@@ -129,11 +336,11 @@ func TestGetOriginalURL_CacheHit(t *testing.T) {
 
 func TestGetOriginalURL_CacheMiss_DBFound(t *testing.T) {
 	repo := mocks.NewMockRepository(t)
-	repo.EXPECT().FindByShortCode(mock.Anything, "abc123").Return("https://db.example.com", nil)
+	repo.EXPECT().FindByShortCode(mock.Anything, "abc123").Return(&domain.URL{ShortCode: "abc123", OriginalURL: "https://db.example.com", Active: true}, nil)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Get("abc123").Return("", false)
-	cache.EXPECT().Set("abc123", "https://db.example.com").Return()
+	cache.EXPECT().Get("abc123").Return(nil, false)
+	cache.EXPECT().Set(linkMatching("abc123", "https://db.example.com")).Return()
 
 	shortener := mocks.NewMockCodeGenerator(t)
 
@@ -144,11 +351,11 @@ func TestGetOriginalURL_CacheMiss_DBFound(t *testing.T) {
 			recordedMetrics = append(recordedMetrics, name)
 		}).Return().Times(2)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	url, err := svc.GetOriginalURL(context.Background(), "abc123")
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
 	require.NoError(t, err)
-	assert.Equal(t, "https://db.example.com", url)
+	assert.Equal(t, "https://db.example.com", redirect.URL)
 
 	assert.Len(t, recordedMetrics, 2)
 	assert.Equal(t, "cache_miss", recordedMetrics[0])
@@ -156,43 +363,440 @@ func TestGetOriginalURL_CacheMiss_DBFound(t *testing.T) {
 
 func TestGetOriginalURL_NotFound(t *testing.T) {
 	repo := mocks.NewMockRepository(t)
-	repo.EXPECT().FindByShortCode(mock.Anything, "notfound").Return("", pgx.ErrNoRows)
+	repo.EXPECT().FindByShortCode(mock.Anything, "notfound").Return(nil, pgx.ErrNoRows)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Get("notfound").Return("", false)
+	cache.EXPECT().Get("notfound").Return(nil, false)
 
 	shortener := mocks.NewMockCodeGenerator(t)
 
 	recorder := mocks.NewMockBusinessRecorder(t)
 	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_miss", float64(1), mock.Anything).Return()
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.GetOriginalURL(context.Background(), "notfound")
+	_, err := svc.GetOriginalURL(context.Background(), "notfound", domain.Visit{})
 	assert.ErrorIs(t, err, service.ErrURLNotFound)
 }
 
+func TestGetOriginalURL_Expired(t *testing.T) {
+	expiredAt := time.Now().Add(-time.Minute)
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByShortCode(mock.Anything, "abc123").Return(&domain.URL{
+		ShortCode:   "abc123",
+		OriginalURL: "https://db.example.com",
+		ExpiresAt:   &expiredAt,
+		Active:      true,
+	}, nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(nil, false)
+	cache.EXPECT().Set(linkMatching("abc123", "https://db.example.com")).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_miss", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+	assert.ErrorIs(t, err, service.ErrURLExpired)
+}
+
+func TestGetOriginalURL_Inactive(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(&domain.URL{ShortCode: "abc123", OriginalURL: "https://cached.example.com"}, true)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+	assert.ErrorIs(t, err, service.ErrURLInactive)
+}
+
 func TestGetOriginalURL_DBError(t *testing.T) {
 	expectedErr := errors.New("db error")
 
 	repo := mocks.NewMockRepository(t)
-	repo.EXPECT().FindByShortCode(mock.Anything, "abc123").Return("", expectedErr)
+	repo.EXPECT().FindByShortCode(mock.Anything, "abc123").Return(nil, expectedErr)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Get("abc123").Return("", false)
+	cache.EXPECT().Get("abc123").Return(nil, false)
 
 	shortener := mocks.NewMockCodeGenerator(t)
 
 	recorder := mocks.NewMockBusinessRecorder(t)
 	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_miss", float64(1), mock.Anything).Return()
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.GetOriginalURL(context.Background(), "abc123")
+	_, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
 
+func TestGetOriginalURL_RedirectStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored int
+		want   int
+	}{
+		{"stored status", http.StatusPermanentRedirect, http.StatusPermanentRedirect},
+		{"missing status defaults to 302", 0, http.StatusFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := mocks.NewMockCache(t)
+			cache.EXPECT().Get("abc123").Return(&domain.URL{
+				ShortCode:      "abc123",
+				OriginalURL:    "https://hooks.example.com",
+				Active:         true,
+				RedirectStatus: tt.stored,
+			}, true)
+
+			recorder := mocks.NewMockBusinessRecorder(t)
+			recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+			svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+			redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, redirect.Status)
+		})
+	}
+}
+
+func TestGetOriginalURL_RoutingRules(t *testing.T) {
+	rules, err := routing.Compile([]routing.Rule{
+		{URL: "https://apps.apple.com/app/id1", Device: routing.DeviceIOS},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{"matching rule", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X)", "https://apps.apple.com/app/id1?utm_source=print"},
+		{"fallback", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "https://example.com/app?utm_source=print"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := mocks.NewMockCache(t)
+			cache.EXPECT().Get("abc123").Return(&domain.URL{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com/app",
+				Active:      true,
+				UTM:         map[string]string{"utm_source": "print"},
+				Rules:       rules,
+			}, true)
+
+			recorder := mocks.NewMockBusinessRecorder(t)
+			recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+			svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+			redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Request: routing.Request{UserAgent: tt.userAgent}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, redirect.URL)
+			assert.True(t, redirect.Conditional)
+		})
+	}
+}
+
+func protectedLink(t *testing.T, pw string) *domain.URL {
+	t.Helper()
+	hash, err := password.Hash(pw)
+	require.NoError(t, err)
+	return &domain.URL{ShortCode: "abc123", OriginalURL: "https://docs.example.com", Active: true, PasswordHash: hash}
+}
+
+func TestGetOriginalURL_PasswordRequired(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(protectedLink(t, "secret"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{})
+	assert.ErrorIs(t, err, service.ErrPasswordRequired)
+}
+
+func TestGetOriginalURL_PasswordInvalid(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(protectedLink(t, "secret"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+
+	attempts := mocks.NewMockAttemptLimiter(t)
+	refunded := false
+	attempts.EXPECT().Reserve("abc123").Return(func() { refunded = true }, true)
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, attempts, mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Password: "guess"})
+	assert.ErrorIs(t, err, service.ErrPasswordInvalid)
+	assert.False(t, refunded, "a failed attempt keeps its token")
+}
+
+func TestGetOriginalURL_PasswordBlocked(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(protectedLink(t, "secret"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+
+	attempts := mocks.NewMockAttemptLimiter(t)
+	attempts.EXPECT().Reserve("abc123").Return(nil, false)
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, attempts, mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	// Even the right password is refused until the limiter recovers.
+	_, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Password: "secret"})
+	assert.ErrorIs(t, err, service.ErrTooManyAttempts)
+}
+
+func TestGetOriginalURL_PasswordCorrect(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(protectedLink(t, "secret"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "redirects", float64(1), mock.Anything).Return()
+
+	attempts := mocks.NewMockAttemptLimiter(t)
+	refunded := false
+	attempts.EXPECT().Reserve("abc123").Return(func() { refunded = true }, true)
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, attempts, mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Password: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://docs.example.com", redirect.URL)
+	assert.True(t, refunded, "a correct password gives its attempt back")
+}
+
+// GetURLInfo tests
+
+func TestGetURLInfo_ProtectedHidesDestination(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(protectedLink(t, "secret"), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	info, err := svc.GetURLInfo(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.True(t, info.Protected)
+	assert.Empty(t, info.OriginalURL)
+}
+
+func TestGetURLInfo_DoesNotRecordRedirect(t *testing.T) {
+	expiredAt := time.Now().Add(-time.Minute)
+
+	repo := mocks.NewMockRepository(t)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(&domain.URL{
+		ShortCode:   "abc123",
+		OriginalURL: "https://cached.example.com",
+		ExpiresAt:   &expiredAt,
+		Active:      true,
+	}, true)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return().Once()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	info, err := svc.GetURLInfo(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cached.example.com", info.OriginalURL)
+	assert.Equal(t, "http://short.url/abc123", info.ShortURL)
+	assert.True(t, info.Active)
+	assert.True(t, info.Expired)
+}
+
+func TestGetURLInfo_NotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByShortCode(mock.Anything, "notfound").Return(nil, pgx.ErrNoRows)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("notfound").Return(nil, false)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_miss", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.GetURLInfo(context.Background(), "notfound")
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
+// UpdateURL tests
+
+func TestUpdateURL_Success(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().UpdateOriginalURL(mock.Anything, "abc123", "https://new.example.com", noOwner, mock.Anything).Return(&domain.URL{
+		ShortCode:   "abc123",
+		OriginalURL: "https://new.example.com",
+		Active:      true,
+	}, nil)
+
+	var calls []string
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("abc123").Run(func(string) { calls = append(calls, "delete") }).Return()
+	cache.EXPECT().Set(linkMatching("abc123", "https://new.example.com")).
+		Run(func(*domain.URL) { calls = append(calls, "set") }).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_updated", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.UpdateURL(context.Background(), "abc123", "https://new.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://new.example.com", resp.OriginalURL)
+	assert.Equal(t, "http://short.url/abc123", resp.ShortURL)
+	assert.Equal(t, []string{"delete", "set"}, calls)
+}
+
+func TestUpdateURL_NotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().UpdateOriginalURL(mock.Anything, "notfound", "https://new.example.com", noOwner, mock.Anything).Return(nil, pgx.ErrNoRows)
+
+	cache := mocks.NewMockCache(t)
+	shortener := mocks.NewMockCodeGenerator(t)
+	recorder := mocks.NewMockBusinessRecorder(t)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.UpdateURL(context.Background(), "notfound", "https://new.example.com")
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
+func TestUpdateURL_DuringCacheMissKeepsNewRow(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().UpdateOriginalURL(mock.Anything, "abc123", "https://new.example.com", noOwner, mock.Anything).Return(&domain.URL{
+		ShortCode:   "abc123",
+		OriginalURL: "https://new.example.com",
+		Active:      true,
+	}, nil)
+
+	// The mock fails on any Set of the old row the reader fetched.
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(nil, false)
+	cache.EXPECT().Delete("abc123").Return()
+	cache.EXPECT().Set(linkMatching("abc123", "https://new.example.com")).Return().Once()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_miss", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_updated", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	// The update commits after the reader fetched the old row but before it
+	// fills the cache.
+	repo.EXPECT().FindByShortCode(mock.Anything, "abc123").RunAndReturn(func(ctx context.Context, _ string) (*domain.URL, error) {
+		_, err := svc.UpdateURL(ctx, "abc123", "https://new.example.com")
+		require.NoError(t, err)
+		return &domain.URL{ShortCode: "abc123", OriginalURL: "https://old.example.com", Active: true}, nil
+	})
+
+	info, err := svc.GetURLInfo(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "https://old.example.com", info.OriginalURL)
+}
+
+// DeleteURL tests
+
+func TestDeleteURL_Success(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "abc123", noOwner, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("abc123").Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deleted", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	err := svc.DeleteURL(context.Background(), "abc123")
+	require.NoError(t, err)
+}
+
+func TestDeleteURL_NotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "notfound", noOwner, mock.Anything).Return(pgx.ErrNoRows)
+
+	cache := mocks.NewMockCache(t)
+	shortener := mocks.NewMockCodeGenerator(t)
+	recorder := mocks.NewMockBusinessRecorder(t)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	err := svc.DeleteURL(context.Background(), "notfound")
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
+// SetURLActive tests
+
+func TestSetURLActive_Deactivate(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().SetActive(mock.Anything, "abc123", false, noOwner, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("abc123").Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deactivated", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	err := svc.SetURLActive(context.Background(), "abc123", false)
+	require.NoError(t, err)
+}
+
+func TestSetURLActive_NotFound(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().SetActive(mock.Anything, "notfound", true, noOwner, mock.Anything).Return(pgx.ErrNoRows)
+
+	cache := mocks.NewMockCache(t)
+	shortener := mocks.NewMockCodeGenerator(t)
+	recorder := mocks.NewMockBusinessRecorder(t)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	err := svc.SetURLActive(context.Background(), "notfound", true)
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
 // CreateShortURLBatch tests
 
 func TestCreateShortURLBatch_EmptyURLs(t *testing.T) {
@@ -201,9 +805,9 @@ func TestCreateShortURLBatch_EmptyURLs(t *testing.T) {
 	shortener := mocks.NewMockCodeGenerator(t)
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	resp, err := svc.CreateShortURLBatch(context.Background(), []string{})
+	resp, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{})
 	require.NoError(t, err)
 	assert.Empty(t, resp)
 }
@@ -211,10 +815,10 @@ func TestCreateShortURLBatch_EmptyURLs(t *testing.T) {
 func TestCreateShortURLBatch_Success(t *testing.T) {
 	repo := mocks.NewMockRepository(t)
 	repo.EXPECT().NextIDs(mock.Anything, 2).Return([]uint{1, 2}, nil)
-	repo.EXPECT().CreateBatch(mock.Anything, mock.Anything).Return(nil)
+	repo.EXPECT().CreateBatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Set(mock.Anything, mock.Anything).Return().Times(2)
+	cache.EXPECT().Set(mock.Anything).Return().Times(2)
 
 	shortener := mocks.NewMockCodeGenerator(t)
 	shortener.EXPECT().Generate(uint(1)).Return("code1", nil)
@@ -223,9 +827,9 @@ func TestCreateShortURLBatch_Success(t *testing.T) {
 	recorder := mocks.NewMockBusinessRecorder(t)
 	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Times(2)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	urls := []string{"https://example.com/1", "https://example.com/2"}
+	urls := []domain.CreateURLRequest{{URL: "https://example.com/1"}, {URL: "https://example.com/2"}}
 	resp, err := svc.CreateShortURLBatch(context.Background(), urls)
 	require.NoError(t, err)
 	assert.Len(t, resp, 2)
@@ -241,9 +845,9 @@ func TestCreateShortURLBatch_NextIDsError(t *testing.T) {
 	shortener := mocks.NewMockCodeGenerator(t)
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.CreateShortURLBatch(context.Background(), []string{"https://example.com"})
+	_, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{{URL: "https://example.com"}})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
@@ -255,7 +859,6 @@ func TestCreateShortURLBatch_GenerateError(t *testing.T) {
 	repo.EXPECT().NextIDs(mock.Anything, 2).Return([]uint{1, 2}, nil)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Set("code1", "url1").Return()
 
 	shortener := mocks.NewMockCodeGenerator(t)
 	shortener.EXPECT().Generate(uint(1)).Return("code1", nil)
@@ -263,9 +866,9 @@ func TestCreateShortURLBatch_GenerateError(t *testing.T) {
 
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.CreateShortURLBatch(context.Background(), []string{"url1", "url2"})
+	_, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{{URL: "url1"}, {URL: "url2"}})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
@@ -277,19 +880,208 @@ func TestCreateShortURLBatch_CreateBatchError(t *testing.T) {
 	repo.EXPECT().NextIDs(mock.Anything, 1).Return([]uint{1}, nil)
 	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(urls []repository.URLRow) bool {
 		return len(urls) == 1
-	})).Return(expectedErr)
+	}), mock.Anything).Return(expectedErr)
 
 	cache := mocks.NewMockCache(t)
-	cache.EXPECT().Set("abc123", "https://example.com").Return()
 
 	shortener := mocks.NewMockCodeGenerator(t)
 	shortener.EXPECT().Generate(uint(1)).Return("abc123", nil)
 
 	recorder := mocks.NewMockBusinessRecorder(t)
 
-	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder)
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
 
-	_, err := svc.CreateShortURLBatch(context.Background(), []string{"https://example.com"})
+	_, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{{URL: "https://example.com"}})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, expectedErr)
 }
+
+func TestCreateShortURLBatch_MixedAliases(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextIDs(mock.Anything, 1).Return([]uint{7}, nil)
+	repo.EXPECT().CreateBatch(mock.Anything, []repository.URLRow{
+		{ShortCode: "spring-sale", OriginalURL: "https://example.com/1", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop},
+		{ShortCode: "code7", OriginalURL: "https://example.com/2", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop},
+	}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return().Times(2)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("spring-sale").Return(false)
+	shortener.EXPECT().Generate(uint(7)).Return("code7", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Times(2)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com/1", Alias: "spring-sale"},
+		{URL: "https://example.com/2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp, 2)
+	assert.Equal(t, "spring-sale", resp[0].ShortCode)
+	assert.Equal(t, "code7", resp[1].ShortCode)
+}
+
+func TestCreateShortURLBatch_AliasTaken(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().CreateBatch(mock.Anything, mock.Anything, mock.Anything).Return(repository.ErrDuplicateShortCode)
+
+	cache := mocks.NewMockCache(t)
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("spring-sale").Return(false)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com", Alias: "spring-sale"},
+	})
+	assert.ErrorIs(t, err, service.ErrAliasTaken)
+}
+
+// The alias is free when checked but taken by the time the batch is inserted.
+func TestCreateShortURLBatchPartial_AliasTakenFailsOnlyItsEntry(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().TakenShortCodes(mock.Anything, []string{"spring-sale"}).Return(nil, nil).Once()
+	repo.EXPECT().NextIDs(mock.Anything, 1).Return([]uint{7}, nil).Once()
+	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(rows []repository.URLRow) bool { return len(rows) == 2 }), mock.Anything).
+		Return(repository.ErrDuplicateShortCode).Once()
+	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(rows []repository.URLRow) bool { return rows[0].ShortCode == "spring-sale" }), mock.Anything).
+		Return(repository.ErrDuplicateShortCode).Once()
+	repo.EXPECT().NextIDs(mock.Anything, 1).Return([]uint{8}, nil).Once()
+	repo.EXPECT().CreateBatch(mock.Anything, mock.MatchedBy(func(rows []repository.URLRow) bool { return rows[0].ShortCode == "code8" }), mock.Anything).
+		Return(nil).Once()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().IsGenerated("spring-sale").Return(false)
+	shortener.EXPECT().Generate(uint(7)).Return("code7", nil)
+	shortener.EXPECT().Generate(uint(8)).Return("code8", nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return().Once()
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), []byte(`{"method":"batch"}`)).Return().Once()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "batch_size", float64(2), []byte(nil)).Return().Once()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, errs, err := svc.CreateShortURLBatchPartial(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com/1", Alias: "spring-sale"},
+		{URL: "https://example.com/2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], service.ErrAliasTaken)
+	assert.NoError(t, errs[1])
+	assert.Equal(t, "code8", resp[1].ShortCode)
+}
+
+func TestCreateShortURLBatchPartial_DatabaseError(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextIDs(mock.Anything, 1).Return(nil, errors.New("db down"))
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url", mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, _, err := svc.CreateShortURLBatchPartial(context.Background(), []domain.CreateURLRequest{{URL: "https://example.com"}})
+	assert.Error(t, err)
+}
+
+func TestCreateShortURLBatch_Dedupe(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByOriginalURLs(mock.Anything, mock.Anything, noOwner).
+		Return(map[string]string{"https://example.com/old": "old123"}, nil)
+	repo.EXPECT().NextIDs(mock.Anything, 1).Return([]uint{7}, nil)
+	repo.EXPECT().CreateBatch(mock.Anything, []repository.URLRow{
+		{ShortCode: "code7", OriginalURL: "https://example.com/new", RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop},
+	}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(linkMatching("code7", "https://example.com/new")).Return().Once()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(7)).Return("code7", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deduplicated", float64(2), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "batch_size", float64(3), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	resp, err := svc.CreateShortURLBatch(context.Background(), []domain.CreateURLRequest{
+		{URL: "https://example.com/new", Dedupe: true},
+		{URL: "https://example.com/old", Dedupe: true},
+		{URL: "https://example.com/new", Dedupe: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp, 3)
+	assert.Equal(t, "code7", resp[0].ShortCode)
+	assert.False(t, resp[0].Deduplicated)
+	assert.Equal(t, "old123", resp[1].ShortCode)
+	assert.True(t, resp[1].Deduplicated)
+	assert.Equal(t, "code7", resp[2].ShortCode)
+	assert.True(t, resp[2].Deduplicated)
+}
+
+// Ownership tests
+
+func TestCreateShortURL_RecordsOwner(t *testing.T) {
+	owner := int64(7)
+	ctx := auth.NewContext(context.Background(), &domain.APIKey{ID: owner})
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().FindByOriginalURLs(mock.Anything, []string{"https://example.com"}, &owner).Return(map[string]string{}, nil)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{ShortCode: "xyz789", OriginalURL: "https://example.com", OwnerKeyID: &owner, RedirectStatus: http.StatusFound, QueryPolicy: domain.QueryDrop}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.MatchedBy(func(u *domain.URL) bool { return *u.OwnerKeyID == owner })).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.CreateShortURL(ctx, &domain.CreateURLRequest{URL: "https://example.com", Dedupe: true})
+	require.NoError(t, err)
+}
+
+func TestDeleteURL_OtherOwnerNotFound(t *testing.T) {
+	owner := int64(7)
+	ctx := auth.NewContext(context.Background(), &domain.APIKey{ID: owner})
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "abc123", &owner, mock.Anything).Return(pgx.ErrNoRows)
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url", mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	err := svc.DeleteURL(ctx, "abc123")
+	assert.ErrorIs(t, err, service.ErrURLNotFound)
+}
+
+func TestGetOriginalURL_PreviewRecordsPreviewMetric(t *testing.T) {
+	created := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC)
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(&domain.URL{ShortCode: "abc123", OriginalURL: "https://example.com", CreatedAt: created, Active: true}, true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "preview", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Preview: true})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", redirect.URL)
+	assert.Equal(t, created, redirect.CreatedAt)
+}
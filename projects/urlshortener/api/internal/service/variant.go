@@ -0,0 +1,49 @@
+package service
+
+import (
+	"math/rand/v2"
+
+	"urlshortener/internal/domain"
+)
+
+// variantsOf returns the variants of req, naming unnamed ones a, b, c... by
+// position. Validation ensures that either all or none are named.
+func variantsOf(req *domain.CreateURLRequest) []domain.Variant {
+	if len(req.Variants) == 0 || req.Variants[0].Name != "" {
+		return req.Variants
+	}
+	named := make([]domain.Variant, len(req.Variants))
+	for i, v := range req.Variants {
+		v.Name = string(rune('a' + i))
+		named[i] = v
+	}
+	return named
+}
+
+// pickVariant returns the variant named assigned if there still is one,
+// otherwise a random variant chosen in proportion to the weights.
+func pickVariant(variants []domain.Variant, assigned string) domain.Variant {
+	if assigned != "" {
+		for _, v := range variants {
+			if v.Name == assigned {
+				return v
+			}
+		}
+	}
+
+	total := 0
+	for _, v := range variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return variants[0]
+	}
+	n := rand.IntN(total) //nolint:gosec // traffic splitting, not security
+	for _, v := range variants {
+		if n < v.Weight {
+			return v
+		}
+		n -= v.Weight
+	}
+	return variants[len(variants)-1]
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func splitLink(sticky bool) *domain.URL {
+	return &domain.URL{
+		ShortCode:   "abc123",
+		OriginalURL: "https://example.com/landing",
+		Active:      true,
+		Variants: []domain.Variant{
+			{Name: "a", URL: "https://example.com/landing-a", Weight: 70},
+			{Name: "b", URL: "https://example.com/landing-b", Weight: 30},
+		},
+		Sticky: sticky,
+	}
+}
+
+func TestGetOriginalURL_VariantsFollowWeights(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(splitLink(false), true)
+
+	served := make(map[string]int)
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "cache_hit", float64(1), mock.Anything).Return()
+	recorder.EXPECT().RecordBusiness(mock.Anything, "redirects", float64(1), mock.Anything).
+		Run(func(_ time.Time, _ string, _ float64, labels []byte) {
+			served[string(labels)]++
+		}).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	// The visitor's earlier variant is ignored because the split is not sticky.
+	const visits = 10000
+	for range visits {
+		redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Variant: "b"})
+		require.NoError(t, err)
+		assert.True(t, redirect.Conditional)
+		assert.False(t, redirect.Sticky)
+		assert.Equal(t, "https://example.com/landing-"+redirect.Variant, redirect.URL)
+	}
+
+	labelsA := `{"short_code":"abc123","original_url":"https://example.com/landing","variant":"a"}`
+	labelsB := `{"short_code":"abc123","original_url":"https://example.com/landing","variant":"b"}`
+	require.Len(t, served, 2)
+	assert.Equal(t, visits, served[labelsA]+served[labelsB])
+	assert.InDelta(t, 0.7, float64(served[labelsA])/visits, 0.03)
+}
+
+func TestGetOriginalURL_StickyVariant(t *testing.T) {
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Get("abc123").Return(splitLink(true), true)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, mock.Anything, float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(mocks.NewMockRepository(t), mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	for range 20 {
+		redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Variant: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/landing-b", redirect.URL)
+		assert.Equal(t, "b", redirect.Variant)
+		assert.True(t, redirect.Sticky)
+	}
+
+	// A variant that no longer exists is reassigned.
+	redirect, err := svc.GetOriginalURL(context.Background(), "abc123", domain.Visit{Variant: "removed"})
+	require.NoError(t, err)
+	assert.Contains(t, []string{"a", "b"}, redirect.Variant)
+}
+
+func TestCreateShortURL_NamesVariants(t *testing.T) {
+	variants := []domain.Variant{
+		{URL: "https://example.com/a", Weight: 1},
+		{URL: "https://example.com/b", Weight: 1},
+	}
+
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().NextID(mock.Anything).Return(uint(42), nil)
+	repo.EXPECT().Create(mock.Anything, repository.URLRow{
+		ShortCode:      "xyz789",
+		OriginalURL:    "https://example.com",
+		RedirectStatus: http.StatusFound,
+		QueryPolicy:    domain.QueryDrop,
+		Variants: []domain.Variant{
+			{Name: "a", URL: "https://example.com/a", Weight: 1},
+			{Name: "b", URL: "https://example.com/b", Weight: 1},
+		},
+		Sticky: true,
+	}, mock.Anything).Return(nil)
+
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Set(mock.Anything).Return()
+
+	shortener := mocks.NewMockCodeGenerator(t)
+	shortener.EXPECT().Generate(uint(42)).Return("xyz789", nil)
+
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_created", float64(1), mock.Anything).Return()
+
+	svc := service.NewURLService(repo, shortener, cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), anyEvents(t))
+
+	_, err := svc.CreateShortURL(context.Background(), &domain.CreateURLRequest{
+		URL:      "https://example.com",
+		Dedupe:   true,
+		Variants: variants,
+		Sticky:   true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, variants[0].Name, "the request is not modified")
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"urlshortener/internal/domain"
+)
+
+const webhookSecretPrefix = "whsec_"
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrInvalidEvents    = errors.New("invalid events")
+	ErrDeliveryNotFound = errors.New("dead delivery not found")
+	ErrInvalidStatus    = errors.New("invalid delivery status")
+)
+
+func (s *URLService) publish(eventType string, data domain.LinkEvent, now time.Time) {
+	s.events.Publish(domain.Event{Type: eventType, OccurredAt: now, Data: data})
+}
+
+type WebhookService struct {
+	repo          WebhookRepository
+	subscriptions WebhookSubscriptions
+}
+
+func NewWebhookService(repo WebhookRepository, subscriptions WebhookSubscriptions) *WebhookService {
+	return &WebhookService{repo: repo, subscriptions: subscriptions}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *domain.CreateWebhookRequest) (*domain.CreateWebhookResponse, error) {
+	if len(req.Events) == 0 {
+		return nil, ErrInvalidEvents
+	}
+	for _, event := range req.Events {
+		if !slices.Contains(domain.Events, event) {
+			return nil, ErrInvalidEvents
+		}
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	secret := webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	hook, err := s.repo.Create(ctx, req.URL, slices.Compact(slices.Sorted(slices.Values(req.Events))), secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	s.subscriptions.RefreshSubscriptions(ctx)
+	return &domain.CreateWebhookResponse{Webhook: *hook, Secret: secret}, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	s.subscriptions.RefreshSubscriptions(ctx)
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, status string, webhookID int64, cursor string, limit int) (*domain.DeliveryPage, error) {
+	if status != "" && status != domain.DeliveryPending && status != domain.DeliveryDead {
+		return nil, ErrInvalidStatus
+	}
+	after, err := decodeIDCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	deliveries, err := s.repo.Deliveries(ctx, status, webhookID, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+
+	page := &domain.DeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		page.NextCursor = encodeIDCursor(deliveries[limit-1].ID)
+	}
+	if page.Deliveries == nil {
+		page.Deliveries = []domain.WebhookDelivery{}
+	}
+	return page, nil
+}
+
+func (s *WebhookService) RetryDelivery(ctx context.Context, id int64) error {
+	if err := s.repo.Retry(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrDeliveryNotFound
+		}
+		return fmt.Errorf("failed to retry delivery: %w", err)
+	}
+	return nil
+}
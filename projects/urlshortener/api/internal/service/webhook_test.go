@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/service"
+	"urlshortener/internal/service/mocks"
+)
+
+func TestCreateWebhook_GeneratesSecret(t *testing.T) {
+	repo := mocks.NewMockWebhookRepository(t)
+	var secret string
+	repo.EXPECT().Create(mock.Anything, "https://hooks.example.com", []string{domain.EventLinkClicked, domain.EventLinkCreated}, mock.Anything).
+		RunAndReturn(func(_ context.Context, url string, events []string, s string) (*domain.Webhook, error) {
+			secret = s
+			return &domain.Webhook{ID: 1, URL: url, Events: events, CreatedAt: time.Now()}, nil
+		})
+
+	subscriptions := mocks.NewMockWebhookSubscriptions(t)
+	subscriptions.EXPECT().RefreshSubscriptions(mock.Anything).Return().Once()
+
+	svc := service.NewWebhookService(repo, subscriptions)
+
+	resp, err := svc.CreateWebhook(context.Background(), &domain.CreateWebhookRequest{
+		URL:    "https://hooks.example.com",
+		Events: []string{domain.EventLinkCreated, domain.EventLinkClicked, domain.EventLinkCreated},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, secret, resp.Secret)
+	assert.True(t, strings.HasPrefix(resp.Secret, "whsec_"))
+	assert.Len(t, resp.Secret, len("whsec_")+43)
+	assert.Equal(t, int64(1), resp.ID)
+}
+
+func TestCreateWebhook_InvalidEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []string
+	}{
+		{"none", nil},
+		{"unknown", []string{domain.EventLinkCreated, "link.*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewWebhookService(mocks.NewMockWebhookRepository(t), mocks.NewMockWebhookSubscriptions(t))
+			_, err := svc.CreateWebhook(context.Background(), &domain.CreateWebhookRequest{URL: "https://hooks.example.com", Events: tt.events})
+			assert.ErrorIs(t, err, service.ErrInvalidEvents)
+		})
+	}
+}
+
+func TestDeleteWebhook_RefreshesSubscriptions(t *testing.T) {
+	repo := mocks.NewMockWebhookRepository(t)
+	repo.EXPECT().Delete(mock.Anything, int64(9)).Return(nil)
+	subscriptions := mocks.NewMockWebhookSubscriptions(t)
+	subscriptions.EXPECT().RefreshSubscriptions(mock.Anything).Return().Once()
+
+	require.NoError(t, service.NewWebhookService(repo, subscriptions).DeleteWebhook(context.Background(), 9))
+}
+
+func TestDeleteWebhook_NotFound(t *testing.T) {
+	repo := mocks.NewMockWebhookRepository(t)
+	repo.EXPECT().Delete(mock.Anything, int64(9)).Return(pgx.ErrNoRows)
+
+	err := service.NewWebhookService(repo, mocks.NewMockWebhookSubscriptions(t)).DeleteWebhook(context.Background(), 9)
+	assert.ErrorIs(t, err, service.ErrWebhookNotFound)
+}
+
+func TestListDeliveries_Pages(t *testing.T) {
+	repo := mocks.NewMockWebhookRepository(t)
+	repo.EXPECT().Deliveries(mock.Anything, domain.DeliveryDead, int64(3), int64(0), 3).
+		Return([]domain.WebhookDelivery{{ID: 4}, {ID: 8}, {ID: 15}}, nil)
+
+	svc := service.NewWebhookService(repo, mocks.NewMockWebhookSubscriptions(t))
+
+	page, err := svc.ListDeliveries(context.Background(), domain.DeliveryDead, 3, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page.Deliveries, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	repo.EXPECT().Deliveries(mock.Anything, domain.DeliveryDead, int64(3), int64(8), 3).
+		Return([]domain.WebhookDelivery{{ID: 15}}, nil)
+
+	page, err = svc.ListDeliveries(context.Background(), domain.DeliveryDead, 3, page.NextCursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{{ID: 15}}, page.Deliveries)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestListDeliveries_Invalid(t *testing.T) {
+	svc := service.NewWebhookService(mocks.NewMockWebhookRepository(t), mocks.NewMockWebhookSubscriptions(t))
+
+	_, err := svc.ListDeliveries(context.Background(), "delivered", 0, "", 0)
+	assert.ErrorIs(t, err, service.ErrInvalidStatus)
+
+	_, err = svc.ListDeliveries(context.Background(), "", 0, "!!", 0)
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+}
+
+func TestRetryDelivery_NotDead(t *testing.T) {
+	repo := mocks.NewMockWebhookRepository(t)
+	repo.EXPECT().Retry(mock.Anything, int64(5)).Return(pgx.ErrNoRows)
+
+	err := service.NewWebhookService(repo, mocks.NewMockWebhookSubscriptions(t)).RetryDelivery(context.Background(), 5)
+	assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+}
+
+func TestDeleteURL_PublishesEvent(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "abc123", noOwner, mock.Anything).Return(nil)
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("abc123").Return()
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deleted", float64(1), mock.Anything).Return()
+
+	events := mocks.NewMockEventPublisher(t)
+	events.EXPECT().Publish(mock.MatchedBy(func(e domain.Event) bool {
+		return e.Type == domain.EventLinkDeleted && e.Data == domain.LinkEvent{ShortCode: "abc123"} && !e.OccurredAt.IsZero()
+	})).Return().Once()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), events)
+
+	require.NoError(t, svc.DeleteURL(context.Background(), "abc123"))
+}
+
+func TestSetURLActive_PublishesEvent(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().SetActive(mock.Anything, "abc123", false, noOwner, mock.Anything).Return(nil)
+	cache := mocks.NewMockCache(t)
+	cache.EXPECT().Delete("abc123").Return()
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "urls_deactivated", float64(1), mock.Anything).Return()
+
+	var published domain.Event
+	events := mocks.NewMockEventPublisher(t)
+	events.EXPECT().Publish(mock.Anything).Run(func(e domain.Event) { published = e }).Return().Once()
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), cache, "http://short.url", recorder, mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), events)
+
+	require.NoError(t, svc.SetURLActive(context.Background(), "abc123", false))
+	assert.Equal(t, domain.EventLinkUpdated, published.Type)
+	require.NotNil(t, published.Data.Active)
+	assert.False(t, *published.Data.Active)
+}
+
+func TestDeleteURL_NotFoundPublishesNothing(t *testing.T) {
+	repo := mocks.NewMockRepository(t)
+	repo.EXPECT().Delete(mock.Anything, "notfound", noOwner, mock.Anything).Return(pgx.ErrNoRows)
+
+	svc := service.NewURLService(repo, mocks.NewMockCodeGenerator(t), mocks.NewMockCache(t), "http://short.url",
+		mocks.NewMockBusinessRecorder(t), mocks.NewMockAttemptLimiter(t), mocks.NewMockHealthChecker(t), mocks.NewMockEventPublisher(t))
+
+	assert.ErrorIs(t, svc.DeleteURL(context.Background(), "notfound"), service.ErrURLNotFound)
+}
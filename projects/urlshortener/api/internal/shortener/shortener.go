@@ -19,3 +19,15 @@ func New() (*Shortener, error) {
 func (s *Shortener) Generate(id uint) (string, error) {
 	return s.sqids.Encode([]uint64{uint64(id)})
 }
+
+// IsGenerated reports whether code is a canonical sqids encoding that Generate
+// produces for some id. Custom aliases must not be such codes, otherwise a later
+// generated code could collide with them.
+func (s *Shortener) IsGenerated(code string) bool {
+	ids := s.sqids.Decode(code)
+	if len(ids) != 1 {
+		return false
+	}
+	encoded, err := s.sqids.Encode(ids)
+	return err == nil && encoded == code
+}
@@ -70,3 +70,18 @@ func TestGenerate_Deterministic(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "A6das1", code)
 }
+
+func TestIsGenerated(t *testing.T) {
+	s, err := shortener.New()
+	require.NoError(t, err)
+
+	for _, id := range []uint{0, 1, 12345, 1_000_000_000} {
+		code, err := s.Generate(id)
+		require.NoError(t, err)
+		assert.True(t, s.IsGenerated(code), "code %q for id %d", code, id)
+	}
+
+	assert.False(t, s.IsGenerated("spring-sale"))
+	assert.False(t, s.IsGenerated("promo"))
+	assert.False(t, s.IsGenerated(""))
+}
@@ -0,0 +1,58 @@
+package validation
+
+import "strings"
+
+const (
+	minAliasLength = 3
+	maxAliasLength = 16 // matches urls.short_code VARCHAR(16)
+)
+
+// reservedAliases are rejected even while no route uses them, such as debug
+// with pprof disabled, so enabling a route never shadows an existing link.
+var reservedAliases = map[string]bool{
+	"api":     true,
+	"debug":   true,
+	"health":  true,
+	"metrics": true,
+}
+
+// ReserveAliases makes ValidateAlias reject names as well, compared
+// case-insensitively. It is meant for the first path segments of the routes
+// registered next to /:code, and must be called before the validator is used.
+func (v *URLValidator) ReserveAliases(names ...string) {
+	if v.reservedAliases == nil {
+		v.reservedAliases = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		v.reservedAliases[strings.ToLower(name)] = true
+	}
+}
+
+func (v *URLValidator) ValidateAlias(alias string) error {
+	if alias == "" {
+		return nil
+	}
+
+	if len(alias) < minAliasLength || len(alias) > maxAliasLength {
+		return ErrInvalidAlias
+	}
+
+	for _, r := range alias {
+		if !isAliasChar(r) {
+			return ErrInvalidAlias
+		}
+	}
+
+	if lower := strings.ToLower(alias); reservedAliases[lower] || v.reservedAliases[lower] {
+		return ErrReservedAlias
+	}
+
+	return nil
+}
+
+func isAliasChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9') ||
+		r == '-' || r == '_'
+}
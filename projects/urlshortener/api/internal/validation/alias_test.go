@@ -0,0 +1,56 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateAlias(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+	v.ReserveAliases("Docs")
+
+	tests := []struct {
+		name    string
+		alias   string
+		wantErr error
+	}{
+		// Valid aliases
+		{"empty means no alias", "", nil},
+		{"lowercase with dash", "spring-sale", nil},
+		{"mixed case with underscore", "Black_Friday", nil},
+		{"digits", "2024", nil},
+		{"min length", "abc", nil},
+		{"max length", "abcdefghijklmnop", nil},
+
+		// Invalid format
+		{"too short", "ab", validation.ErrInvalidAlias},
+		{"too long", "abcdefghijklmnopq", validation.ErrInvalidAlias},
+		{"slash", "spring/sale", validation.ErrInvalidAlias},
+		{"dot", "robots.txt", validation.ErrInvalidAlias},
+		{"space", "spring sale", validation.ErrInvalidAlias},
+		{"non-ascii", "prüfung", validation.ErrInvalidAlias},
+
+		// Reserved
+		{"api", "api", validation.ErrReservedAlias},
+		{"debug", "debug", validation.ErrReservedAlias},
+		{"health", "health", validation.ErrReservedAlias},
+		{"metrics", "metrics", validation.ErrReservedAlias},
+		{"reserved is case-insensitive", "API", validation.ErrReservedAlias},
+		{"reserved from a route", "docs", validation.ErrReservedAlias},
+		{"reserved from a route is case-insensitive", "DOCS", validation.ErrReservedAlias},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateAlias(tt.alias)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
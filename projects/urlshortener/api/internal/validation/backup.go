@@ -0,0 +1,18 @@
+package validation
+
+import "context"
+
+const maxBackups = 5
+
+// ValidateBackups checks backup destinations like the primary one.
+func (v *URLValidator) ValidateBackups(ctx context.Context, backups []string) error {
+	if len(backups) > maxBackups {
+		return ErrInvalidBackups
+	}
+	for _, backup := range backups {
+		if err := v.ValidateURL(ctx, backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateBackups(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidateBackups(context.Background(), nil))
+	assert.NoError(t, v.ValidateBackups(context.Background(), []string{"https://mirror1.example.com", "https://mirror2.example.com"}))
+	assert.ErrorIs(t, v.ValidateBackups(context.Background(), []string{"javascript:alert(1)"}), validation.ErrUnsafeProtocol)
+	assert.ErrorIs(t, v.ValidateBackups(context.Background(), make([]string, 6)), validation.ErrInvalidBackups)
+}
+
+func TestURLValidator_ValidateRequest_BackupsWithVariants(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	err := v.ValidateRequest(context.Background(), &domain.CreateURLRequest{
+		URL:     "https://example.com",
+		Backups: []string{"https://mirror.example.com"},
+		Variants: []domain.Variant{
+			{URL: "https://example.com/a", Weight: 1},
+			{URL: "https://example.com/b", Weight: 1},
+		},
+	})
+	assert.ErrorIs(t, err, validation.ErrInvalidBackups)
+}
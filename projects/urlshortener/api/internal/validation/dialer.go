@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+)
+
+// NewDialer returns a dialer that refuses to connect to private and reserved
+// addresses, unless allowPrivateIPs. URLs are checked when they are saved, but
+// their hostnames can point elsewhere by the time they are fetched; checking
+// the address actually dialed also defeats DNS rebinding.
+func NewDialer(allowPrivateIPs bool) *net.Dialer {
+	dialer := &net.Dialer{}
+	if allowPrivateIPs {
+		return dialer
+	}
+	v := NewIPValidator()
+	dialer.Control = func(_, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		addr, err := netip.ParseAddr(host)
+		if err != nil {
+			return err
+		}
+		if err := v.validateIP(addr); err != nil {
+			return fmt.Errorf("dial %s: %w", address, err)
+		}
+		return nil
+	}
+	return dialer
+}
+
+// NewTransport returns a transport that connects through dialer. It never uses
+// a proxy, which would make dialer check the proxy instead of the destination.
+func NewTransport(dialer *net.Dialer) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
@@ -0,0 +1,29 @@
+package validation_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/validation"
+)
+
+func TestNewDialer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: validation.NewTransport(validation.NewDialer(false))}
+	_, err := client.Get(srv.URL)
+	assert.ErrorIs(t, err, validation.ErrPrivateIPNotAllowed, "loopback is refused at connect time")
+
+	client = &http.Client{Transport: validation.NewTransport(validation.NewDialer(true))}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
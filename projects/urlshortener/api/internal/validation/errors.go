@@ -8,8 +8,29 @@ var (
 	ErrUnsafeProtocol      = errors.New("url protocol not allowed")
 	ErrURLTooLong          = errors.New("url exceeds maximum length")
 	ErrPrivateIPNotAllowed = errors.New("private ip addresses not allowed")
+	ErrUnresolvableHost    = errors.New("url host could not be resolved")
 	ErrBatchTooLarge       = errors.New("batch size exceeds maximum")
 	ErrEmptyBatch          = errors.New("urls is required")
+	ErrEmptyCodes          = errors.New("codes is required")
+	ErrInvalidAlias        = errors.New("invalid alias format")
+	ErrReservedAlias       = errors.New("alias is reserved")
+	ErrDuplicateAlias      = errors.New("duplicate alias in batch")
+	ErrConflictingExpiry   = errors.New("expires_at and ttl are mutually exclusive")
+	ErrInvalidTTL          = errors.New("ttl must be positive and at most 10 years")
+	ErrExpiryInPast        = errors.New("expires_at must be in the future")
+	ErrPasswordTooLong     = errors.New("password exceeds maximum length")
+	ErrPasswordInBatch     = errors.New("password is not supported in batch")
+	ErrInvalidRedirect     = errors.New("redirect_status must be 301, 302, 307 or 308")
+	ErrInvalidQueryPolicy  = errors.New("query_policy must be drop, append or override")
+	ErrInvalidUTM          = errors.New("invalid utm parameters")
+	ErrTooManyRules        = errors.New("too many routing rules")
+	ErrInvalidRule         = errors.New("invalid routing rule")
+	ErrInvalidVariants     = errors.New("invalid variants")
+	ErrInvalidBackups      = errors.New("invalid backups")
+	ErrInvalidTags         = errors.New("invalid tags")
+	ErrInvalidCampaign     = errors.New("invalid campaign")
+	ErrInvalidSchedule     = errors.New("active_from must be before the link expires")
+	ErrInvalidPrelaunch    = errors.New("invalid prelaunch url")
 )
 
 type BatchValidationError struct {
@@ -0,0 +1,23 @@
+package validation
+
+import "time"
+
+// maxTTL is the longest ttl accepted, in seconds. It keeps the expiry well
+// within the range of time.Duration.
+const maxTTL = 10 * 365 * 24 * 60 * 60
+
+func (v *URLValidator) ValidateExpiry(expiresAt *time.Time, ttl int) error {
+	if expiresAt != nil && ttl != 0 {
+		return ErrConflictingExpiry
+	}
+
+	if ttl < 0 || ttl > maxTTL {
+		return ErrInvalidTTL
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return ErrExpiryInPast
+	}
+
+	return nil
+}
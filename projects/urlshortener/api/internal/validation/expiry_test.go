@@ -0,0 +1,45 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateExpiry(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		ttl       int
+		wantErr   error
+	}{
+		{"no expiry", nil, 0, nil},
+		{"future expires_at", &future, 0, nil},
+		{"positive ttl", nil, 3600, nil},
+		{"past expires_at", &past, 0, validation.ErrExpiryInPast},
+		{"ten year ttl", nil, 10 * 365 * 24 * 3600, nil},
+		{"negative ttl", nil, -1, validation.ErrInvalidTTL},
+		{"ttl over ten years", nil, 10*365*24*3600 + 1, validation.ErrInvalidTTL},
+		{"overflowing ttl", nil, 10000000000, validation.ErrInvalidTTL},
+		{"both set", &future, 3600, validation.ErrConflictingExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateExpiry(tt.expiresAt, tt.ttl)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,17 +1,62 @@
 package validation
 
 import (
+	"context"
 	"net/netip"
 	"strings"
+	"sync"
+	"time"
 )
 
-type IPValidator struct{}
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+type IPValidator struct {
+	resolver    Resolver
+	timeout     time.Duration
+	ttl         time.Duration
+	concurrency int
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+	hosts       map[string]resolvedHost
+}
+
+type resolvedHost struct {
+	err     error
+	expires time.Time
+}
 
 func NewIPValidator() *IPValidator {
 	return &IPValidator{}
 }
 
-func (v *IPValidator) ValidateHost(host string) error {
+func NewResolvingIPValidator(resolver Resolver, timeout, ttl time.Duration, concurrency int) *IPValidator {
+	return &IPValidator{
+		resolver:    resolver,
+		timeout:     timeout,
+		ttl:         ttl,
+		concurrency: max(1, concurrency),
+		hosts:       make(map[string]resolvedHost),
+	}
+}
+
+func (v *IPValidator) ValidateHost(ctx context.Context, host string) error {
+	hostname := hostOnly(host)
+	addr, err := netip.ParseAddr(hostname)
+	if err != nil {
+		// Not an IP literal, so only checked if a resolver is configured
+		if v.resolver == nil {
+			return nil
+		}
+		return v.validateHostname(ctx, hostname)
+	}
+
+	return v.validateIP(addr)
+}
+
+func hostOnly(host string) string {
 	hostname := host
 	if idx := strings.LastIndex(host, ":"); idx != -1 {
 		// Check if this is IPv6 with brackets
@@ -26,14 +71,106 @@ func (v *IPValidator) ValidateHost(host string) error {
 	if idx := strings.LastIndex(hostname, "]:"); idx != -1 {
 		hostname = hostname[:idx]
 	}
+	return hostname
+}
 
-	addr, err := netip.ParseAddr(hostname)
-	if err != nil {
-		// Not an IP literal, skip validation (no DNS resolution per design decision)
-		return nil
+type verdictsKey struct{}
+
+// Failed lookups are not cached, so their verdicts travel with ctx.
+func (v *IPValidator) resolveAll(ctx context.Context, hosts []string) context.Context {
+	if v.resolver == nil {
+		return ctx
+	}
+	names := make(map[string]bool)
+	for _, host := range hosts {
+		hostname := hostOnly(host)
+		if _, err := netip.ParseAddr(hostname); err != nil {
+			names[normalizeHostname(hostname)] = true
+		}
+	}
+	if len(names) == 0 {
+		return ctx
 	}
 
-	return v.validateIP(addr)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		verdicts = make(map[string]error, len(names))
+		queue    = make(chan string)
+	)
+	for range min(v.concurrency, len(names)) {
+		wg.Go(func() {
+			for hostname := range queue {
+				err := v.validateHostname(ctx, hostname)
+				mu.Lock()
+				verdicts[hostname] = err
+				mu.Unlock()
+			}
+		})
+	}
+	for hostname := range names {
+		queue <- hostname
+	}
+	close(queue)
+	wg.Wait()
+
+	return context.WithValue(ctx, verdictsKey{}, verdicts)
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(hostname), ".")
+}
+
+// Clients may connect to any address of a name, so every one is checked.
+func (v *IPValidator) validateHostname(ctx context.Context, hostname string) error {
+	hostname = normalizeHostname(hostname)
+	if verdicts, ok := ctx.Value(verdictsKey{}).(map[string]error); ok {
+		if verdict, found := verdicts[hostname]; found {
+			return verdict
+		}
+	}
+	now := time.Now()
+
+	v.mu.Lock()
+	cached, ok := v.hosts[hostname]
+	v.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.err
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+	addrs, err := v.resolver.LookupNetIP(lookupCtx, "ip", hostname)
+	if err != nil || len(addrs) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrUnresolvableHost
+	}
+
+	var verdict error
+	for _, addr := range addrs {
+		if verdict = v.validateIP(addr); verdict != nil {
+			break
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hosts[hostname] = resolvedHost{err: verdict, expires: now.Add(v.ttl)}
+	if now.Sub(v.lastCleanup) > v.ttl {
+		v.cleanup(now)
+	}
+	return verdict
+}
+
+func (v *IPValidator) cleanup(now time.Time) {
+	for hostname, h := range v.hosts {
+		if !now.Before(h.expires) {
+			delete(v.hosts, hostname)
+		}
+	}
+	v.lastCleanup = now
 }
 
 func (v *IPValidator) validateIP(addr netip.Addr) error {
@@ -58,37 +195,30 @@ func (v *IPValidator) validateIP(addr netip.Addr) error {
 	return nil
 }
 
-func (v *IPValidator) isReservedRange(addr netip.Addr) bool {
-	if !addr.Is4() {
-		return false
-	}
-
-	ip4 := addr.As4()
-
-	// 100.64.0.0/10 (Carrier-grade NAT)
-	if ip4[0] == 100 && ip4[1] >= 64 && ip4[1] <= 127 {
-		return true
-	}
-
-	// 192.0.0.0/24 (IETF Protocol Assignments)
-	if ip4[0] == 192 && ip4[1] == 0 && ip4[2] == 0 {
-		return true
-	}
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "This network", which some stacks route to the host
+	netip.MustParsePrefix("100.64.0.0/10"),   // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF Protocol Assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved, including broadcast
+	netip.MustParsePrefix("2002::/16"),       // 6to4, which can embed any IPv4 address
+}
 
-	// 192.0.2.0/24 (TEST-NET-1)
-	if ip4[0] == 192 && ip4[1] == 0 && ip4[2] == 2 {
-		return true
-	}
+// NAT64 addresses reach the IPv4 address in their last four bytes.
+var nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
 
-	// 198.51.100.0/24 (TEST-NET-2)
-	if ip4[0] == 198 && ip4[1] == 51 && ip4[2] == 100 {
-		return true
+func (v *IPValidator) isReservedRange(addr netip.Addr) bool {
+	if nat64Prefix.Contains(addr) {
+		a := addr.As16()
+		return v.validateIP(netip.AddrFrom4([4]byte(a[12:]))) != nil
 	}
-
-	// 203.0.113.0/24 (TEST-NET-3)
-	if ip4[0] == 203 && ip4[1] == 0 && ip4[2] == 113 {
-		return true
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
 	}
-
 	return false
 }
@@ -1,10 +1,18 @@
 package validation_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"urlshortener/internal/domain"
 	"urlshortener/internal/validation"
 )
 
@@ -16,7 +24,7 @@ func TestIPValidator_ValidateHost(t *testing.T) {
 		host    string
 		wantErr error
 	}{
-		// Hostnames (no DNS resolution, so allowed)
+		// Hostnames (no resolver, so allowed)
 		{"hostname", "example.com", nil},
 		{"hostname with port", "example.com:8080", nil},
 		{"localhost hostname", "localhost", nil},
@@ -53,6 +61,22 @@ func TestIPValidator_ValidateHost(t *testing.T) {
 		// IETF Protocol Assignments
 		{"ietf protocol", "192.0.0.1", validation.ErrPrivateIPNotAllowed},
 
+		// Other reserved ranges
+		{"this network", "0.1.2.3", validation.ErrPrivateIPNotAllowed},
+		{"benchmarking", "198.18.0.1", validation.ErrPrivateIPNotAllowed},
+		{"benchmarking upper", "198.19.255.255", validation.ErrPrivateIPNotAllowed},
+		{"after benchmarking", "198.20.0.1", nil},
+		{"reserved class e", "240.0.0.1", validation.ErrPrivateIPNotAllowed},
+		{"broadcast", "255.255.255.255", validation.ErrPrivateIPNotAllowed},
+		{"6to4", "[2002:c0a8:101::1]", validation.ErrPrivateIPNotAllowed},
+		{"6to4 of public ipv4", "[2002:808:808::1]", validation.ErrPrivateIPNotAllowed},
+
+		// NAT64 reaches the embedded IPv4 address
+		{"nat64 private", "[64:ff9b::10.0.0.1]", validation.ErrPrivateIPNotAllowed},
+		{"nat64 loopback", "[64:ff9b::7f00:1]:8080", validation.ErrPrivateIPNotAllowed},
+		{"nat64 metadata", "[64:ff9b::169.254.169.254]", validation.ErrPrivateIPNotAllowed},
+		{"nat64 public", "[64:ff9b::8.8.8.8]", nil},
+
 		// Unspecified
 		{"unspecified ipv4", "0.0.0.0", validation.ErrPrivateIPNotAllowed},
 		{"unspecified ipv6", "[::]", validation.ErrPrivateIPNotAllowed},
@@ -60,7 +84,7 @@ func TestIPValidator_ValidateHost(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := v.ValidateHost(tt.host)
+			err := v.ValidateHost(context.Background(), tt.host)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
@@ -85,7 +109,101 @@ func TestIPValidator_ValidateHost_IPv4MappedIPv6(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := v.ValidateHost(tt.host)
+			err := v.ValidateHost(context.Background(), tt.host)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// fakeResolver answers from a fixed table after delay and counts lookups.
+// Hostnames in slow block until the lookup is cancelled.
+type fakeResolver struct {
+	mu       sync.Mutex
+	addrs    map[string][]string
+	slow     map[string]bool
+	lookups  map[string]int
+	delay    time.Duration
+	inFlight int
+	peak     int
+}
+
+func newFakeResolver(addrs map[string][]string) *fakeResolver {
+	return &fakeResolver{addrs: addrs, slow: map[string]bool{}, lookups: map[string]int{}}
+}
+
+func (r *fakeResolver) LookupNetIP(ctx context.Context, _, host string) ([]netip.Addr, error) {
+	r.mu.Lock()
+	r.lookups[host]++
+	slow := r.slow[host]
+	r.inFlight++
+	r.peak = max(r.peak, r.inFlight)
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.inFlight--
+		r.mu.Unlock()
+	}()
+
+	time.Sleep(r.delay)
+	if slow {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	raw, ok := r.addrs[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	addrs := make([]netip.Addr, len(raw))
+	for i, a := range raw {
+		addrs[i] = netip.MustParseAddr(a)
+	}
+	return addrs, nil
+}
+
+func (r *fakeResolver) count(host string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookups[host]
+}
+
+func TestIPValidator_ResolvesHostnames(t *testing.T) {
+	resolver := newFakeResolver(map[string][]string{
+		"example.com":     {"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"},
+		"internal-server": {"10.0.0.8"},
+		"localhost":       {"127.0.0.1", "::1"},
+		"mixed.example":   {"93.184.216.34", "192.168.1.10"},
+		"metadata.test":   {"169.254.169.254"},
+		"cgnat.test":      {"100.64.0.1"},
+		"mapped.test":     {"::ffff:10.1.2.3"},
+	})
+	v := validation.NewResolvingIPValidator(resolver, time.Second, time.Minute, 4)
+
+	tests := []struct {
+		name    string
+		host    string
+		wantErr error
+	}{
+		{"public", "example.com", nil},
+		{"public with port", "example.com:8443", nil},
+		{"case and trailing dot", "Example.COM.", nil},
+		{"internal name", "internal-server", validation.ErrPrivateIPNotAllowed},
+		{"internal name with port", "internal-server:8080", validation.ErrPrivateIPNotAllowed},
+		{"localhost", "localhost", validation.ErrPrivateIPNotAllowed},
+		{"any private address", "mixed.example", validation.ErrPrivateIPNotAllowed},
+		{"link-local", "metadata.test", validation.ErrPrivateIPNotAllowed},
+		{"reserved", "cgnat.test", validation.ErrPrivateIPNotAllowed},
+		{"ipv4-mapped", "mapped.test", validation.ErrPrivateIPNotAllowed},
+		{"unknown", "nxdomain.test", validation.ErrUnresolvableHost},
+		{"ip literal", "10.0.0.1", validation.ErrPrivateIPNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateHost(context.Background(), tt.host)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
@@ -93,4 +211,118 @@ func TestIPValidator_ValidateHost_IPv4MappedIPv6(t *testing.T) {
 			}
 		})
 	}
+	assert.Zero(t, resolver.count("10.0.0.1"), "ip literals are not looked up")
+}
+
+func TestIPValidator_CachesVerdicts(t *testing.T) {
+	resolver := newFakeResolver(map[string][]string{
+		"example.com":     {"93.184.216.34"},
+		"internal-server": {"10.0.0.8"},
+	})
+	v := validation.NewResolvingIPValidator(resolver, time.Second, time.Minute, 4)
+
+	for range 3 {
+		require.NoError(t, v.ValidateHost(context.Background(), "example.com"))
+		require.ErrorIs(t, v.ValidateHost(context.Background(), "internal-server:8080"), validation.ErrPrivateIPNotAllowed)
+		require.ErrorIs(t, v.ValidateHost(context.Background(), "nxdomain.test"), validation.ErrUnresolvableHost)
+	}
+	assert.Equal(t, 1, resolver.count("example.com"))
+	assert.Equal(t, 1, resolver.count("internal-server"))
+	assert.Equal(t, 3, resolver.count("nxdomain.test"), "failed lookups are not cached")
+}
+
+func TestIPValidator_CacheExpires(t *testing.T) {
+	resolver := newFakeResolver(map[string][]string{"rebind.test": {"93.184.216.34"}})
+	v := validation.NewResolvingIPValidator(resolver, time.Second, 10*time.Millisecond, 4)
+
+	require.NoError(t, v.ValidateHost(context.Background(), "rebind.test"))
+
+	resolver.mu.Lock()
+	resolver.addrs["rebind.test"] = []string{"10.0.0.8"}
+	resolver.mu.Unlock()
+	time.Sleep(20 * time.Millisecond)
+
+	assert.ErrorIs(t, v.ValidateHost(context.Background(), "rebind.test"), validation.ErrPrivateIPNotAllowed)
+	assert.Equal(t, 2, resolver.count("rebind.test"))
+}
+
+func TestIPValidator_LookupTimeout(t *testing.T) {
+	resolver := newFakeResolver(nil)
+	resolver.slow["slow.test"] = true
+	v := validation.NewResolvingIPValidator(resolver, 20*time.Millisecond, time.Minute, 4)
+
+	start := time.Now()
+	err := v.ValidateHost(context.Background(), "slow.test")
+	assert.ErrorIs(t, err, validation.ErrUnresolvableHost)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestURLValidator_ResolveHosts(t *testing.T) {
+	resolver := newFakeResolver(map[string][]string{
+		"example.com":     {"93.184.216.34"},
+		"internal-server": {"10.0.0.8"},
+	})
+
+	v := validation.NewURLValidator(2048, 100, false)
+	require.NoError(t, v.ValidateURL(context.Background(), "http://internal-server/"), "hostnames pass without a resolver")
+
+	v.ResolveHosts(resolver, time.Second, time.Minute, 4)
+	assert.NoError(t, v.ValidateURL(context.Background(), "https://example.com/page"))
+	assert.ErrorIs(t, v.ValidateURL(context.Background(), "http://internal-server/"), validation.ErrPrivateIPNotAllowed)
+
+	allowed := validation.NewURLValidator(2048, 100, true)
+	allowed.ResolveHosts(resolver, time.Second, time.Minute, 4)
+	assert.NoError(t, allowed.ValidateURL(context.Background(), "http://internal-server/"))
+	assert.Equal(t, 1, resolver.count("internal-server"), "not looked up when private IPs are allowed")
+}
+
+func TestURLValidator_ValidateBatchEntries_ResolvesConcurrently(t *testing.T) {
+	addrs := map[string][]string{"internal-server": {"10.0.0.8"}}
+	var reqs []domain.CreateURLRequest
+	for i := range 40 {
+		host := fmt.Sprintf("host%d.example", i)
+		addrs[host] = []string{"93.184.216.34"}
+		reqs = append(reqs, domain.CreateURLRequest{URL: "https://" + host + "/a"}, domain.CreateURLRequest{URL: "https://" + host + "/b"})
+	}
+	reqs = append(reqs,
+		domain.CreateURLRequest{URL: "https://example.com", Backups: []string{"http://internal-server/"}},
+		domain.CreateURLRequest{URL: "https://nxdomain.test/a"},
+		domain.CreateURLRequest{URL: "https://nxdomain.test/b"},
+	)
+	resolver := newFakeResolver(addrs)
+	resolver.addrs["example.com"] = []string{"93.184.216.34"}
+	resolver.delay = 5 * time.Millisecond
+
+	v := validation.NewURLValidator(2048, 100, false)
+	v.ResolveHosts(resolver, time.Second, time.Minute, 8)
+
+	errs, err := v.ValidateBatchEntries(context.Background(), reqs)
+	require.NoError(t, err)
+	require.Len(t, errs, len(reqs))
+	for i := range 80 {
+		assert.NoError(t, errs[i], "entry %d", i)
+	}
+	assert.ErrorIs(t, errs[80], validation.ErrPrivateIPNotAllowed)
+	assert.ErrorIs(t, errs[81], validation.ErrUnresolvableHost)
+	assert.ErrorIs(t, errs[82], validation.ErrUnresolvableHost)
+
+	assert.Equal(t, 8, resolver.peak, "lookups run up to the concurrency limit")
+	assert.Equal(t, 1, resolver.count("host0.example"))
+	assert.Equal(t, 1, resolver.count("nxdomain.test"), "each hostname is looked up once per batch")
+}
+
+func TestURLValidator_ValidateBatchEntries_Cancelled(t *testing.T) {
+	resolver := newFakeResolver(nil)
+	resolver.slow["slow.test"] = true
+	v := validation.NewURLValidator(2048, 100, false)
+	v.ResolveHosts(resolver, time.Minute, time.Minute, 4)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := v.ValidateBatchEntries(ctx, []domain.CreateURLRequest{{URL: "https://slow.test"}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = v.ValidateBatch(ctx, []domain.CreateURLRequest{{URL: "https://slow.test"}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
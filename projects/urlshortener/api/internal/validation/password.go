@@ -0,0 +1,10 @@
+package validation
+
+const maxPasswordLength = 128
+
+func (v *URLValidator) ValidatePassword(password string) error {
+	if len(password) > maxPasswordLength {
+		return ErrPasswordTooLong
+	}
+	return nil
+}
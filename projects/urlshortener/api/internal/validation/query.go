@@ -0,0 +1,31 @@
+package validation
+
+import "urlshortener/internal/domain"
+
+const maxUTMValueLength = 256
+
+var utmKeys = map[string]bool{
+	"utm_source":   true,
+	"utm_medium":   true,
+	"utm_campaign": true,
+	"utm_term":     true,
+	"utm_content":  true,
+}
+
+func (v *URLValidator) ValidateQueryPolicy(policy string) error {
+	switch policy {
+	case "", domain.QueryDrop, domain.QueryAppend, domain.QueryOverride:
+		return nil
+	}
+	return ErrInvalidQueryPolicy
+}
+
+// ValidateUTM accepts the five standard UTM parameters with non-empty values.
+func (v *URLValidator) ValidateUTM(utm map[string]string) error {
+	for key, value := range utm {
+		if !utmKeys[key] || value == "" || len(value) > maxUTMValueLength {
+			return ErrInvalidUTM
+		}
+	}
+	return nil
+}
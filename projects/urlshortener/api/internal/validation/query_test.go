@@ -0,0 +1,44 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateQueryPolicy(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	for _, policy := range []string{"", domain.QueryDrop, domain.QueryAppend, domain.QueryOverride} {
+		assert.NoError(t, v.ValidateQueryPolicy(policy), policy)
+	}
+	for _, policy := range []string{"keep", "DROP", "merge"} {
+		assert.ErrorIs(t, v.ValidateQueryPolicy(policy), validation.ErrInvalidQueryPolicy, policy)
+	}
+}
+
+func TestURLValidator_ValidateUTM(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidateUTM(nil))
+	assert.NoError(t, v.ValidateUTM(map[string]string{
+		"utm_source":   "newsletter",
+		"utm_medium":   "email",
+		"utm_campaign": "spring sale",
+		"utm_term":     "shoes",
+		"utm_content":  "header",
+	}))
+
+	tests := []map[string]string{
+		{"ref": "newsletter"},
+		{"utm_source": ""},
+		{"utm_source": strings.Repeat("a", 257)},
+	}
+	for _, utm := range tests {
+		assert.ErrorIs(t, v.ValidateUTM(utm), validation.ErrInvalidUTM, utm)
+	}
+}
@@ -0,0 +1,18 @@
+package validation
+
+import "net/http"
+
+var redirectStatuses = map[int]bool{
+	0:                            true, // default
+	http.StatusMovedPermanently:  true,
+	http.StatusFound:             true,
+	http.StatusTemporaryRedirect: true,
+	http.StatusPermanentRedirect: true,
+}
+
+func (v *URLValidator) ValidateRedirectStatus(status int) error {
+	if !redirectStatuses[status] {
+		return ErrInvalidRedirect
+	}
+	return nil
+}
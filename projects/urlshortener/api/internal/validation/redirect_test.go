@@ -0,0 +1,20 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateRedirectStatus(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	for _, status := range []int{0, 301, 302, 307, 308} {
+		assert.NoError(t, v.ValidateRedirectStatus(status), status)
+	}
+	for _, status := range []int{200, 300, 303, 304, 404} {
+		assert.ErrorIs(t, v.ValidateRedirectStatus(status), validation.ErrInvalidRedirect, status)
+	}
+}
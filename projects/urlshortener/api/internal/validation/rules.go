@@ -0,0 +1,27 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"urlshortener/internal/routing"
+)
+
+const maxRules = 20
+
+// ValidateRules checks every rule destination like a link URL and compiles the
+// rules to catch unknown devices, malformed languages and time windows.
+func (v *URLValidator) ValidateRules(ctx context.Context, rules []routing.Rule) error {
+	if len(rules) > maxRules {
+		return ErrTooManyRules
+	}
+	for _, rule := range rules {
+		if err := v.ValidateURL(ctx, rule.URL); err != nil {
+			return err
+		}
+	}
+	if _, err := routing.Compile(rules); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRule, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/routing"
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateRules(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidateRules(context.Background(), nil))
+	assert.NoError(t, v.ValidateRules(context.Background(), []routing.Rule{
+		{URL: "https://apps.apple.com/app/id1", Device: routing.DeviceIOS},
+		{URL: "https://example.de", Languages: []string{"de"}},
+	}))
+
+	assert.ErrorIs(t, v.ValidateRules(context.Background(), []routing.Rule{{URL: "javascript:alert(1)", Device: routing.DeviceIOS}}), validation.ErrUnsafeProtocol)
+	assert.ErrorIs(t, v.ValidateRules(context.Background(), []routing.Rule{{URL: "https://example.com"}}), validation.ErrInvalidRule)
+	assert.ErrorIs(t, v.ValidateRules(context.Background(), []routing.Rule{{URL: "https://example.com", Device: "watch"}}), validation.ErrInvalidRule)
+
+	tooMany := make([]routing.Rule, 21)
+	for i := range tooMany {
+		tooMany[i] = routing.Rule{URL: "https://example.com", Device: routing.DeviceIOS}
+	}
+	assert.ErrorIs(t, v.ValidateRules(context.Background(), tooMany), validation.ErrTooManyRules)
+}
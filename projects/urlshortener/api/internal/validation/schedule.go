@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"context"
+	"time"
+
+	"urlshortener/internal/domain"
+)
+
+// ValidateSchedule checks the activation time of a link against its expiry,
+// whether given as expires_at or as a ttl from now, and the pre-launch URL,
+// which is only served to scheduled links. An activation time in the past is
+// allowed and simply has no effect.
+func (v *URLValidator) ValidateSchedule(ctx context.Context, req *domain.CreateURLRequest) error {
+	if req.PrelaunchURL != "" {
+		if req.ActiveFrom == nil || v.ValidateURL(ctx, req.PrelaunchURL) != nil {
+			return ErrInvalidPrelaunch
+		}
+	}
+	if req.ActiveFrom == nil {
+		return nil
+	}
+
+	expiry := req.ExpiresAt
+	if req.TTL > maxTTL {
+		return ErrInvalidTTL
+	}
+	if req.TTL > 0 {
+		t := time.Now().Add(time.Duration(req.TTL) * time.Second)
+		expiry = &t
+	}
+	if expiry != nil && !req.ActiveFrom.Before(*expiry) {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateSchedule(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(2 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		req  domain.CreateURLRequest
+		want error
+	}{
+		{"no schedule", domain.CreateURLRequest{}, nil},
+		{"scheduled", domain.CreateURLRequest{ActiveFrom: &soon}, nil},
+		{"already active", domain.CreateURLRequest{ActiveFrom: &past}, nil},
+		{"with prelaunch url", domain.CreateURLRequest{ActiveFrom: &soon, PrelaunchURL: "https://example.com/teaser"}, nil},
+		{"before expiry", domain.CreateURLRequest{ActiveFrom: &soon, ExpiresAt: &later}, nil},
+		{"after expiry", domain.CreateURLRequest{ActiveFrom: &later, ExpiresAt: &soon}, validation.ErrInvalidSchedule},
+		{"at expiry", domain.CreateURLRequest{ActiveFrom: &soon, ExpiresAt: &soon}, validation.ErrInvalidSchedule},
+		{"after ttl", domain.CreateURLRequest{ActiveFrom: &soon, TTL: 60}, validation.ErrInvalidSchedule},
+		{"before ttl", domain.CreateURLRequest{ActiveFrom: &soon, TTL: 7200}, nil},
+		{"overflowing ttl", domain.CreateURLRequest{ActiveFrom: &soon, TTL: 10000000000}, validation.ErrInvalidTTL},
+		{"prelaunch url without schedule", domain.CreateURLRequest{PrelaunchURL: "https://example.com/teaser"}, validation.ErrInvalidPrelaunch},
+		{"unsafe prelaunch url", domain.CreateURLRequest{ActiveFrom: &soon, PrelaunchURL: "javascript:alert(1)"}, validation.ErrInvalidPrelaunch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateSchedule(context.Background(), &tt.req)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxTags           = 10
+	maxTagLength      = 64
+	maxCampaignLength = 128
+)
+
+// ValidateTags accepts up to 10 tags made of the same characters as aliases.
+func (v *URLValidator) ValidateTags(tags []string) error {
+	if len(tags) > maxTags {
+		return ErrInvalidTags
+	}
+	for _, tag := range tags {
+		if tag == "" || len(tag) > maxTagLength {
+			return ErrInvalidTags
+		}
+		for _, r := range tag {
+			if !isAliasChar(r) {
+				return ErrInvalidTags
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateCampaign accepts free text such as "Black Friday 2025" without
+// control characters.
+func (v *URLValidator) ValidateCampaign(campaign string) error {
+	if utf8.RuneCountInString(campaign) > maxCampaignLength || !utf8.ValidString(campaign) {
+		return ErrInvalidCampaign
+	}
+	for _, r := range campaign {
+		if unicode.IsControl(r) {
+			return ErrInvalidCampaign
+		}
+	}
+	return nil
+}
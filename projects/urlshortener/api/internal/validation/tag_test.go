@@ -0,0 +1,44 @@
+package validation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateTags(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidateTags(nil))
+	assert.NoError(t, v.ValidateTags([]string{"black-friday", "email_2025"}))
+	assert.ErrorIs(t, v.ValidateTags([]string{""}), validation.ErrInvalidTags)
+	assert.ErrorIs(t, v.ValidateTags([]string{"has space"}), validation.ErrInvalidTags)
+	assert.ErrorIs(t, v.ValidateTags([]string{strings.Repeat("a", 65)}), validation.ErrInvalidTags)
+	assert.ErrorIs(t, v.ValidateTags(strings.Split("a,b,c,d,e,f,g,h,i,j,k", ",")), validation.ErrInvalidTags)
+}
+
+func TestURLValidator_ValidateCampaign(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidateCampaign(""))
+	assert.NoError(t, v.ValidateCampaign("Black Friday 2025 – Früh"))
+	assert.NoError(t, v.ValidateCampaign(strings.Repeat("ü", 128)))
+	assert.ErrorIs(t, v.ValidateCampaign(strings.Repeat("a", 129)), validation.ErrInvalidCampaign)
+	assert.ErrorIs(t, v.ValidateCampaign("line\nbreak"), validation.ErrInvalidCampaign)
+	assert.ErrorIs(t, v.ValidateCampaign("\xff"), validation.ErrInvalidCampaign)
+}
+
+func TestURLValidator_ValidateRequest_Tags(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	err := v.ValidateRequest(context.Background(), &domain.CreateURLRequest{URL: "https://example.com", Tags: []string{"a b"}})
+	assert.ErrorIs(t, err, validation.ErrInvalidTags)
+
+	err = v.ValidateRequest(context.Background(), &domain.CreateURLRequest{URL: "https://example.com", Campaign: "\t"})
+	assert.ErrorIs(t, err, validation.ErrInvalidCampaign)
+}
@@ -1,8 +1,12 @@
 package validation
 
 import (
+	"context"
 	"net/url"
 	"strings"
+	"time"
+
+	"urlshortener/internal/domain"
 )
 
 var blockedProtocols = map[string]bool{
@@ -24,6 +28,7 @@ type URLValidator struct {
 	maxBatchSize    int
 	allowPrivateIPs bool
 	ipValidator     *IPValidator
+	reservedAliases map[string]bool
 }
 
 func NewURLValidator(maxLength, maxBatchSize int, allowPrivateIPs bool) *URLValidator {
@@ -35,7 +40,12 @@ func NewURLValidator(maxLength, maxBatchSize int, allowPrivateIPs bool) *URLVali
 	}
 }
 
-func (v *URLValidator) ValidateURL(rawURL string) error {
+// ResolveHosts must be called before the validator is used.
+func (v *URLValidator) ResolveHosts(resolver Resolver, timeout, ttl time.Duration, concurrency int) {
+	v.ipValidator = NewResolvingIPValidator(resolver, timeout, ttl, concurrency)
+}
+
+func (v *URLValidator) ValidateURL(ctx context.Context, rawURL string) error {
 	if strings.TrimSpace(rawURL) == "" {
 		return ErrEmptyURL
 	}
@@ -62,7 +72,7 @@ func (v *URLValidator) ValidateURL(rawURL string) error {
 	}
 
 	if !v.allowPrivateIPs {
-		if err := v.ipValidator.ValidateHost(parsed.Host); err != nil {
+		if err := v.ipValidator.ValidateHost(ctx, parsed.Host); err != nil {
 			return err
 		}
 	}
@@ -70,19 +80,127 @@ func (v *URLValidator) ValidateURL(rawURL string) error {
 	return nil
 }
 
-func (v *URLValidator) ValidateBatch(urls []string) error {
-	if len(urls) == 0 {
+func (v *URLValidator) ValidateRequest(ctx context.Context, req *domain.CreateURLRequest) error {
+	if err := v.ValidateURL(ctx, req.URL); err != nil {
+		return err
+	}
+	if err := v.ValidateAlias(req.Alias); err != nil {
+		return err
+	}
+	if err := v.ValidateExpiry(req.ExpiresAt, req.TTL); err != nil {
+		return err
+	}
+	if err := v.ValidateSchedule(ctx, req); err != nil {
+		return err
+	}
+	if err := v.ValidatePassword(req.Password); err != nil {
+		return err
+	}
+	if err := v.ValidateRedirectStatus(req.RedirectStatus); err != nil {
+		return err
+	}
+	if err := v.ValidateQueryPolicy(req.QueryPolicy); err != nil {
+		return err
+	}
+	if err := v.ValidateUTM(req.UTM); err != nil {
+		return err
+	}
+	if err := v.ValidateRules(ctx, req.Rules); err != nil {
+		return err
+	}
+	if err := v.ValidateVariants(ctx, req.Variants); err != nil {
+		return err
+	}
+	// Variants replace URL, so there would be nothing to fail over from.
+	if len(req.Backups) > 0 && len(req.Variants) > 0 {
+		return ErrInvalidBackups
+	}
+	if err := v.ValidateBackups(ctx, req.Backups); err != nil {
+		return err
+	}
+	if err := v.ValidateTags(req.Tags); err != nil {
+		return err
+	}
+	return v.ValidateCampaign(req.Campaign)
+}
+
+// Thousands of password hashes would not fit in the write timeout.
+func (v *URLValidator) ValidateBatchEntry(ctx context.Context, req *domain.CreateURLRequest) error {
+	if err := v.ValidateRequest(ctx, req); err != nil {
+		return err
+	}
+	if req.Password != "" {
+		return ErrPasswordInBatch
+	}
+	return nil
+}
+
+func (v *URLValidator) ValidateBatchEntries(ctx context.Context, reqs []domain.CreateURLRequest) ([]error, error) {
+	if !v.allowPrivateIPs {
+		ctx = v.ipValidator.resolveAll(ctx, batchHosts(reqs))
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(reqs))
+	for i := range reqs {
+		errs[i] = v.ValidateBatchEntry(ctx, &reqs[i])
+	}
+	return errs, ctx.Err()
+}
+
+func batchHosts(reqs []domain.CreateURLRequest) []string {
+	var hosts []string
+	add := func(rawURL string) {
+		if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+			hosts = append(hosts, parsed.Host)
+		}
+	}
+	for i := range reqs {
+		req := &reqs[i]
+		add(req.URL)
+		add(req.PrelaunchURL)
+		for _, backup := range req.Backups {
+			add(backup)
+		}
+		for _, rule := range req.Rules {
+			add(rule.URL)
+		}
+		for _, variant := range req.Variants {
+			add(variant.URL)
+		}
+	}
+	return hosts
+}
+
+func (v *URLValidator) ValidateBatch(ctx context.Context, reqs []domain.CreateURLRequest) error {
+	if len(reqs) == 0 {
 		return ErrEmptyBatch
 	}
 
-	if len(urls) > v.maxBatchSize {
+	if len(reqs) > v.maxBatchSize {
 		return ErrBatchTooLarge
 	}
 
+	errs, err := v.ValidateBatchEntries(ctx, reqs)
+	if err != nil {
+		return err
+	}
+
 	var batchErrors []IndexedError
-	for i, u := range urls {
-		if err := v.ValidateURL(u); err != nil {
-			batchErrors = append(batchErrors, IndexedError{Index: i, Err: err})
+	aliases := make(map[string]bool)
+	for i := range reqs {
+		req := &reqs[i]
+		if errs[i] != nil {
+			batchErrors = append(batchErrors, IndexedError{Index: i, Err: errs[i]})
+			continue
+		}
+		if req.Alias != "" {
+			if aliases[req.Alias] {
+				batchErrors = append(batchErrors, IndexedError{Index: i, Err: ErrDuplicateAlias})
+				continue
+			}
+			aliases[req.Alias] = true
 		}
 	}
 
@@ -92,3 +210,14 @@ func (v *URLValidator) ValidateBatch(urls []string) error {
 
 	return nil
 }
+
+// Unknown codes are simply not found, so only the list size is checked.
+func (v *URLValidator) ValidateCodes(codes []string) error {
+	if len(codes) == 0 {
+		return ErrEmptyCodes
+	}
+	if len(codes) > v.maxBatchSize {
+		return ErrBatchTooLarge
+	}
+	return nil
+}
@@ -1,15 +1,25 @@
 package validation_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"urlshortener/internal/domain"
 	"urlshortener/internal/validation"
 )
 
+func batchOf(urls ...string) []domain.CreateURLRequest {
+	reqs := make([]domain.CreateURLRequest, len(urls))
+	for i, u := range urls {
+		reqs[i] = domain.CreateURLRequest{URL: u}
+	}
+	return reqs
+}
+
 func TestURLValidator_ValidateURL(t *testing.T) {
 	v := validation.NewURLValidator(2048, 100, false)
 
@@ -58,7 +68,7 @@ func TestURLValidator_ValidateURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := v.ValidateURL(tt.url)
+			err := v.ValidateURL(context.Background(), tt.url)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
@@ -72,11 +82,11 @@ func TestURLValidator_ValidateURL_Length(t *testing.T) {
 	v := validation.NewURLValidator(100, 100, false)
 
 	shortURL := "https://example.com"
-	err := v.ValidateURL(shortURL)
+	err := v.ValidateURL(context.Background(), shortURL)
 	assert.NoError(t, err)
 
 	longURL := "https://example.com/" + strings.Repeat("a", 100)
-	err = v.ValidateURL(longURL)
+	err = v.ValidateURL(context.Background(), longURL)
 	assert.ErrorIs(t, err, validation.ErrURLTooLong)
 }
 
@@ -91,7 +101,7 @@ func TestURLValidator_ValidateURL_AllowPrivateIPs(t *testing.T) {
 	}
 
 	for _, url := range privateIPs {
-		err := v.ValidateURL(url)
+		err := v.ValidateURL(context.Background(), url)
 		assert.NoError(t, err, "URL %q should be allowed with allowPrivateIPs=true", url)
 	}
 }
@@ -100,41 +110,85 @@ func TestURLValidator_ValidateBatch(t *testing.T) {
 	v := validation.NewURLValidator(2048, 3, false)
 
 	t.Run("empty batch", func(t *testing.T) {
-		err := v.ValidateBatch([]string{})
+		err := v.ValidateBatch(context.Background(), []domain.CreateURLRequest{})
 		assert.ErrorIs(t, err, validation.ErrEmptyBatch)
 	})
 
 	t.Run("batch too large", func(t *testing.T) {
-		urls := []string{
+		urls := batchOf(
 			"https://example.com/1",
 			"https://example.com/2",
 			"https://example.com/3",
 			"https://example.com/4",
-		}
-		err := v.ValidateBatch(urls)
+		)
+		err := v.ValidateBatch(context.Background(), urls)
 		assert.ErrorIs(t, err, validation.ErrBatchTooLarge)
 	})
 
 	t.Run("valid batch", func(t *testing.T) {
-		urls := []string{
+		urls := batchOf(
 			"https://example.com/1",
 			"https://example.com/2",
 			"https://example.com/3",
-		}
-		err := v.ValidateBatch(urls)
+		)
+		err := v.ValidateBatch(context.Background(), urls)
 		assert.NoError(t, err)
 	})
 
 	t.Run("batch with invalid urls", func(t *testing.T) {
-		urls := []string{
+		urls := batchOf(
 			"https://example.com/1",
 			"javascript:alert(1)",
 			"https://example.com/3",
+		)
+		err := v.ValidateBatch(context.Background(), urls)
+		batchErr, ok := err.(*validation.BatchValidationError)
+		require.True(t, ok, "expected *BatchValidationError, got %T", err)
+		require.Len(t, batchErr.Errors, 1)
+		assert.Equal(t, 1, batchErr.Errors[0].Index)
+	})
+
+	t.Run("batch with invalid and duplicate aliases", func(t *testing.T) {
+		reqs := []domain.CreateURLRequest{
+			{URL: "https://example.com/1", Alias: "spring-sale"},
+			{URL: "https://example.com/2", Alias: "spring-sale"},
+			{URL: "https://example.com/3", Alias: "api"},
+		}
+		err := v.ValidateBatch(context.Background(), reqs)
+		batchErr, ok := err.(*validation.BatchValidationError)
+		require.True(t, ok, "expected *BatchValidationError, got %T", err)
+		require.Len(t, batchErr.Errors, 2)
+		assert.Equal(t, 1, batchErr.Errors[0].Index)
+		assert.ErrorIs(t, batchErr.Errors[0].Err, validation.ErrDuplicateAlias)
+		assert.Equal(t, 2, batchErr.Errors[1].Index)
+		assert.ErrorIs(t, batchErr.Errors[1].Err, validation.ErrReservedAlias)
+	})
+	t.Run("batch with password", func(t *testing.T) {
+		reqs := []domain.CreateURLRequest{
+			{URL: "https://example.com/1"},
+			{URL: "https://example.com/2", Password: "secret"},
 		}
-		err := v.ValidateBatch(urls)
+		err := v.ValidateBatch(context.Background(), reqs)
 		batchErr, ok := err.(*validation.BatchValidationError)
 		require.True(t, ok, "expected *BatchValidationError, got %T", err)
 		require.Len(t, batchErr.Errors, 1)
 		assert.Equal(t, 1, batchErr.Errors[0].Index)
+		assert.ErrorIs(t, batchErr.Errors[0].Err, validation.ErrPasswordInBatch)
 	})
 }
+
+func TestURLValidator_ValidateCodes(t *testing.T) {
+	v := validation.NewURLValidator(2048, 3, false)
+
+	assert.ErrorIs(t, v.ValidateCodes(nil), validation.ErrEmptyCodes)
+	assert.ErrorIs(t, v.ValidateCodes([]string{"a", "b", "c", "d"}), validation.ErrBatchTooLarge)
+	assert.NoError(t, v.ValidateCodes([]string{"a", "b", "c"}))
+}
+
+func TestURLValidator_ValidatePassword(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidatePassword(""))
+	assert.NoError(t, v.ValidatePassword(strings.Repeat("p", 128)))
+	assert.ErrorIs(t, v.ValidatePassword(strings.Repeat("p", 129)), validation.ErrPasswordTooLong)
+}
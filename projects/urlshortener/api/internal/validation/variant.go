@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"context"
+
+	"urlshortener/internal/domain"
+)
+
+const (
+	minVariants          = 2
+	maxVariants          = 10
+	maxVariantWeight     = 1000
+	maxVariantNameLength = 32
+)
+
+// ValidateVariants checks an A/B split: 2 to 10 valid destinations with
+// weights from 1 to 1000. Either every variant has a unique name, using the
+// same characters as aliases, or none has and they are named a, b, c...
+func (v *URLValidator) ValidateVariants(ctx context.Context, variants []domain.Variant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	if len(variants) < minVariants || len(variants) > maxVariants {
+		return ErrInvalidVariants
+	}
+
+	names := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		if err := v.ValidateURL(ctx, variant.URL); err != nil {
+			return err
+		}
+		if variant.Weight < 1 || variant.Weight > maxVariantWeight {
+			return ErrInvalidVariants
+		}
+		if (variant.Name == "") != (variants[0].Name == "") {
+			return ErrInvalidVariants
+		}
+		if variant.Name == "" {
+			continue
+		}
+		if len(variant.Name) > maxVariantNameLength || names[variant.Name] {
+			return ErrInvalidVariants
+		}
+		for _, r := range variant.Name {
+			if !isAliasChar(r) {
+				return ErrInvalidVariants
+			}
+		}
+		names[variant.Name] = true
+	}
+	return nil
+}
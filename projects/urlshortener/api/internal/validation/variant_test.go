@@ -0,0 +1,53 @@
+package validation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"urlshortener/internal/domain"
+	"urlshortener/internal/validation"
+)
+
+func TestURLValidator_ValidateVariants(t *testing.T) {
+	v := validation.NewURLValidator(2048, 100, false)
+
+	assert.NoError(t, v.ValidateVariants(context.Background(), nil))
+	assert.NoError(t, v.ValidateVariants(context.Background(), []domain.Variant{
+		{URL: "https://example.com/a", Weight: 70},
+		{URL: "https://example.com/b", Weight: 30},
+	}))
+	assert.NoError(t, v.ValidateVariants(context.Background(), []domain.Variant{
+		{Name: "control", URL: "https://example.com/a", Weight: 1},
+		{Name: "new-hero", URL: "https://example.com/b", Weight: 1000},
+	}))
+
+	tests := map[string][]domain.Variant{
+		"single variant": {{URL: "https://example.com/a", Weight: 1}},
+		"zero weight":    {{URL: "https://example.com/a", Weight: 0}, {URL: "https://example.com/b", Weight: 1}},
+		"weight too big": {{URL: "https://example.com/a", Weight: 1001}, {URL: "https://example.com/b", Weight: 1}},
+		"partly named":   {{Name: "a", URL: "https://example.com/a", Weight: 1}, {URL: "https://example.com/b", Weight: 1}},
+		"duplicate name": {{Name: "a", URL: "https://example.com/a", Weight: 1}, {Name: "a", URL: "https://example.com/b", Weight: 1}},
+		"invalid name":   {{Name: "a b", URL: "https://example.com/a", Weight: 1}, {Name: "c", URL: "https://example.com/b", Weight: 1}},
+		"long name": {
+			{Name: strings.Repeat("a", 33), URL: "https://example.com/a", Weight: 1},
+			{Name: "b", URL: "https://example.com/b", Weight: 1},
+		},
+	}
+	for name, variants := range tests {
+		assert.ErrorIs(t, v.ValidateVariants(context.Background(), variants), validation.ErrInvalidVariants, name)
+	}
+
+	tooMany := make([]domain.Variant, 11)
+	for i := range tooMany {
+		tooMany[i] = domain.Variant{URL: "https://example.com", Weight: 1}
+	}
+	assert.ErrorIs(t, v.ValidateVariants(context.Background(), tooMany), validation.ErrInvalidVariants)
+
+	assert.ErrorIs(t, v.ValidateVariants(context.Background(), []domain.Variant{
+		{URL: "javascript:alert(1)", Weight: 1},
+		{URL: "https://example.com/b", Weight: 1},
+	}), validation.ErrUnsafeProtocol)
+}
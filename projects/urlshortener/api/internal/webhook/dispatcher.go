@@ -0,0 +1,343 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"urlshortener/internal/config"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+	"urlshortener/internal/validation"
+)
+
+const userAgent = "urlshortener-webhook/1.0"
+
+// HeaderID is the same for every attempt, so receivers can discard repeats.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderID        = "X-Webhook-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Store interface {
+	SubscribedEvents(ctx context.Context) ([]string, error)
+	Enqueue(ctx context.Context, events []repository.QueuedEvent) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]repository.DeliveryJob, error)
+	Complete(ctx context.Context, results []repository.DeliveryResult) error
+}
+
+type BusinessRecorder interface {
+	RecordBusiness(t time.Time, name string, value float64, labelsJSON []byte)
+}
+
+type Dispatcher struct {
+	store    Store
+	recorder BusinessRecorder
+	logger   *slog.Logger
+	cfg      *config.WebhookConfig
+	client   *http.Client
+	eventCh  chan domain.Event
+	// Only subscribed events are queued, so redirects cost nothing while no
+	// webhook wants clicks.
+	subscribed atomic.Pointer[map[string]bool]
+
+	wg           sync.WaitGroup
+	shutdownOnce sync.Once
+	shutdownCh   chan struct{}
+	dropped      atomic.Uint64
+}
+
+func NewDispatcher(store Store, recorder BusinessRecorder, cfg *config.WebhookConfig, dialer *net.Dialer, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:    store,
+		recorder: recorder,
+		logger:   logger,
+		cfg:      cfg,
+		client: &http.Client{
+			Transport: validation.NewTransport(dialer),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		eventCh:    make(chan domain.Event, cfg.BufferSize),
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+func (d *Dispatcher) Publish(e domain.Event) {
+	if !d.cfg.Enabled {
+		return
+	}
+	if subscribed := d.subscribed.Load(); subscribed == nil || !(*subscribed)[e.Type] {
+		return
+	}
+	select {
+	case d.eventCh <- e:
+	default:
+		d.dropped.Add(1)
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	if !d.cfg.Enabled {
+		d.logger.Info("webhooks disabled")
+		return
+	}
+
+	d.RefreshSubscriptions(ctx)
+	d.wg.Add(2)
+	go d.flushEvents(ctx, time.Duration(d.cfg.FlushInterval)*time.Millisecond)
+	go d.deliverDue(ctx, time.Duration(d.cfg.PollInterval)*time.Millisecond)
+
+	d.logger.Info("webhook dispatcher started",
+		slog.Int("buffer_size", d.cfg.BufferSize),
+		slog.Int("poll_interval_ms", d.cfg.PollInterval),
+		slog.Int("concurrency", d.cfg.Concurrency))
+}
+
+// Also run on every poll, so instances that did not change webhooks catch up.
+func (d *Dispatcher) RefreshSubscriptions(ctx context.Context) {
+	if !d.cfg.Enabled {
+		return
+	}
+	events, err := d.store.SubscribedEvents(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.logger.Error("failed to load webhook subscriptions", slog.String("error", err.Error()))
+		}
+		return
+	}
+	subscribed := make(map[string]bool, len(events))
+	for _, event := range events {
+		subscribed[event] = true
+	}
+	d.subscribed.Store(&subscribed)
+}
+
+func (d *Dispatcher) Close() {
+	d.shutdownOnce.Do(func() {
+		close(d.shutdownCh)
+		d.wg.Wait()
+	})
+}
+
+func (d *Dispatcher) flushEvents(ctx context.Context, interval time.Duration) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]domain.Event, 0, d.cfg.BufferSize)
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.drainAndFlush(batch)
+			return
+		case <-d.shutdownCh:
+			d.drainAndFlush(batch)
+			return
+		case e := <-d.eventCh:
+			batch = append(batch, e)
+			if len(batch) >= d.cfg.FlushThreshold {
+				d.writeEvents(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				d.writeEvents(ctx, batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) drainAndFlush(batch []domain.Event) {
+	for {
+		select {
+		case e := <-d.eventCh:
+			batch = append(batch, e)
+		default:
+			if len(batch) > 0 {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				d.writeEvents(ctx, batch)
+				cancel()
+			}
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) writeEvents(ctx context.Context, batch []domain.Event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	events := make([]repository.QueuedEvent, 0, len(batch))
+	for _, e := range batch {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			d.logger.Error("failed to encode webhook event", slog.String("error", err.Error()))
+			continue
+		}
+		events = append(events, repository.QueuedEvent{Type: e.Type, Payload: payload})
+	}
+
+	if err := d.store.Enqueue(ctx, events); err != nil {
+		d.logger.Error("failed to queue webhook events", slog.String("error", err.Error()))
+	}
+
+	if dropped := d.dropped.Swap(0); dropped > 0 {
+		d.logger.Warn("dropped webhook events", slog.Uint64("count", dropped))
+	}
+}
+
+func (d *Dispatcher) deliverDue(ctx context.Context, interval time.Duration) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.shutdownCh:
+			return
+		case <-ticker.C:
+			d.RefreshSubscriptions(ctx)
+			// A full batch suggests a backlog.
+			for d.DeliverDue(ctx) == max(1, d.cfg.BatchSize) {
+				if d.stopping(ctx) {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) stopping(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-d.shutdownCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sends in flight outlive ctx, so shutting down never fails a delivery.
+func (d *Dispatcher) DeliverDue(ctx context.Context) int {
+	batchSize := max(1, d.cfg.BatchSize)
+	concurrency := max(1, d.cfg.Concurrency)
+	timeout := time.Duration(d.cfg.TimeoutMs) * time.Millisecond
+	// The lease outlasts the slowest batch, so a delivery is only sent twice
+	// if the worker sending it died.
+	lease := time.Duration((batchSize+concurrency-1)/concurrency+1) * timeout
+
+	jobs, err := d.store.Claim(ctx, batchSize, lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.logger.Error("failed to claim webhook deliveries", slog.String("error", err.Error()))
+		}
+		return 0
+	}
+	if len(jobs) == 0 {
+		return 0
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	results := make([]repository.DeliveryResult, len(jobs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = d.deliver(ctx, job, timeout)
+		}()
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := d.store.Complete(ctx, results); err != nil {
+		d.logger.Error("failed to record webhook deliveries", slog.String("error", err.Error()))
+	}
+	return len(jobs)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, job repository.DeliveryJob, timeout time.Duration) repository.DeliveryResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := repository.DeliveryResult{ID: job.ID}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err == nil {
+		timestamp := start.Unix()
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set(HeaderEvent, job.Event)
+		req.Header.Set(HeaderID, strconv.FormatInt(job.ID, 10))
+		req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(HeaderSignature, Sign(job.Secret, timestamp, job.Payload))
+
+		var resp *http.Response
+		resp, err = d.client.Do(req)
+		if err == nil {
+			result.LastStatus = resp.StatusCode
+			// Draining a little lets the connection be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			_ = resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+			}
+		}
+	}
+	result.Delivered = err == nil
+
+	labels := fmt.Appendf(nil, `{"event":%q,"status_code":%d,"delivered":%t}`, job.Event, result.LastStatus, result.Delivered)
+	d.recorder.RecordBusiness(time.Now(), "webhook_delivery", float64(time.Since(start).Milliseconds()), labels)
+
+	if result.Delivered {
+		return result
+	}
+
+	attempts := job.Attempts + 1
+	result.LastError = err.Error()
+	result.NextAttemptAt = time.Now().Add(d.backoff(attempts))
+	if attempts >= d.cfg.MaxAttempts {
+		result.Dead = true
+		d.logger.Warn("webhook delivery dead",
+			slog.Int64("delivery_id", job.ID),
+			slog.String("url", job.URL),
+			slog.Int("attempts", attempts),
+			slog.String("error", result.LastError))
+	}
+	return result
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := time.Duration(d.cfg.BackoffBaseSeconds) * time.Second
+	limit := time.Duration(d.cfg.BackoffMaxSeconds) * time.Second
+	for range attempts - 1 {
+		if delay >= limit {
+			break
+		}
+		delay *= 2
+	}
+	return min(delay, limit)
+}
@@ -0,0 +1,283 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"urlshortener/internal/config"
+	"urlshortener/internal/domain"
+	"urlshortener/internal/repository"
+	"urlshortener/internal/validation"
+	"urlshortener/internal/webhook"
+	"urlshortener/internal/webhook/mocks"
+)
+
+const testSecret = "whsec_test"
+
+func testConfig() *config.WebhookConfig {
+	return &config.WebhookConfig{
+		Enabled:            true,
+		BufferSize:         100,
+		FlushInterval:      10,
+		FlushThreshold:     100,
+		PollInterval:       60000,
+		BatchSize:          10,
+		Concurrency:        2,
+		TimeoutMs:          1000,
+		MaxAttempts:        3,
+		BackoffBaseSeconds: 10,
+		BackoffMaxSeconds:  60,
+	}
+}
+
+func newTestDispatcher(t *testing.T, cfg *config.WebhookConfig) (*webhook.Dispatcher, *mocks.MockStore) {
+	return newDialingDispatcher(t, cfg, &net.Dialer{})
+}
+
+func newDialingDispatcher(t *testing.T, cfg *config.WebhookConfig, dialer *net.Dialer) (*webhook.Dispatcher, *mocks.MockStore) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := mocks.NewMockStore(t)
+	recorder := mocks.NewMockBusinessRecorder(t)
+	recorder.EXPECT().RecordBusiness(mock.Anything, "webhook_delivery", mock.Anything, mock.Anything).Maybe()
+	return webhook.NewDispatcher(store, recorder, cfg, dialer, logger), store
+}
+
+func job(id int64, url string, attempts int) repository.DeliveryJob {
+	return repository.DeliveryJob{
+		ID:       id,
+		URL:      url,
+		Secret:   testSecret,
+		Event:    domain.EventLinkCreated,
+		Payload:  []byte(`{"type":"link.created","data":{"short_code":"abc123"}}`),
+		Attempts: attempts,
+	}
+}
+
+// deliver runs one DeliverDue round over jobs and returns the recorded results.
+func deliver(t *testing.T, d *webhook.Dispatcher, store *mocks.MockStore, jobs ...repository.DeliveryJob) []repository.DeliveryResult {
+	t.Helper()
+	var results []repository.DeliveryResult
+	store.EXPECT().Claim(mock.Anything, 10, mock.Anything).Return(jobs, nil).Once()
+	store.EXPECT().Complete(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, r []repository.DeliveryResult) { results = r }).
+		Return(nil).Once()
+
+	assert.Equal(t, len(jobs), d.DeliverDue(context.Background()))
+	return results
+}
+
+func statusServer(t *testing.T, status int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDeliverDue_SignsRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	d, store := newTestDispatcher(t, testConfig())
+	results := deliver(t, d, store, job(7, srv.URL, 0))
+
+	require.Len(t, results, 1)
+	assert.Equal(t, repository.DeliveryResult{ID: 7, Delivered: true, LastStatus: http.StatusNoContent}, results[0])
+
+	require.NotNil(t, got)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, domain.EventLinkCreated, got.Header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "7", got.Header.Get(webhook.HeaderID))
+	timestamp, err := strconv.ParseInt(got.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.Sign(testSecret, timestamp, body), got.Header.Get(webhook.HeaderSignature))
+	assert.JSONEq(t, string(job(7, srv.URL, 0).Payload), string(body))
+}
+
+func TestDeliverDue_FailureBacksOff(t *testing.T) {
+	failing := statusServer(t, http.StatusInternalServerError)
+	redirect := statusServer(t, http.StatusFound)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	d, store := newTestDispatcher(t, testConfig())
+	before := time.Now()
+	results := deliver(t, d, store, job(1, failing.URL, 0), job(2, redirect.URL, 1), job(3, down.URL, 0))
+
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.False(t, r.Delivered, "delivery %d", r.ID)
+		assert.False(t, r.Dead, "delivery %d", r.ID)
+		assert.NotEmpty(t, r.LastError, "delivery %d", r.ID)
+	}
+	assert.Equal(t, http.StatusInternalServerError, results[0].LastStatus)
+	assert.Equal(t, http.StatusFound, results[1].LastStatus, "redirects are not followed")
+	assert.Zero(t, results[2].LastStatus)
+
+	// 10s after the first failure, doubled after the second.
+	assert.WithinRange(t, results[0].NextAttemptAt, before.Add(10*time.Second), time.Now().Add(10*time.Second))
+	assert.WithinRange(t, results[1].NextAttemptAt, before.Add(20*time.Second), time.Now().Add(20*time.Second))
+}
+
+func TestDeliverDue_RefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	d, store := newDialingDispatcher(t, testConfig(), validation.NewDialer(false))
+	results := deliver(t, d, store, job(1, srv.URL, 0))
+
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Delivered)
+	assert.Contains(t, results[0].LastError, validation.ErrPrivateIPNotAllowed.Error())
+	assert.False(t, called, "the receiver is never reached")
+}
+
+func TestDeliverDue_BackoffIsCapped(t *testing.T) {
+	failing := statusServer(t, http.StatusBadGateway)
+	cfg := testConfig()
+	cfg.MaxAttempts = 20
+
+	d, store := newTestDispatcher(t, cfg)
+	before := time.Now()
+	results := deliver(t, d, store, job(1, failing.URL, 12))
+
+	require.Len(t, results, 1)
+	assert.WithinRange(t, results[0].NextAttemptAt, before.Add(time.Minute), time.Now().Add(time.Minute))
+}
+
+func TestDeliverDue_DeadAfterMaxAttempts(t *testing.T) {
+	failing := statusServer(t, http.StatusServiceUnavailable)
+
+	d, store := newTestDispatcher(t, testConfig())
+	results := deliver(t, d, store, job(1, failing.URL, 1), job(2, failing.URL, 2))
+
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Dead)
+	assert.True(t, results[1].Dead)
+	assert.Equal(t, http.StatusServiceUnavailable, results[1].LastStatus)
+}
+
+func TestDeliverDue_NothingDue(t *testing.T) {
+	d, store := newTestDispatcher(t, testConfig())
+	store.EXPECT().Claim(mock.Anything, 10, mock.Anything).Return(nil, nil).Once()
+
+	assert.Zero(t, d.DeliverDue(context.Background()))
+}
+
+func TestClose_QueuesBufferedEvents(t *testing.T) {
+	cfg := testConfig()
+	cfg.FlushInterval = 60000
+
+	d, store := newTestDispatcher(t, cfg)
+	store.EXPECT().SubscribedEvents(mock.Anything).Return([]string{domain.EventLinkCreated, domain.EventLinkDeleted}, nil)
+	var queued []repository.QueuedEvent
+	store.EXPECT().Enqueue(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, events []repository.QueuedEvent) { queued = append(queued, events...) }).
+		Return(nil)
+
+	d.Start(context.Background())
+	now := time.Now().UTC().Truncate(time.Second)
+	d.Publish(domain.Event{Type: domain.EventLinkCreated, OccurredAt: now, Data: domain.LinkEvent{ShortCode: "abc123"}})
+	d.Publish(domain.Event{Type: domain.EventLinkDeleted, OccurredAt: now, Data: domain.LinkEvent{ShortCode: "abc123"}})
+	d.Close()
+
+	require.Len(t, queued, 2)
+	assert.Equal(t, domain.EventLinkCreated, queued[0].Type)
+	assert.Equal(t, domain.EventLinkDeleted, queued[1].Type)
+
+	var e domain.Event
+	require.NoError(t, json.Unmarshal(queued[0].Payload, &e))
+	assert.Equal(t, domain.Event{Type: domain.EventLinkCreated, OccurredAt: now, Data: domain.LinkEvent{ShortCode: "abc123"}}, e)
+}
+
+func TestPublish_OnlySubscribedEvents(t *testing.T) {
+	cfg := testConfig()
+	cfg.FlushInterval = 60000
+
+	d, store := newTestDispatcher(t, cfg)
+	subscribed := []string{}
+	store.EXPECT().SubscribedEvents(mock.Anything).RunAndReturn(func(context.Context) ([]string, error) {
+		return subscribed, nil
+	})
+	var queued []string
+	store.EXPECT().Enqueue(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, events []repository.QueuedEvent) {
+			for _, e := range events {
+				queued = append(queued, e.Type)
+			}
+		}).
+		Return(nil)
+
+	d.Start(context.Background())
+	d.Publish(domain.Event{Type: domain.EventLinkClicked})
+
+	subscribed = []string{domain.EventLinkClicked}
+	d.RefreshSubscriptions(context.Background())
+	d.Publish(domain.Event{Type: domain.EventLinkClicked})
+	d.Publish(domain.Event{Type: domain.EventLinkCreated})
+	d.Close()
+
+	assert.Equal(t, []string{domain.EventLinkClicked}, queued)
+}
+
+func TestPublish_KeepsSubscriptionsOnError(t *testing.T) {
+	cfg := testConfig()
+	cfg.FlushInterval = 60000
+
+	d, store := newTestDispatcher(t, cfg)
+	store.EXPECT().SubscribedEvents(mock.Anything).Return([]string{domain.EventLinkClicked}, nil).Once()
+	store.EXPECT().SubscribedEvents(mock.Anything).Return(nil, errors.New("db down")).Once()
+	var queued int
+	store.EXPECT().Enqueue(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, events []repository.QueuedEvent) { queued += len(events) }).
+		Return(nil)
+
+	d.Start(context.Background())
+	d.RefreshSubscriptions(context.Background())
+	d.Publish(domain.Event{Type: domain.EventLinkClicked})
+	d.Close()
+
+	assert.Equal(t, 1, queued)
+}
+
+func TestPublish_Disabled(t *testing.T) {
+	cfg := testConfig()
+	cfg.Enabled = false
+
+	d, _ := newTestDispatcher(t, cfg)
+	d.Start(context.Background())
+	d.Publish(domain.Event{Type: domain.EventLinkCreated})
+	d.Close()
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		webhook.Sign("secret", 1700000000, []byte("{}")))
+}
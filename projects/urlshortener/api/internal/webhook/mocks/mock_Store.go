@@ -0,0 +1,251 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	repository "urlshortener/internal/repository"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function with given fields: ctx, limit, lease
+func (_m *MockStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]repository.DeliveryJob, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 []repository.DeliveryJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]repository.DeliveryJob, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []repository.DeliveryJob); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.DeliveryJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockStore_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *MockStore_Expecter) Claim(ctx interface{}, limit interface{}, lease interface{}) *MockStore_Claim_Call {
+	return &MockStore_Claim_Call{Call: _e.mock.On("Claim", ctx, limit, lease)}
+}
+
+func (_c *MockStore_Claim_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *MockStore_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockStore_Claim_Call) Return(_a0 []repository.DeliveryJob, _a1 error) *MockStore_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Claim_Call) RunAndReturn(run func(context.Context, int, time.Duration) ([]repository.DeliveryJob, error)) *MockStore_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function with given fields: ctx, results
+func (_m *MockStore) Complete(ctx context.Context, results []repository.DeliveryResult) error {
+	ret := _m.Called(ctx, results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.DeliveryResult) error); ok {
+		r0 = rf(ctx, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockStore_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - results []repository.DeliveryResult
+func (_e *MockStore_Expecter) Complete(ctx interface{}, results interface{}) *MockStore_Complete_Call {
+	return &MockStore_Complete_Call{Call: _e.mock.On("Complete", ctx, results)}
+}
+
+func (_c *MockStore_Complete_Call) Run(run func(ctx context.Context, results []repository.DeliveryResult)) *MockStore_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]repository.DeliveryResult))
+	})
+	return _c
+}
+
+func (_c *MockStore_Complete_Call) Return(_a0 error) *MockStore_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Complete_Call) RunAndReturn(run func(context.Context, []repository.DeliveryResult) error) *MockStore_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function with given fields: ctx, events
+func (_m *MockStore) Enqueue(ctx context.Context, events []repository.QueuedEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.QueuedEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockStore_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []repository.QueuedEvent
+func (_e *MockStore_Expecter) Enqueue(ctx interface{}, events interface{}) *MockStore_Enqueue_Call {
+	return &MockStore_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, events)}
+}
+
+func (_c *MockStore_Enqueue_Call) Run(run func(ctx context.Context, events []repository.QueuedEvent)) *MockStore_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]repository.QueuedEvent))
+	})
+	return _c
+}
+
+func (_c *MockStore_Enqueue_Call) Return(_a0 error) *MockStore_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Enqueue_Call) RunAndReturn(run func(context.Context, []repository.QueuedEvent) error) *MockStore_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribedEvents provides a mock function with given fields: ctx
+func (_m *MockStore) SubscribedEvents(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribedEvents")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_SubscribedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribedEvents'
+type MockStore_SubscribedEvents_Call struct {
+	*mock.Call
+}
+
+// SubscribedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) SubscribedEvents(ctx interface{}) *MockStore_SubscribedEvents_Call {
+	return &MockStore_SubscribedEvents_Call{Call: _e.mock.On("SubscribedEvents", ctx)}
+}
+
+func (_c *MockStore_SubscribedEvents_Call) Run(run func(ctx context.Context)) *MockStore_SubscribedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_SubscribedEvents_Call) Return(_a0 []string, _a1 error) *MockStore_SubscribedEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_SubscribedEvents_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockStore_SubscribedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
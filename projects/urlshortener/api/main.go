@@ -19,13 +19,18 @@ import (
 
 	"urlshortener/internal/cache"
 	"urlshortener/internal/config"
+	"urlshortener/internal/domain"
 	"urlshortener/internal/handler"
 	"urlshortener/internal/metrics"
 	custommiddleware "urlshortener/internal/middleware"
+	"urlshortener/internal/password"
+	"urlshortener/internal/probe"
+	"urlshortener/internal/qrcode"
 	"urlshortener/internal/repository"
 	"urlshortener/internal/service"
 	"urlshortener/internal/shortener"
 	"urlshortener/internal/validation"
+	"urlshortener/internal/webhook"
 )
 
 func main() {
@@ -74,18 +79,108 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		cfg.Validation.MaxBatchSize,
 		cfg.Validation.AllowPrivateIPs,
 	)
+	if cfg.Validation.ResolveHosts {
+		urlValidator.ResolveHosts(
+			net.DefaultResolver,
+			time.Duration(cfg.Validation.ResolveTimeoutMs)*time.Millisecond,
+			time.Duration(cfg.Validation.ResolveCacheSeconds)*time.Second,
+			cfg.Validation.ResolveConcurrency,
+		)
+	}
+
+	attempts := password.NewAttemptLimiter(
+		cfg.Password.AttemptsPerMinute,
+		cfg.Password.AttemptsBurst,
+		time.Duration(cfg.Password.ExpireMinutes)*time.Minute,
+	)
+
+	apiKeyRepo := repository.NewAPIKeyRepository(repo.Pool())
 
-	urlService := service.NewURLService(repo, short, urlCache, cfg.App.BaseURL, recorder)
-	h := handler.New(urlService, urlValidator, logger, recorder)
+	// Destinations are checked again when they are fetched, in case their
+	// hostnames now resolve to internal addresses.
+	dialer := validation.NewDialer(cfg.Validation.AllowPrivateIPs)
+
+	prober := probe.NewProber(repo, recorder, &cfg.Probe, dialer, logger)
+	prober.Start(ctx)
+	defer prober.Close()
+
+	webhookRepo := repository.NewWebhookRepository(repo.Pool())
+	dispatcher := webhook.NewDispatcher(webhookRepo, recorder, &cfg.Webhook, dialer, logger)
+	dispatcher.Start(ctx)
+	defer dispatcher.Close()
+
+	urlService := service.NewURLService(repo, short, urlCache, cfg.App.BaseURL, recorder, attempts, prober, dispatcher)
+	if cfg.Schedule.NotYetStatus < 400 || cfg.Schedule.NotYetStatus > 599 {
+		return fmt.Errorf("invalid SCHEDULE_NOT_YET_STATUS %d: must be an error status", cfg.Schedule.NotYetStatus)
+	}
+	h := handler.New(urlService, urlValidator, logger, recorder, handler.NotYetAvailable{
+		Status:  cfg.Schedule.NotYetStatus,
+		Message: cfg.Schedule.NotYetMessage,
+	})
+
+	if _, err := qrcode.ParseLevel(cfg.QR.Level); err != nil {
+		return fmt.Errorf("invalid QR_LEVEL %q: %w", cfg.QR.Level, err)
+	}
+	qrCache, err := cache.NewImageCache(int64(cfg.QR.CacheMaxMB) << 20)
+	if err != nil {
+		return fmt.Errorf("failed to create qr cache: %w", err)
+	}
+	defer qrCache.Close()
+
+	qrHandler := handler.NewQRHandler(
+		service.NewQRService(urlService, qrCache, recorder, cfg.QR.MaxSize),
+		domain.QROptions{Format: domain.QRFormatPNG, Size: cfg.QR.Size, Level: cfg.QR.Level, Margin: cfg.QR.Margin},
+		logger,
+	)
 
 	e := echo.New()
 	e.HideBanner = true
 	e.Use(middleware.Recover())
-	e.Use(middleware.BodyLimit(cfg.Validation.MaxRequestBodySize))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Limit: cfg.Validation.MaxRequestBodySize,
+		// Imports are streamed row by row instead of buffered.
+		Skipper: func(c echo.Context) bool { return c.Path() == handler.ImportPath },
+	}))
 	e.Use(custommiddleware.Metrics(recorder))
+	keyAttempts := password.NewAttemptLimiter(cfg.Auth.FailuresPerMinute, cfg.Auth.FailuresBurst, 10*time.Minute)
+	e.Use(custommiddleware.Authenticate(apiKeyRepo, keyAttempts, logger))
 	e.Use(custommiddleware.RateLimit(&cfg.RateLimit, logger))
 
-	h.Register(e)
+	idempotencyRepo := repository.NewIdempotencyRepository(repo.Pool(), time.Duration(cfg.Idempotency.TTLHours)*time.Hour)
+	go deleteExpiredIdempotencyKeys(ctx, idempotencyRepo, time.Duration(cfg.Idempotency.CleanupIntervalMinutes)*time.Minute, logger)
+	h.Register(e, handler.RouteMiddleware{
+		Idempotent: custommiddleware.Idempotency(idempotencyRepo, logger),
+		RequireScope: func(scope string) echo.MiddlewareFunc {
+			return custommiddleware.RequireScope(scope, cfg.Auth.Required)
+		},
+		RequireKey: func(scope string) echo.MiddlewareFunc {
+			return custommiddleware.RequireScope(scope, true)
+		},
+	})
+	qrHandler.Register(e.Group("/api/v1"), custommiddleware.RequireScope(domain.ScopeStatsRead, cfg.Auth.Required))
+	handler.NewImportHandler(
+		urlService,
+		urlValidator,
+		cfg.Import.ChunkSize,
+		time.Duration(cfg.Import.IdleTimeoutSeconds)*time.Second,
+		logger,
+	).Register(e, custommiddleware.RequireScope(domain.ScopeLinksCreate, cfg.Auth.Required))
+	// Exports dump every visible link, so they always need a key.
+	handler.NewExportHandler(
+		urlService,
+		time.Duration(cfg.Export.IdleTimeoutSeconds)*time.Second,
+		logger,
+	).Register(e.Group("/api/v1"), custommiddleware.RequireScope(domain.ScopeStatsRead, true))
+
+	if cfg.Auth.AdminSecret != "" {
+		keyGroup := e.Group("/api/v1/keys", custommiddleware.AdminAuth(cfg.Auth.AdminSecret))
+		handler.NewKeyHandler(service.NewAPIKeyService(apiKeyRepo), logger).Register(keyGroup)
+		logger.Info("api key management enabled", slog.String("path", "/api/v1/keys"))
+
+		webhookGroup := e.Group("/api/v1/webhooks", custommiddleware.AdminAuth(cfg.Auth.AdminSecret))
+		handler.NewWebhookHandler(service.NewWebhookService(webhookRepo, dispatcher), urlValidator, logger).Register(webhookGroup)
+		logger.Info("webhook management enabled", slog.String("path", "/api/v1/webhooks"))
+	}
 
 	if cfg.Pprof.Enabled {
 		pprofGroup := e.Group("/debug/pprof", custommiddleware.PprofAuth(cfg.Pprof.Secret))
@@ -93,6 +188,9 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		logger.Info("pprof endpoints enabled", slog.String("path", "/debug/pprof/*"))
 	}
 
+	// Aliases would be shadowed by, or be mistaken for, the routes above.
+	urlValidator.ReserveAliases(handler.RootSegments(e.Routes())...)
+
 	httpAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	logger.Info("starting HTTP server",
 		slog.String("addr", httpAddr),
@@ -181,6 +279,27 @@ func run(ctx context.Context, logger *slog.Logger) error {
 	return nil
 }
 
+func deleteExpiredIdempotencyKeys(ctx context.Context, repo *repository.IdempotencyRepository, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := repo.DeleteExpired(ctx)
+			if err != nil {
+				logger.Error("failed to delete expired idempotency keys", slog.String("error", err.Error()))
+				continue
+			}
+			if deleted > 0 {
+				logger.Info("deleted expired idempotency keys", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
+
 func collectInfraMetrics(ctx context.Context, recorder *metrics.Recorder, repo *repository.URLRepository, urlCache *cache.URLCache) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
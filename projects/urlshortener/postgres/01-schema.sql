@@ -1,8 +1,162 @@
 -- Sequence for ID generation (used by app for short code generation)
 CREATE SEQUENCE IF NOT EXISTS urls_id_seq;
 
+-- API keys are stored as SHA-256 hashes; prefix identifies a key in listings
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash BYTEA NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
 CREATE TABLE IF NOT EXISTS urls (
     short_code VARCHAR(16) PRIMARY KEY,
     original_url TEXT NOT NULL,
-    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Salted PBKDF2 hash; NULL for links without a password
+    password_hash TEXT,
+    -- API key that created the link; NULL for anonymous links
+    owner_key_id BIGINT REFERENCES api_keys (id),
+    redirect_status SMALLINT NOT NULL DEFAULT 302,
+    -- What happens to the query string of a visit: drop, append or override
+    query_policy VARCHAR(8) NOT NULL DEFAULT 'drop',
+    -- UTM parameters added to every redirect, as a query string
+    utm TEXT,
+    -- Ordered routing rules; original_url is the fallback when none matches
+    rules JSONB,
+    -- Weighted A/B destinations that replace original_url as the fallback
+    variants JSONB,
+    -- Visitors keep their variant through a cookie
+    sticky BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Served in order while original_url fails health probes
+    backups TEXT[],
+    -- Free-form labels for listing and bulk operations
+    tags TEXT[],
+    campaign TEXT,
+    -- Scheduled links redirect only from active_from on; before that visits
+    -- get prelaunch_url, or a not yet available response if it is NULL
+    active_from TIMESTAMPTZ,
+    prelaunch_url TEXT,
+    -- Deleted rows are kept so their short codes are never handed out again
+    deleted_at TIMESTAMPTZ
+);
+
+-- Bring urls tables created by earlier versions up to date. Their columns are
+-- added with the same definitions as above.
+ALTER TABLE urls
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS active BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS password_hash TEXT,
+    ADD COLUMN IF NOT EXISTS owner_key_id BIGINT REFERENCES api_keys (id),
+    ADD COLUMN IF NOT EXISTS redirect_status SMALLINT NOT NULL DEFAULT 302,
+    ADD COLUMN IF NOT EXISTS query_policy VARCHAR(8) NOT NULL DEFAULT 'drop',
+    ADD COLUMN IF NOT EXISTS utm TEXT,
+    ADD COLUMN IF NOT EXISTS rules JSONB,
+    ADD COLUMN IF NOT EXISTS variants JSONB,
+    ADD COLUMN IF NOT EXISTS sticky BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS backups TEXT[],
+    ADD COLUMN IF NOT EXISTS tags TEXT[],
+    ADD COLUMN IF NOT EXISTS campaign TEXT,
+    ADD COLUMN IF NOT EXISTS active_from TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS prelaunch_url TEXT,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- created_at used to be a TIMESTAMP filled in by NOW(), i.e. local time in the
+-- server's time zone, which is also how the conversion reads it. Checked first
+-- because changing the type rewrites the table.
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'urls' AND column_name = 'created_at')
+        = 'timestamp without time zone' THEN
+        ALTER TABLE urls ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+    END IF;
+END
+$$;
+
+-- Lookup of existing links by destination for opt-in deduplication
+CREATE INDEX IF NOT EXISTS urls_original_url_hash_idx ON urls USING HASH (original_url);
+
+-- Listing and bulk operations by tag or campaign
+CREATE INDEX IF NOT EXISTS urls_tags_idx ON urls USING GIN (tags);
+CREATE INDEX IF NOT EXISTS urls_campaign_idx ON urls (campaign, short_code) WHERE campaign IS NOT NULL;
+
+-- Responses of requests made with an Idempotency-Key, replayed on retry.
+-- status_code is NULL while the original request is still running.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    -- Client-chosen key, prefixed with the API key ID for authenticated clients
+    key TEXT PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code SMALLINT,
+    response BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
+
+-- Periodic deletion of expired keys
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys (created_at);
+
+-- Append-only log of every change to a link, kept after the link is deleted.
+-- Creates store the new link and deletes the old one in new_value and
+-- old_value, without the password hash; other changes store only the fields
+-- they changed.
+CREATE TABLE IF NOT EXISTS url_audit (
+    id BIGSERIAL PRIMARY KEY,
+    short_code VARCHAR(16) NOT NULL REFERENCES urls (short_code),
+    -- create, update, delete, deactivate or reactivate
+    action VARCHAR(16) NOT NULL,
+    -- API key that made the change; NULL for anonymous callers
+    actor_key_id BIGINT REFERENCES api_keys (id),
+    actor_ip TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    old_value JSONB,
+    new_value JSONB
+);
+
+CREATE INDEX IF NOT EXISTS url_audit_short_code_idx ON url_audit (short_code, id);
+
+CREATE OR REPLACE FUNCTION url_audit_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'url_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS url_audit_append_only ON url_audit;
+CREATE TRIGGER url_audit_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON url_audit
+    FOR EACH STATEMENT EXECUTE FUNCTION url_audit_append_only();
+
+-- Endpoints that receive link events. The secret signs every delivery, so it
+-- is stored in clear.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Delivery queue with one row per event and subscribed webhook. Pending rows
+-- are retried with exponential backoff and become dead after too many failed
+-- attempts; delivered rows are removed.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    -- pending or dead
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    -- Also pushed forward while a worker holds the row, so no other worker
+    -- sends it at the same time
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status SMALLINT,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status_idx ON webhook_deliveries (status, id);